@@ -0,0 +1,72 @@
+package timeinterval
+
+import (
+	"sync"
+	"time"
+)
+
+type scheduleMode uint8
+
+// ScheduleFixedRate schedules occurrences at the fixed times of the repeating interval, regardless of when
+// previous executions completed.
+const ScheduleFixedRate scheduleMode = 0
+
+// ScheduleFixedDelay schedules the next occurrence one repetition after the previous execution completed.
+const ScheduleFixedDelay scheduleMode = 1
+
+// AdaptiveSchedule wraps a Repeating and computes the next occurrence either at a fixed rate or with a fixed
+// delay after the previous execution completed (matching the semantics of java's ScheduledExecutorService).
+// It is safe for concurrent use.
+type AdaptiveSchedule struct {
+	Repeating Repeating
+	Mode      scheduleMode
+
+	mu            sync.Mutex
+	lastCompleted *time.Time
+}
+
+// NewAdaptiveSchedule returns an AdaptiveSchedule for the given repeating interval and mode.
+func NewAdaptiveSchedule(r Repeating, mode scheduleMode) *AdaptiveSchedule {
+	return &AdaptiveSchedule{Repeating: r, Mode: mode}
+}
+
+// Complete records that an execution completed at the given time.
+func (s *AdaptiveSchedule) Complete(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastCompleted = &t
+}
+
+// LastCompleted returns the time the previous execution completed or nil if no completion has been recorded.
+func (s *AdaptiveSchedule) LastCompleted() *time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastCompleted == nil {
+		return nil
+	}
+	t := *s.lastCompleted
+	return &t
+}
+
+// Next returns the time of the next occurrence relative to the given time or nil if the schedule has ended.
+//
+// With ScheduleFixedRate this is identical to Repeating#Next.
+// With ScheduleFixedDelay the next occurrence is one repetition after the last recorded completion.
+// This time may be before the given time when an execution is overdue. Until a completion has been recorded
+// the fixed rate occurrences are used.
+func (s *AdaptiveSchedule) Next(t time.Time) *time.Time {
+	completed := s.LastCompleted()
+	if s.Mode != ScheduleFixedDelay || completed == nil || !s.Repeating.Started(t) {
+		return s.Repeating.Next(t)
+	}
+	var nxt time.Time
+	if s.Repeating.Interval.Period != nil {
+		nxt = s.Repeating.Interval.Period.AddTo(*completed)
+	} else {
+		nxt = completed.Add(s.Repeating.RepeatEvery())
+	}
+	if s.Repeating.Ended(nxt) {
+		return nil
+	}
+	return &nxt
+}
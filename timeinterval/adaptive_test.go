@@ -0,0 +1,46 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveSchedule_Next(t *testing.T) {
+	r, err := ParseRepeatingIntervalISO8601("R10/2019-01-02T21:00:00Z/PT15M")
+	assert.Nil(t, err)
+	startsAt := r.Interval.StartsAt
+	now := startsAt.Add(20 * time.Minute)
+
+	rate := NewAdaptiveSchedule(*r, ScheduleFixedRate)
+	delay := NewAdaptiveSchedule(*r, ScheduleFixedDelay)
+	assert.Nil(t, delay.LastCompleted())
+	assert.Equal(t, startsAt.Add(30*time.Minute), *delay.Next(now))
+
+	rate.Complete(startsAt.Add(20 * time.Minute))
+	delay.Complete(startsAt.Add(20 * time.Minute))
+	assert.Equal(t, startsAt.Add(20*time.Minute), *delay.LastCompleted())
+	assert.Equal(t, startsAt.Add(30*time.Minute), *rate.Next(now))
+	assert.Equal(t, startsAt.Add(35*time.Minute), *delay.Next(now))
+
+	// Overdue executions are reported as such.
+	assert.Equal(t, startsAt.Add(35*time.Minute), *delay.Next(startsAt.Add(time.Hour)))
+
+	// Occurrences after the repeating interval ended are not returned.
+	delay.Complete(r.EndsAt().Add(-time.Minute))
+	assert.Nil(t, delay.Next(r.EndsAt().Add(-time.Minute)))
+
+	// Before the schedule starts the start is returned.
+	assert.Equal(t, startsAt, *delay.Next(startsAt.Add(-time.Hour)))
+}
+
+func TestAdaptiveSchedule_NextPeriod(t *testing.T) {
+	r, err := ParseRepeatingIntervalISO8601("R/2019-01-31T10:00:00Z/P1M")
+	assert.Nil(t, err)
+	s := NewAdaptiveSchedule(*r, ScheduleFixedDelay)
+	completed, err := time.Parse(time.RFC3339, "2019-03-31T12:00:00Z")
+	assert.Nil(t, err)
+	s.Complete(completed)
+	assert.Equal(t, "2019-04-30T12:00:00Z", s.Next(completed).Format(time.RFC3339))
+}
@@ -0,0 +1,169 @@
+package timeinterval
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// binaryPeriodFlag is set in the format byte of the binary encoding when the interval has a calendar Period.
+const binaryPeriodFlag = 0x80
+
+// binaryIntervalSize is the size of the binary encoding of an interval without Period.
+const binaryIntervalSize = 17
+
+// binaryPeriodSize is the size of the calendar Period appended to the binary encoding of an interval.
+const binaryPeriodSize = 20
+
+// MarshalBinary encodes the interval as its format byte followed by the start and end as big-endian int64 Unix
+// nanoseconds (17 bytes). Intervals with a calendar Period additionally hold its years, months and days as int32 and
+// its time as int64 (20 bytes). Time zones and Meta are not encoded. See: encoding.BinaryMarshaler.
+func (in Interval) MarshalBinary() ([]byte, error) {
+	b := make([]byte, binaryIntervalSize, binaryIntervalSize+binaryPeriodSize)
+	b[0] = byte(in.Format)
+	if !in.OpenStart() {
+		if in.StartsAt.Before(minUnixNano) || in.StartsAt.After(maxUnixNano) {
+			return nil, errors.New("start cannot be represented in Unix nanoseconds")
+		}
+		binary.BigEndian.PutUint64(b[1:9], uint64(in.StartsAt.UnixNano()))
+	}
+	if !in.OpenEnd() {
+		if in.EndsAt.Before(minUnixNano) || in.EndsAt.After(maxUnixNano) {
+			return nil, errors.New("end cannot be represented in Unix nanoseconds")
+		}
+		binary.BigEndian.PutUint64(b[9:17], uint64(in.EndsAt.UnixNano()))
+	}
+	if p := in.Period; p != nil {
+		b[0] |= binaryPeriodFlag
+		var period [binaryPeriodSize]byte
+		binary.BigEndian.PutUint32(period[0:4], uint32(int32(p.Years)))
+		binary.BigEndian.PutUint32(period[4:8], uint32(int32(p.Months)))
+		binary.BigEndian.PutUint32(period[8:12], uint32(int32(p.Days)))
+		binary.BigEndian.PutUint64(period[12:20], uint64(p.Time))
+		b = append(b, period[:]...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes an interval encoded by MarshalBinary. The times are in UTC.
+// See: encoding.BinaryUnmarshaler.
+func (in *Interval) UnmarshalBinary(data []byte) error {
+	i, n, err := decodeInterval(data)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return errors.New("invalid binary interval length")
+	}
+	in.assign(i)
+	return nil
+}
+
+// decodeInterval decodes an interval encoded by MarshalBinary at the start of data and returns the number of bytes
+// it used.
+func decodeInterval(data []byte) (Interval, int, error) {
+	if len(data) < binaryIntervalSize {
+		return Interval{}, 0, errors.New("invalid binary interval length")
+	}
+	in := Interval{Format: isoFormat(data[0] &^ binaryPeriodFlag)}
+	if in.Format > ISOFormatOpenEnd {
+		return Interval{}, 0, errors.New("invalid binary interval format")
+	}
+	in.StartsAt = time.Unix(0, int64(binary.BigEndian.Uint64(data[1:9]))).UTC()
+	in.EndsAt = time.Unix(0, int64(binary.BigEndian.Uint64(data[9:17]))).UTC()
+	if in.OpenStart() {
+		in.StartsAt = openStart
+	}
+	if in.OpenEnd() {
+		in.EndsAt = openEnd
+	}
+	n := binaryIntervalSize
+	if data[0]&binaryPeriodFlag != 0 {
+		if len(data) < binaryIntervalSize+binaryPeriodSize {
+			return Interval{}, 0, errors.New("invalid binary interval length")
+		}
+		period := data[binaryIntervalSize:]
+		in.Period = &Period{
+			Years:  int(int32(binary.BigEndian.Uint32(period[0:4]))),
+			Months: int(int32(binary.BigEndian.Uint32(period[4:8]))),
+			Days:   int(int32(binary.BigEndian.Uint32(period[8:12]))),
+			Time:   time.Duration(binary.BigEndian.Uint64(period[12:20])),
+		}
+		n += binaryPeriodSize
+	}
+	return in, n, in.Validate()
+}
+
+// MarshalBinary encodes the repeating interval as a byte holding its repeat format (0 for "R", 1 for "R-1" and
+// 2 for a repetition count), the repetition count as big-endian uint32 if it has one, and the binary encoding
+// of its interval. See: Interval.MarshalBinary.
+func (in Repeating) MarshalBinary() ([]byte, error) {
+	interval, err := in.Interval.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if in.Repetitions == nil {
+		return append([]byte{byte(in.Format)}, interval...), nil
+	}
+	b := make([]byte, 5, 5+len(interval))
+	b[0] = 2
+	binary.BigEndian.PutUint32(b[1:5], *in.Repetitions)
+	return append(b, interval...), nil
+}
+
+// UnmarshalBinary decodes a repeating interval encoded by MarshalBinary. The times are in UTC.
+// See: encoding.BinaryUnmarshaler.
+func (in *Repeating) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("invalid binary repeating interval length")
+	}
+	r := Repeating{Reference: in.Reference}
+	offset := 1
+	switch data[0] {
+	case byte(RepeatFormatOmitted), byte(RepeatFormatMinusOne):
+		r.Format = repeatFormat(data[0])
+	case 2:
+		if len(data) < 5 {
+			return errors.New("invalid binary repeating interval length")
+		}
+		reps := binary.BigEndian.Uint32(data[1:5])
+		r.Repetitions = &reps
+		offset = 5
+	default:
+		return errors.New("invalid binary repeat format")
+	}
+	i, n, err := decodeInterval(data[offset:])
+	if err != nil {
+		return err
+	}
+	if offset+n != len(data) {
+		return errors.New("invalid binary repeating interval length")
+	}
+	if err := validateRepeating(i, ParseOptions{}); err != nil {
+		return err
+	}
+	r.Interval = in.Interval
+	r.Interval.assign(i)
+	*in = r
+	return nil
+}
+
+// GobEncode encodes the interval like MarshalBinary. See: gob.GobEncoder.
+func (in Interval) GobEncode() ([]byte, error) {
+	return in.MarshalBinary()
+}
+
+// GobDecode decodes an interval encoded by GobEncode. See: gob.GobDecoder.
+func (in *Interval) GobDecode(data []byte) error {
+	return in.UnmarshalBinary(data)
+}
+
+// GobEncode encodes the repeating interval like MarshalBinary. See: gob.GobEncoder.
+func (in Repeating) GobEncode() ([]byte, error) {
+	return in.MarshalBinary()
+}
+
+// GobDecode decodes a repeating interval encoded by GobEncode. See: gob.GobDecoder.
+func (in *Repeating) GobDecode(data []byte) error {
+	return in.UnmarshalBinary(data)
+}
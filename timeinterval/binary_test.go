@@ -0,0 +1,109 @@
+package timeinterval
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval_MarshalBinary(t *testing.T) {
+	expectations := map[string]int{
+		"2019-01-02T21:00:00Z/2022-01-03T21:00:00Z": 17,
+		"2019-01-02T21:00:00Z/PT1H":                 17,
+		"PT1H/2019-01-02T21:00:00Z":                 17,
+		"2019-01-31T21:00:00Z/P1M":                  37,
+		"2019-01-02T21:00:00Z/..":                   17,
+		"../2019-01-02T21:00:00Z":                   17,
+	}
+	for given, size := range expectations {
+		in := MustParseIntervalISO8601(given)
+		b, err := in.MarshalBinary()
+		assert.Nil(t, err)
+		assert.Len(t, b, size, given)
+		var result Interval
+		assert.Nil(t, result.UnmarshalBinary(b), given)
+		iso, err := result.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, given, iso)
+	}
+
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00.000000001+01:00/PT1H")
+	b, err := in.MarshalBinary()
+	assert.Nil(t, err)
+	var result Interval
+	assert.Nil(t, result.UnmarshalBinary(b))
+	assert.True(t, in.StartsAt.Equal(result.StartsAt))
+	assert.Equal(t, time.UTC, result.StartsAt.Location())
+
+	_, err = MustParseIntervalISO8601("2300-01-02T21:00:00Z/PT1H").MarshalBinary()
+	assert.NotNil(t, err)
+	assert.NotNil(t, result.UnmarshalBinary([]byte{1, 2, 3}))
+	assert.NotNil(t, result.UnmarshalBinary(make([]byte, 18)))
+	b = make([]byte, 17)
+	b[0] = 9
+	assert.NotNil(t, result.UnmarshalBinary(b))
+}
+
+func TestRepeating_MarshalBinary(t *testing.T) {
+	expectations := map[string]int{
+		"R5/2019-01-02T21:00:00Z/PT15M": 22,
+		"R/2019-01-02T21:00:00Z/PT15M":  18,
+		"R-1/2019-01-02T21:00:00Z/P1M":  38,
+	}
+	for given, size := range expectations {
+		r := MustParseRepeatingIntervalISO8601(given)
+		b, err := r.MarshalBinary()
+		assert.Nil(t, err)
+		assert.Len(t, b, size, given)
+		result := Repeating{Reference: OccurrenceEnd}
+		assert.Nil(t, result.UnmarshalBinary(b), given)
+		assert.Equal(t, OccurrenceEnd, result.Reference)
+		iso, err := result.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, given, iso)
+	}
+
+	var r Repeating
+	assert.NotNil(t, r.UnmarshalBinary(nil))
+	assert.NotNil(t, r.UnmarshalBinary([]byte{3}))
+	assert.NotNil(t, r.UnmarshalBinary([]byte{2, 0}))
+	open, err := MustParseIntervalISO8601("2019-01-02T21:00:00Z/..").MarshalBinary()
+	assert.Nil(t, err)
+	assert.NotNil(t, r.UnmarshalBinary(append([]byte{0}, open...)))
+	zero, err := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT0S").MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, ErrZeroLengthRepeating, r.UnmarshalBinary(append([]byte{0}, zero...)))
+}
+
+func TestGob(t *testing.T) {
+	type message struct {
+		Name     string
+		Window   Interval
+		Schedule *Repeating
+		Open     []Interval
+	}
+	given := message{
+		Name:     "backup",
+		Window:   *MustParseIntervalISO8601("2019-01-31T21:00:00Z/P1M"),
+		Schedule: MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT15M"),
+		Open:     []Interval{*MustParseIntervalISO8601("2019-01-02T21:00:00Z/..")},
+	}
+	var buf bytes.Buffer
+	assert.Nil(t, gob.NewEncoder(&buf).Encode(given))
+	var result message
+	assert.Nil(t, gob.NewDecoder(&buf).Decode(&result))
+	assert.Equal(t, given, result)
+
+	buf.Reset()
+	assert.Nil(t, gob.NewEncoder(&buf).Encode(struct{ Window []byte }{Window: []byte{1, 2, 3}}))
+	assert.NotNil(t, gob.NewDecoder(&buf).Decode(&result))
+
+	// Zero-length repeating intervals are rejected like by ParseRepeatingIntervalISO8601.
+	buf.Reset()
+	zero := Repeating{Interval: *MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT0S")}
+	assert.Nil(t, gob.NewEncoder(&buf).Encode(message{Window: given.Window, Schedule: &zero}))
+	assert.NotNil(t, gob.NewDecoder(&buf).Decode(&result))
+}
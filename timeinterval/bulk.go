@@ -0,0 +1,88 @@
+package timeinterval
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LineError describes an interval that failed to parse in bulk. See: ParseIntervalsISO8601.
+type LineError struct {
+	// Line is the 1-based position of the interval in the input.
+	Line int
+	// Input is the string that failed to parse.
+	Input string
+	// Err is the parse error.
+	Err error
+	// Offset is the byte offset in the interval string at which its invalid part begins. See: ParseError.Offset
+	Offset int
+}
+
+// Error returns the parse error prefixed with the line.
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// LineErrors aggregates the errors of intervals that failed to parse in bulk.
+type LineErrors []LineError
+
+// Error returns the number of failed intervals and the first error.
+func (e LineErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%d intervals failed to parse, first %v", len(e), e[0])
+}
+
+// ParseIntervalsISO8601 parses each string as an ISO8601 "interval". It returns the intervals that parsed
+// successfully in order and, if any failed, LineErrors describing the failures.
+// Unlike ParseIntervalISO8601, no correction suggestions are computed for failures, which keeps bulk parsing fast.
+func ParseIntervalsISO8601(ss []string) ([]Interval, error) {
+	result := make([]Interval, 0, len(ss))
+	var errs LineErrors
+	for i, s := range ss {
+		in, err := parseInterval(s, StrictParseOptions)
+		if err != nil {
+			offset, err := splitOffset(err)
+			errs = append(errs, LineError{Line: i + 1, Input: s, Err: err, Offset: offset})
+			continue
+		}
+		result = append(result, *in)
+	}
+	if len(errs) > 0 {
+		return result, errs
+	}
+	return result, nil
+}
+
+// ReadIntervalsISO8601 reads one ISO8601 "interval" per line from r and calls fn with each interval that parsed
+// successfully, without holding the intervals in memory. Blank lines are skipped and surrounding whitespace is
+// ignored. Lines that fail to parse are returned as LineErrors after the input was read. Reading stops at the first
+// error returned by r or fn, which is returned instead.
+func ReadIntervalsISO8601(r io.Reader, fn func(line int, in Interval) error) error {
+	scanner := bufio.NewScanner(r)
+	var errs LineErrors
+	for line := 1; scanner.Scan(); line++ {
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" {
+			continue
+		}
+		in, err := parseInterval(s, StrictParseOptions)
+		if err != nil {
+			offset, err := splitOffset(err)
+			errs = append(errs, LineError{Line: line, Input: s, Err: err, Offset: offset})
+			continue
+		}
+		if err := fn(line, *in); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package timeinterval
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIntervalsISO8601(t *testing.T) {
+	result, err := ParseIntervalsISO8601([]string{
+		"2019-01-02T21:00:00Z/P1D",
+		"2019-01-02T21:00:00Z/P1H",
+		"2019-01-02T21:00:00Z/2019-01-03T21:00:00Z",
+		"P1D/P1D",
+	})
+	assert.Len(t, result, 2)
+	assert.Equal(t, *MustParseIntervalISO8601("2019-01-02T21:00:00Z/2019-01-03T21:00:00Z"), result[1])
+	errs, ok := err.(LineErrors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, 2, errs[0].Line)
+	assert.Equal(t, "2019-01-02T21:00:00Z/P1H", errs[0].Input)
+	assert.Equal(t, 23, errs[0].Offset)
+	assert.Equal(t, 4, errs[1].Line)
+	assert.EqualError(t, err, "2 intervals failed to parse, first line 2: invalid duration format")
+
+	result, err = ParseIntervalsISO8601([]string{"2019-01-02T21:00:00Z/P1D"})
+	assert.Nil(t, err)
+	assert.Len(t, result, 1)
+}
+
+func TestReadIntervalsISO8601(t *testing.T) {
+	input := "2019-01-02T21:00:00Z/P1D\n\n  2019-01-03T21:00:00Z/PT1H  \ninvalid\n"
+	var lines []int
+	var intervals []Interval
+	err := ReadIntervalsISO8601(strings.NewReader(input), func(line int, in Interval) error {
+		lines = append(lines, line)
+		intervals = append(intervals, in)
+		return nil
+	})
+	assert.EqualError(t, err, "line 4: invalid interval format")
+	assert.Equal(t, []int{1, 3}, lines)
+	assert.Equal(t, *MustParseIntervalISO8601("2019-01-03T21:00:00Z/PT1H"), intervals[1])
+
+	stop := errors.New("stop")
+	calls := 0
+	err = ReadIntervalsISO8601(strings.NewReader(input), func(line int, in Interval) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,16 @@
+package timeinterval
+
+import "time"
+
+// MatchesCalDAVTimeRange returns a boolean indicating if the interval matches a CalDAV time-range filter
+// with the semantics of a VEVENT with DTSTART and DTEND. See: RFC 4791 section 9.9.
+//
+// A zero filterStart or filterEnd leaves the time-range open-ended in that direction.
+// Zero length intervals match when they start within [filterStart, filterEnd).
+func MatchesCalDAVTimeRange(in Interval, filterStart, filterEnd time.Time) bool {
+	startsBeforeFilterEnds := filterEnd.IsZero() || in.StartsAt.Before(filterEnd)
+	if in.Duration() == 0 {
+		return startsBeforeFilterEnds && (filterStart.IsZero() || !in.StartsAt.Before(filterStart))
+	}
+	return startsBeforeFilterEnds && (filterStart.IsZero() || in.EndsAt.After(filterStart))
+}
@@ -0,0 +1,40 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustTime(t *testing.T, rfc3339 string) time.Time {
+	tm, err := time.Parse(time.RFC3339, rfc3339)
+	assert.Nil(t, err)
+	return tm
+}
+
+func TestMatchesCalDAVTimeRange(t *testing.T) {
+	in, err := ParseIntervalISO8601("2019-01-02T10:00:00Z/2019-01-02T12:00:00Z")
+	assert.Nil(t, err)
+	expectations := []struct {
+		start, end time.Time
+		expected   bool
+	}{
+		{mustTime(t, "2019-01-02T09:00:00Z"), mustTime(t, "2019-01-02T11:00:00Z"), true},
+		{mustTime(t, "2019-01-02T11:00:00Z"), mustTime(t, "2019-01-02T13:00:00Z"), true},
+		{mustTime(t, "2019-01-02T08:00:00Z"), mustTime(t, "2019-01-02T10:00:00Z"), false},
+		{mustTime(t, "2019-01-02T12:00:00Z"), mustTime(t, "2019-01-02T13:00:00Z"), false},
+		{time.Time{}, mustTime(t, "2019-01-02T10:00:01Z"), true},
+		{time.Time{}, mustTime(t, "2019-01-02T10:00:00Z"), false},
+		{mustTime(t, "2019-01-02T11:59:59Z"), time.Time{}, true},
+		{mustTime(t, "2019-01-02T12:00:00Z"), time.Time{}, false},
+		{time.Time{}, time.Time{}, true},
+	}
+	for _, e := range expectations {
+		assert.Equal(t, e.expected, MatchesCalDAVTimeRange(*in, e.start, e.end), "%v - %v", e.start, e.end)
+	}
+
+	instant := Interval{StartsAt: mustTime(t, "2019-01-02T10:00:00Z"), EndsAt: mustTime(t, "2019-01-02T10:00:00Z"), Format: ISOFormatTimeAndTime}
+	assert.True(t, MatchesCalDAVTimeRange(instant, mustTime(t, "2019-01-02T10:00:00Z"), mustTime(t, "2019-01-02T11:00:00Z")))
+	assert.False(t, MatchesCalDAVTimeRange(instant, mustTime(t, "2019-01-02T09:00:00Z"), mustTime(t, "2019-01-02T10:00:00Z")))
+}
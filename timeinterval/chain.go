@@ -0,0 +1,109 @@
+package timeinterval
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrChainCycle is returned by Chain when schedules depend on each other in a cycle.
+var ErrChainCycle = errors.New("schedule chain contains a cycle")
+
+// Chain models simple pipeline dependencies between named schedules: a dependent step starts when the corresponding
+// occurrence of the step it depends on has completed (after its runtime) plus an offset.
+// Steps may be added in any order; dependencies are resolved by Schedule and Validate.
+type Chain struct {
+	steps map[string]chainStep
+}
+
+type chainStep struct {
+	schedule   Schedule
+	dependency string
+	offset     time.Duration
+	runtime    time.Duration
+}
+
+// NewChain returns an empty Chain.
+func NewChain() *Chain {
+	return &Chain{steps: map[string]chainStep{}}
+}
+
+// Add adds an independent step with occurrences given by the schedule and taking runtime to complete.
+func (c *Chain) Add(name string, s Schedule, runtime time.Duration) error {
+	if s == nil {
+		return errors.New("schedule cannot be nil")
+	}
+	return c.add(name, chainStep{schedule: s, runtime: runtime})
+}
+
+// After adds a step starting offset after the corresponding occurrence of the dependency has completed and
+// taking runtime to complete.
+func (c *Chain) After(name, dependency string, offset, runtime time.Duration) error {
+	return c.add(name, chainStep{dependency: dependency, offset: offset, runtime: runtime})
+}
+
+func (c *Chain) add(name string, step chainStep) error {
+	if _, ok := c.steps[name]; ok {
+		return fmt.Errorf("step %q already exists", name)
+	}
+	if step.runtime < 0 {
+		return errors.New("runtime cannot be negative")
+	}
+	c.steps[name] = step
+	return nil
+}
+
+// Schedule returns the schedule of the named step. The occurrences of a dependent step are the occurrences of the
+// independent step it (transitively) depends on, shifted by the runtimes and offsets along the chain.
+func (c *Chain) Schedule(name string) (Schedule, error) {
+	var shift time.Duration
+	visited := map[string]bool{}
+	for {
+		step, ok := c.steps[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown step %q", name)
+		}
+		if visited[name] {
+			return nil, ErrChainCycle
+		}
+		visited[name] = true
+		if step.schedule != nil {
+			return shiftedSchedule{schedule: step.schedule, shift: shift}, nil
+		}
+		dependency := c.steps[step.dependency]
+		shift += dependency.runtime + step.offset
+		name = step.dependency
+	}
+}
+
+// Validate returns an error if a step depends on an unknown step or the steps depend on each other in a cycle.
+func (c *Chain) Validate() error {
+	names := make([]string, 0, len(c.steps))
+	for name := range c.steps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := c.Schedule(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shiftedSchedule is a Schedule with the occurrences of another schedule moved by a fixed duration.
+type shiftedSchedule struct {
+	schedule Schedule
+	shift    time.Duration
+}
+
+// Next returns the time of the first shifted occurrence after the given time.
+func (s shiftedSchedule) Next(t time.Time) *time.Time {
+	nxt := s.schedule.Next(t.Add(-s.shift))
+	if nxt == nil {
+		return nil
+	}
+	shifted := nxt.Add(s.shift)
+	return &shifted
+}
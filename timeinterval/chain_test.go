@@ -0,0 +1,61 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain_Schedule(t *testing.T) {
+	c := NewChain()
+	// Steps may be added before the step they depend on.
+	assert.Nil(t, c.After("load", "transform", 5*time.Minute, 10*time.Minute))
+	assert.Nil(t, c.After("transform", "extract", 0, 20*time.Minute))
+	assert.Nil(t, c.Add("extract", MustParseRepeatingIntervalISO8601("R/2019-01-02T01:00:00Z/P1D"), 30*time.Minute))
+	assert.Nil(t, c.Validate())
+
+	from := time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC)
+	expectations := map[string]time.Time{
+		"extract":   time.Date(2019, 1, 3, 1, 0, 0, 0, time.UTC),
+		"transform": time.Date(2019, 1, 3, 1, 30, 0, 0, time.UTC),
+		"load":      time.Date(2019, 1, 3, 1, 55, 0, 0, time.UTC),
+	}
+	for name, expected := range expectations {
+		s, err := c.Schedule(name)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, *s.Next(from), name)
+		// The occurrence after the first one belongs to the next day.
+		assert.Equal(t, expected.AddDate(0, 0, 1), *s.Next(expected), name)
+	}
+
+	s, err := c.Schedule("load")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 1, 3, 1, 55, 0, 0, time.UTC), *s.Next(time.Date(2019, 1, 3, 1, 40, 0, 0, time.UTC)))
+}
+
+func TestChain_Bounded(t *testing.T) {
+	c := NewChain()
+	assert.Nil(t, c.Add("a", MustParseRepeatingIntervalISO8601("R1/2019-01-02T01:00:00Z/P1D"), time.Hour))
+	assert.Nil(t, c.After("b", "a", 0, time.Hour))
+	s, err := c.Schedule("b")
+	assert.Nil(t, err)
+	assert.Nil(t, s.Next(time.Date(2019, 1, 4, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestChain_Invalid(t *testing.T) {
+	c := NewChain()
+	assert.Nil(t, c.After("a", "b", 0, time.Minute))
+	assert.Nil(t, c.After("b", "c", 0, time.Minute))
+	assert.Nil(t, c.After("c", "a", 0, time.Minute))
+	assert.Equal(t, ErrChainCycle, c.Validate())
+	_, err := c.Schedule("a")
+	assert.Equal(t, ErrChainCycle, err)
+
+	c = NewChain()
+	assert.Nil(t, c.After("a", "missing", 0, time.Minute))
+	assert.EqualError(t, c.Validate(), `unknown step "missing"`)
+	assert.EqualError(t, c.After("a", "b", 0, time.Minute), `step "a" already exists`)
+	assert.EqualError(t, c.Add("b", nil, time.Minute), "schedule cannot be nil")
+	assert.EqualError(t, c.Add("c", MustParseRepeatingIntervalISO8601("R/2019-01-02T01:00:00Z/P1D"), -time.Minute), "runtime cannot be negative")
+}
@@ -0,0 +1,54 @@
+package timeinterval
+
+import (
+	"errors"
+	"time"
+)
+
+// Claims holds the registered validity claims of tokens such as JWTs (RFC 7519 section 4.1) as Unix times in seconds.
+// A zero value means the claim is absent.
+type Claims struct {
+	NotBefore int64 `json:"nbf,omitempty"`
+	ExpiresAt int64 `json:"exp,omitempty"`
+}
+
+// AsClaims returns the interval as token validity claims. Open bounds are absent claims and fractional seconds are
+// truncated.
+func (in Interval) AsClaims() Claims {
+	c := Claims{}
+	if !in.OpenStart() {
+		c.NotBefore = in.StartsAt.Unix()
+	}
+	if !in.OpenEnd() {
+		c.ExpiresAt = in.EndsAt.Unix()
+	}
+	return c
+}
+
+// FromClaims returns the interval a token with the given "nbf" and "exp" claims is valid for. A zero claim is absent
+// and leaves the interval open in that direction. The times are in UTC.
+func FromClaims(nbf, exp int64) (*Interval, error) {
+	switch {
+	case nbf == 0 && exp == 0:
+		return nil, errors.New("claims must have nbf or exp")
+	case nbf == 0:
+		return NewOpenStartInterval(time.Unix(exp, 0).UTC()), nil
+	case exp == 0:
+		return NewOpenEndInterval(time.Unix(nbf, 0).UTC()), nil
+	}
+	startsAt, endsAt := time.Unix(nbf, 0).UTC(), time.Unix(exp, 0).UTC()
+	return NewInterval(&startsAt, &endsAt, nil)
+}
+
+// ValidNow returns a boolean indicating if a token valid for the interval is valid now, allowing for the given
+// clock skew between the issuer and the verifier. Like the "exp" claim, the end itself is not valid.
+func (in Interval) ValidNow(skew time.Duration) bool {
+	return in.validAt(time.Now(), skew)
+}
+
+func (in Interval) validAt(t time.Time, skew time.Duration) bool {
+	if !in.OpenStart() && t.Before(in.StartsAt.Add(-skew)) {
+		return false
+	}
+	return in.OpenEnd() || t.Before(in.EndsAt.Add(skew))
+}
@@ -0,0 +1,59 @@
+package timeinterval
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval_AsClaims(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00.5Z/PT1H")
+	c := in.AsClaims()
+	assert.Equal(t, Claims{NotBefore: 1546462800, ExpiresAt: 1546466400}, c)
+	b, err := json.Marshal(c)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"nbf":1546462800,"exp":1546466400}`, string(b))
+
+	b, err = json.Marshal(NewOpenEndInterval(in.StartsAt).AsClaims())
+	assert.Nil(t, err)
+	assert.Equal(t, `{"nbf":1546462800}`, string(b))
+}
+
+func TestFromClaims(t *testing.T) {
+	in, err := FromClaims(1546462800, 1546466400)
+	assert.Nil(t, err)
+	iso, err := in.ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00Z/2019-01-02T22:00:00Z", iso)
+
+	in, err = FromClaims(0, 1546466400)
+	assert.Nil(t, err)
+	assert.True(t, in.OpenStart())
+	in, err = FromClaims(1546462800, 0)
+	assert.Nil(t, err)
+	assert.True(t, in.OpenEnd())
+
+	_, err = FromClaims(0, 0)
+	assert.NotNil(t, err)
+	_, err = FromClaims(1546466400, 1546462800)
+	assert.NotNil(t, err)
+}
+
+func TestInterval_ValidNow(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT1H")
+	expectations := map[time.Time]bool{
+		in.StartsAt.Add(-time.Minute):      false,
+		in.StartsAt.Add(-30 * time.Second): true,
+		in.EndsAt.Add(29 * time.Second):    true,
+		in.EndsAt.Add(30 * time.Second):    false,
+	}
+	for given, expected := range expectations {
+		assert.Equal(t, expected, in.validAt(given, 30*time.Second), given.String())
+	}
+	assert.False(t, in.validAt(in.EndsAt, 0))
+	assert.False(t, in.ValidNow(time.Minute))
+	assert.True(t, NewOpenEndInterval(in.StartsAt).ValidNow(0))
+	assert.True(t, NewOpenStartInterval(time.Now().Add(time.Hour)).ValidNow(0))
+}
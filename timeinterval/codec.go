@@ -0,0 +1,172 @@
+package timeinterval
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type boundStyle uint8
+
+// BoundStyleKeep means the Codec formats intervals in their own Format.
+const BoundStyleKeep boundStyle = 0
+
+// BoundStyleTimes means the Codec formats intervals as Time/Time.
+const BoundStyleTimes boundStyle = 1
+
+// BoundStyleStartAndDuration means the Codec formats intervals as Time/Duration.
+const BoundStyleStartAndDuration boundStyle = 2
+
+// BoundStyleDurationAndEnd means the Codec formats intervals as Duration/Time.
+const BoundStyleDurationAndEnd boundStyle = 3
+
+// Codec parses and formats ISO8601 intervals with settings configured once, e.g. as a package level variable of an
+// application, instead of passing options at every call site.
+type Codec struct {
+	// Location is used for times without a time zone designator when parsing unless Options.DefaultLocation is set.
+	// Times are converted to it when formatting. If nil, times are formatted in their own location.
+	Location *time.Location
+	// Precision is the smallest unit of formatted times and durations, e.g. time.Millisecond. Finer parts are
+	// truncated. Zero means whole seconds.
+	Precision time.Duration
+	// PreferWeeks formats whole weeks with the W designator (e.g. P1W2D instead of P9D).
+	PreferWeeks bool
+	// DecimalComma formats fractions of durations with a comma (e.g. PT0,5S instead of PT0.5S), which ISO8601
+	// prefers. Both separators are accepted when parsing. Fractions of times are formatted with a dot regardless.
+	DecimalComma bool
+	// BoundStyle determines the format of closed intervals. Intervals with an open start or end keep their format.
+	BoundStyle boundStyle
+	// Options controls which deviations from the ISO8601 specification are tolerated when parsing.
+	Options ParseOptions
+}
+
+// Parse parses an ISO8601 "interval" string.
+func (c Codec) Parse(s string) (*Interval, error) {
+	return parseIntervalISO8601(s, c.parseOptions())
+}
+
+// ParseRepeating parses an ISO8601 "repeating interval" string.
+func (c Codec) ParseRepeating(s string) (*Repeating, error) {
+	return parseRepeatingIntervalISO8601(s, c.parseOptions())
+}
+
+func (c Codec) parseOptions() ParseOptions {
+	opts := c.Options
+	if opts.DefaultLocation == nil {
+		opts.DefaultLocation = c.Location
+	}
+	return opts
+}
+
+// Format returns the interval formatted as an ISO8601 interval string.
+func (c Codec) Format(in Interval) (string, error) {
+	format := in.Format
+	if c.BoundStyle != BoundStyleKeep && !in.OpenStart() && !in.OpenEnd() {
+		format = isoFormat(c.BoundStyle)
+	}
+	switch format {
+	case ISOFormatDurationAndTime:
+		d, err := c.formatDuration(in)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s/%s", d, c.formatTime(in.EndsAt)), nil
+	case ISOFormatTimeAndDuration:
+		d, err := c.formatDuration(in)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s/%s", c.formatTime(in.StartsAt), d), nil
+	case ISOFormatOpenStart:
+		return fmt.Sprintf("../%s", c.formatTime(in.EndsAt)), nil
+	case ISOFormatOpenEnd:
+		return fmt.Sprintf("%s/..", c.formatTime(in.StartsAt)), nil
+	default:
+		return fmt.Sprintf("%s/%s", c.formatTime(in.StartsAt), c.formatTime(in.EndsAt)), nil
+	}
+}
+
+// FormatRepeating returns the repeating interval formatted as an ISO8601 repeating interval string.
+func (c Codec) FormatRepeating(r Repeating) (string, error) {
+	iso, err := c.Format(r.Interval)
+	if err != nil {
+		return "", err
+	}
+	return r.prefix() + iso, nil
+}
+
+// precision returns the Precision or one second if it is unset.
+func (c Codec) precision() time.Duration {
+	if c.Precision <= 0 {
+		return time.Second
+	}
+	return c.Precision
+}
+
+// formatTime formats t in the Location with as many fractional digits as needed for the Precision.
+func (c Codec) formatTime(t time.Time) string {
+	if c.Location != nil {
+		t = t.In(c.Location)
+	}
+	p := c.precision()
+	t = t.Truncate(p)
+	switch {
+	case p >= time.Second:
+		return t.Format(time.RFC3339)
+	case p >= time.Millisecond:
+		return t.Format("2006-01-02T15:04:05.000Z07:00")
+	case p >= time.Microsecond:
+		return t.Format("2006-01-02T15:04:05.000000Z07:00")
+	}
+	return t.Format("2006-01-02T15:04:05.000000000Z07:00")
+}
+
+// formatDuration formats the Period of the interval or otherwise its Duration truncated to the Precision.
+func (c Codec) formatDuration(in Interval) (string, error) {
+	iso, err := c.formatDurationComponents(in)
+	if err != nil || !c.DecimalComma {
+		return iso, err
+	}
+	// Only the seconds of a duration can be fractional.
+	return strings.Replace(iso, ".", ",", 1), nil
+}
+
+// formatDurationComponents formats the duration of the interval with a dot as the decimal separator.
+func (c Codec) formatDurationComponents(in Interval) (string, error) {
+	if in.Period != nil {
+		p := *in.Period
+		p.Time = p.Time.Truncate(c.precision())
+		if !c.PreferWeeks || p.Days < 7 || p.Time < 0 {
+			return p.ISO8601()
+		}
+		if p.Years < 0 || p.Months < 0 {
+			return "", errors.New("negative periods cannot be represented")
+		}
+		// Write the days as weeks and days, e.g. P1M1W2D instead of P1M9D.
+		iso := "P"
+		if p.Years != 0 {
+			iso += fmt.Sprintf("%dY", p.Years)
+		}
+		if p.Months != 0 {
+			iso += fmt.Sprintf("%dM", p.Months)
+		}
+		iso += fmt.Sprintf("%dW", p.Days/7)
+		if p.Days%7 != 0 {
+			iso += fmt.Sprintf("%dD", p.Days%7)
+		}
+		if p.Time != 0 {
+			iso += "T" + timeComponentsISO8601(p.Time)
+		}
+		return iso, nil
+	}
+	d := in.Duration().Truncate(c.precision())
+	if c.PreferWeeks || d < durationDay {
+		return FormatDurationISO8601(d)
+	}
+	iso := fmt.Sprintf("P%dD", d/durationDay)
+	if rest := d % durationDay; rest != 0 {
+		iso += "T" + timeComponentsISO8601(rest)
+	}
+	return iso, nil
+}
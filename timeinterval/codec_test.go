@@ -0,0 +1,77 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodec_Format(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00.123456Z/P9DT1H")
+	expectations := []struct {
+		codec    Codec
+		expected string
+	}{
+		{Codec{}, "2019-01-02T21:00:00Z/P9DT1H"},
+		{Codec{PreferWeeks: true}, "2019-01-02T21:00:00Z/P1W2DT1H"},
+		{Codec{Precision: time.Millisecond}, "2019-01-02T21:00:00.123Z/P9DT1H"},
+		{Codec{Precision: time.Microsecond}, "2019-01-02T21:00:00.123456Z/P9DT1H"},
+		{Codec{BoundStyle: BoundStyleTimes}, "2019-01-02T21:00:00Z/2019-01-11T22:00:00Z"},
+		{Codec{BoundStyle: BoundStyleDurationAndEnd}, "P9DT1H/2019-01-11T22:00:00Z"},
+		{Codec{Location: time.FixedZone("", 3600)}, "2019-01-02T22:00:00+01:00/P9DT1H"},
+	}
+	for _, e := range expectations {
+		result, err := e.codec.Format(*in)
+		assert.Nil(t, err)
+		assert.Equal(t, e.expected, result)
+	}
+
+	// Periods are formatted with weeks as well.
+	p := MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1M14D")
+	result, err := Codec{PreferWeeks: true}.Format(*p)
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00Z/P1M2W", result)
+
+	// Open intervals keep their format.
+	open := NewOpenEndInterval(in.StartsAt)
+	result, err = Codec{BoundStyle: BoundStyleStartAndDuration}.Format(*open)
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00Z/..", result)
+
+	// Fractions of durations are formatted with a comma and parse again, while times keep the dot.
+	c := Codec{Precision: time.Millisecond, DecimalComma: true}
+	fractional := MustParseIntervalISO8601("2019-01-02T21:00:00.25Z/PT1H0,5S")
+	result, err = c.Format(*fractional)
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00.250Z/PT1H0,5S", result)
+	parsed, err := c.Parse(result)
+	assert.Nil(t, err)
+	assert.True(t, fractional.EndsAt.Equal(parsed.EndsAt))
+	result, err = c.Format(*MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1MT0.5S"))
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00.000Z/P1MT0,5S", result)
+
+	r := MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/2019-01-09T21:00:00Z")
+	result, err = Codec{PreferWeeks: true, BoundStyle: BoundStyleStartAndDuration}.FormatRepeating(*r)
+	assert.Nil(t, err)
+	assert.Equal(t, "R5/2019-01-02T21:00:00Z/P1W", result)
+}
+
+func TestCodec_Parse(t *testing.T) {
+	loc := time.FixedZone("", -5*3600)
+	c := Codec{Location: loc, Options: ParseOptions{AllowLowercase: true}}
+	in, err := c.Parse("2019-01-02t21:00:00/p1d")
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-03T02:00:00Z", in.StartsAt.UTC().Format(time.RFC3339))
+	result, err := c.Format(*in)
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00-05:00/P1D", result)
+
+	r, err := c.ParseRepeating("r3/2019-01-02t21:00:00/pt1h")
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(3), *r.Repetitions)
+
+	_, err = Codec{}.Parse("2019-01-02T21:00:00/P1D")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,153 @@
+package timeinterval
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Selector selects labeled intervals by requiring each of its labels to be present with the given value.
+// An empty Selector selects everything.
+type Selector map[string]string
+
+// ParseSelector parses a comma separated list of key=value pairs (e.g. "team=payments,env=prod") into a Selector.
+func ParseSelector(s string) (Selector, error) {
+	sel := Selector{}
+	if strings.TrimSpace(s) == "" {
+		return sel, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.New("invalid selector format")
+		}
+		key := strings.TrimSpace(kv[0])
+		if key == "" {
+			return nil, errors.New("selector key cannot be empty")
+		}
+		sel[key] = strings.TrimSpace(kv[1])
+	}
+	return sel, nil
+}
+
+// Matches returns a boolean indicating if the given labels satisfy the selector.
+func (sel Selector) Matches(labels map[string]string) bool {
+	for k, v := range sel {
+		if lv, ok := labels[k]; !ok || lv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in the format accepted by ParseSelector with the keys sorted.
+func (sel Selector) String() string {
+	pairs := make([]string, 0, len(sel))
+	for k, v := range sel {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Labeled is an Interval annotated with labels, e.g. team=payments or env=prod.
+type Labeled struct {
+	Interval Interval
+	Labels   map[string]string
+}
+
+// Collection is a set of labeled intervals that can be queried by labels and time.
+// The zero value is an empty collection ready to use.
+type Collection struct {
+	items []Labeled
+}
+
+// NewCollection returns a Collection containing the given labeled intervals.
+func NewCollection(items ...Labeled) *Collection {
+	c := &Collection{}
+	for _, l := range items {
+		c.Add(l)
+	}
+	return c
+}
+
+// Add adds the labeled interval to the collection.
+func (c *Collection) Add(l Labeled) {
+	c.items = append(c.items, l)
+}
+
+// Len returns the number of labeled intervals in the collection.
+func (c *Collection) Len() int {
+	return len(c.items)
+}
+
+// Items returns a copy of the labeled intervals in the collection in the order they were added.
+func (c *Collection) Items() []Labeled {
+	items := make([]Labeled, len(c.items))
+	copy(items, c.items)
+	return items
+}
+
+// Select returns the labeled intervals matching the selector.
+func (c *Collection) Select(sel Selector) []Labeled {
+	return c.filter(func(l Labeled) bool {
+		return sel.Matches(l.Labels)
+	})
+}
+
+// At returns the labeled intervals matching the selector that are active (see: Interval#In) at the given time.
+func (c *Collection) At(t time.Time, sel Selector) []Labeled {
+	return c.filter(func(l Labeled) bool {
+		return sel.Matches(l.Labels) && l.Interval.In(t)
+	})
+}
+
+// Within returns the labeled intervals matching the selector that overlap the given window.
+// Intervals merely touching the window (e.g. ending when the window starts) are not included.
+func (c *Collection) Within(window Interval, sel Selector) []Labeled {
+	return c.filter(func(l Labeled) bool {
+		return sel.Matches(l.Labels) && overlaps(l.Interval, window)
+	})
+}
+
+// StateAt returns the values of the given label of the intervals active (see: Interval#In) at the given time and the
+// next time after it at which an interval starts or ends, so a time scrubber can render what is active and when that
+// changes in one call. Each value is returned once in the order the intervals were added. Intervals without the label
+// are not included in active but are considered for nextChange, which is nil if no interval starts or ends after t.
+func (c *Collection) StateAt(t time.Time, label string) (active []string, nextChange *time.Time) {
+	seen := map[string]bool{}
+	for _, l := range c.items {
+		if v, ok := l.Labels[label]; ok && l.Interval.In(t) && !seen[v] {
+			seen[v] = true
+			active = append(active, v)
+		}
+		boundaries := []time.Time{l.Interval.StartsAt, l.Interval.EndsAt}
+		if l.Interval.OpenEnd() {
+			boundaries = boundaries[:1]
+		}
+		for _, b := range boundaries {
+			if b.After(t) && (nextChange == nil || b.Before(*nextChange)) {
+				b := b
+				nextChange = &b
+			}
+		}
+	}
+	return active, nextChange
+}
+
+func (c *Collection) filter(keep func(Labeled) bool) []Labeled {
+	var result []Labeled
+	for _, l := range c.items {
+		if keep(l) {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// overlaps returns a boolean indicating if the two intervals share a period of time.
+// Intervals are treated as half-open, so intervals that only touch at a boundary do not overlap.
+func overlaps(a, b Interval) bool {
+	return a.StartsAt.Before(b.EndsAt) && b.StartsAt.Before(a.EndsAt)
+}
@@ -0,0 +1,94 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSelector(t *testing.T) {
+	sel, err := ParseSelector("team=payments, env=prod")
+	assert.Nil(t, err)
+	assert.Equal(t, Selector{"team": "payments", "env": "prod"}, sel)
+	assert.Equal(t, "env=prod,team=payments", sel.String())
+
+	sel, err = ParseSelector("")
+	assert.Nil(t, err)
+	assert.Empty(t, sel)
+
+	for _, given := range []string{"team", "=payments", "team=payments,"} {
+		_, err = ParseSelector(given)
+		assert.NotNil(t, err, given)
+	}
+}
+
+func TestSelector_Matches(t *testing.T) {
+	labels := map[string]string{"team": "payments", "env": "prod"}
+	expectations := map[string]bool{
+		"":                       true,
+		"team=payments":          true,
+		"team=payments,env=prod": true,
+		"team=payments,env=dev":  false,
+		"region=eu":              false,
+	}
+	for given, expected := range expectations {
+		sel, err := ParseSelector(given)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, sel.Matches(labels), given)
+	}
+}
+
+func TestCollection(t *testing.T) {
+	a, err := ParseIntervalISO8601("2019-01-02T21:00:00Z/P1D")
+	assert.Nil(t, err)
+	b, err := ParseIntervalISO8601("2019-01-03T21:00:00Z/P1D")
+	assert.Nil(t, err)
+	c := NewCollection(
+		Labeled{Interval: *a, Labels: map[string]string{"team": "payments", "env": "prod"}},
+		Labeled{Interval: *b, Labels: map[string]string{"team": "payments", "env": "dev"}},
+	)
+	c.Add(Labeled{Interval: *b, Labels: map[string]string{"team": "search", "env": "prod"}})
+	assert.Equal(t, 3, c.Len())
+	assert.Len(t, c.Items(), 3)
+
+	assert.Len(t, c.Select(Selector{"team": "payments"}), 2)
+	assert.Len(t, c.Select(Selector{"env": "prod"}), 2)
+	assert.Len(t, c.Select(nil), 3)
+
+	result := c.At(b.StartsAt.Add(time.Hour), Selector{"env": "prod"})
+	assert.Len(t, result, 1)
+	assert.Equal(t, "search", result[0].Labels["team"])
+
+	assert.Len(t, c.Within(*a, Selector{"team": "payments"}), 1)
+	assert.Len(t, c.Within(*b, Selector{"team": "payments"}), 1)
+	assert.Len(t, c.Within(*b, nil), 2)
+}
+
+func TestCollection_StateAt(t *testing.T) {
+	c := NewCollection(
+		Labeled{Interval: *MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT2H"), Labels: map[string]string{"name": "deploy"}},
+		Labeled{Interval: *MustParseIntervalISO8601("2019-01-02T22:00:00Z/PT2H"), Labels: map[string]string{"name": "freeze"}},
+		Labeled{Interval: *MustParseIntervalISO8601("2019-01-02T22:30:00Z/PT1H"), Labels: map[string]string{"name": "deploy"}},
+		Labeled{Interval: *MustParseIntervalISO8601("2019-01-03T01:00:00Z/.."), Labels: map[string]string{"team": "search"}},
+	)
+
+	active, next := c.StateAt(mustTime(t, "2019-01-02T20:00:00Z"), "name")
+	assert.Empty(t, active)
+	assert.Equal(t, mustTime(t, "2019-01-02T21:00:00Z"), *next)
+
+	active, next = c.StateAt(mustTime(t, "2019-01-02T22:45:00Z"), "name")
+	assert.Equal(t, []string{"deploy", "freeze"}, active)
+	assert.Equal(t, mustTime(t, "2019-01-02T23:00:00Z"), *next)
+
+	active, next = c.StateAt(mustTime(t, "2019-01-03T00:00:00Z"), "name")
+	assert.Equal(t, []string{"freeze"}, active)
+	assert.Equal(t, mustTime(t, "2019-01-03T01:00:00Z"), *next)
+
+	active, next = c.StateAt(mustTime(t, "2019-01-03T02:00:00Z"), "name")
+	assert.Empty(t, active)
+	assert.Nil(t, next)
+
+	active, _ = c.StateAt(mustTime(t, "2019-01-03T02:00:00Z"), "team")
+	assert.Equal(t, []string{"search"}, active)
+}
@@ -0,0 +1,97 @@
+package timeinterval
+
+import (
+	"errors"
+	"math"
+)
+
+// ToColumns returns the starts and ends of the intervals as separate columns of Unix nanoseconds, e.g. for an
+// Apache Arrow timestamp[ns] array. Open bounds are the smallest respectively largest int64. An error is returned
+// if a time cannot be represented as int64 Unix nanoseconds (years 1678 to 2262).
+func ToColumns(ins []Interval) (starts, ends []int64, err error) {
+	starts = make([]int64, len(ins))
+	ends = make([]int64, len(ins))
+	for i, in := range ins {
+		starts[i], ends[i], err = in.unixNano()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return starts, ends, nil
+}
+
+// FromColumns returns the intervals held by columns returned by ToColumns. The times are in UTC.
+func FromColumns(starts, ends []int64) ([]Interval, error) {
+	if len(starts) != len(ends) {
+		return nil, errors.New("columns must have the same length")
+	}
+	ins := make([]Interval, len(starts))
+	for i := range starts {
+		in, err := fromUnixNano(starts[i], ends[i])
+		if err != nil {
+			return nil, err
+		}
+		ins[i] = *in
+	}
+	return ins, nil
+}
+
+// OverlappingColumns returns the indexes of the intervals in the columns (see: ToColumns) that overlap the interval
+// from start to end given in Unix nanoseconds. Like Collection.Within, intervals merely touching it do not overlap.
+func OverlappingColumns(starts, ends []int64, start, end int64) []int {
+	var result []int
+	for i := range starts {
+		if starts[i] < end && start < ends[i] {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// ActiveColumns returns the indexes of the intervals in the columns (see: ToColumns) that are active at the
+// given Unix nanoseconds. Like Interval.In, intervals are active at both their start and end.
+func ActiveColumns(starts, ends []int64, t int64) []int {
+	var result []int
+	for i := range starts {
+		if starts[i] <= t && t <= ends[i] {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// ContainsBatch returns for each of the given Unix nanoseconds whether the interval is active (see: Interval.In)
+// at that time. The containment check is a single unsigned comparison without branches, so the loop is suited for
+// filtering large volumes of events against a window.
+func ContainsBatch(in Interval, ts []int64) []bool {
+	start, end := in.clampedUnixNano()
+	result := make([]bool, len(ts))
+	if end < start {
+		return result
+	}
+	width := uint64(end - start)
+	ts = ts[:len(result)]
+	for i := range result {
+		// ts[i]-start wraps around to a value larger than width for ts[i] < start.
+		result[i] = uint64(ts[i]-start) <= width
+	}
+	return result
+}
+
+// clampedUnixNano returns the start and end of the interval as Unix nanoseconds clamped to the int64 range.
+func (in Interval) clampedUnixNano() (int64, int64) {
+	start, end := int64(math.MinInt64), int64(math.MaxInt64)
+	if !in.OpenStart() && !in.StartsAt.Before(minUnixNano) {
+		start = math.MaxInt64
+		if !in.StartsAt.After(maxUnixNano) {
+			start = in.StartsAt.UnixNano()
+		}
+	}
+	if !in.OpenEnd() && !in.EndsAt.After(maxUnixNano) {
+		end = math.MinInt64
+		if !in.EndsAt.Before(minUnixNano) {
+			end = in.EndsAt.UnixNano()
+		}
+	}
+	return start, end
+}
@@ -0,0 +1,95 @@
+package timeinterval
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumns(t *testing.T) {
+	ins := []Interval{
+		*MustParseIntervalISO8601("2019-01-02T21:00:00Z/2019-01-03T21:00:00Z"),
+		*MustParseIntervalISO8601("2019-01-03T21:00:00Z/PT1H"),
+		*MustParseIntervalISO8601("2019-01-04T21:00:00Z/.."),
+		*MustParseIntervalISO8601("../2019-01-02T21:00:00Z"),
+	}
+	starts, ends, err := ToColumns(ins)
+	assert.Nil(t, err)
+	day := time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC).UnixNano()
+	assert.Equal(t, []int64{day, day + int64(durationDay), day + 2*int64(durationDay), math.MinInt64}, starts)
+	assert.Equal(t, []int64{day + int64(durationDay), day + int64(durationDay+time.Hour), math.MaxInt64, day}, ends)
+
+	result, err := FromColumns(starts, ends)
+	assert.Nil(t, err)
+	for i, in := range result {
+		assert.True(t, ins[i].StartsAt.Equal(in.StartsAt))
+		assert.True(t, ins[i].EndsAt.Equal(in.EndsAt))
+		assert.Equal(t, ins[i].OpenStart(), in.OpenStart())
+		assert.Equal(t, ins[i].OpenEnd(), in.OpenEnd())
+	}
+
+	assert.Equal(t, []int{0, 1}, OverlappingColumns(starts, ends, day+int64(time.Hour), day+int64(durationDay+time.Minute)))
+	assert.Equal(t, []int{0, 3}, ActiveColumns(starts, ends, day))
+	assert.Equal(t, []int{2}, ActiveColumns(starts, ends, math.MaxInt64-1))
+
+	_, _, err = ToColumns([]Interval{*MustParseIntervalISO8601("2300-01-02T21:00:00Z/PT1H")})
+	assert.NotNil(t, err)
+	_, err = FromColumns(starts, ends[1:])
+	assert.NotNil(t, err)
+	_, err = FromColumns([]int64{math.MinInt64}, []int64{math.MaxInt64})
+	assert.NotNil(t, err)
+	_, err = FromColumns([]int64{day}, []int64{day - 1})
+	assert.NotNil(t, err)
+}
+
+func TestContainsBatch(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT1H")
+	start := in.StartsAt.UnixNano()
+	end := in.EndsAt.UnixNano()
+	ts := []int64{math.MinInt64, start - 1, start, start + 1, end, end + 1, math.MaxInt64}
+	assert.Equal(t, []bool{false, false, true, true, true, false, false}, ContainsBatch(*in, ts))
+	assert.Equal(t, []bool{false, false, true, true, true, true, true}, ContainsBatch(*NewOpenEndInterval(in.StartsAt), ts))
+	assert.Equal(t, []bool{true, true, true, true, true, false, false}, ContainsBatch(*NewOpenStartInterval(in.EndsAt), ts))
+	assert.Equal(t, []bool{false, false, false, false, false, false, true}, ContainsBatch(*MustParseIntervalISO8601("2300-01-02T21:00:00Z/PT1H"), ts))
+	assert.Equal(t, []bool{false, false, false, false, false, false, false}, ContainsBatch(Interval{StartsAt: in.EndsAt, EndsAt: in.StartsAt}, ts))
+	for i, v := range ContainsBatch(*in, ts) {
+		assert.Equal(t, in.In(time.Unix(0, ts[i])), v)
+	}
+}
+
+func benchmarkTimestamps(in Interval) []int64 {
+	// Events around the window in random order, so that the branches of the naive loop are unpredictable.
+	ts := make([]int64, 4096)
+	rnd := rand.New(rand.NewSource(1))
+	for i := range ts {
+		ts[i] = in.StartsAt.Add(time.Duration(rnd.Intn(2*len(ts))-len(ts)/2) * time.Second).UnixNano()
+	}
+	return ts
+}
+
+func BenchmarkContainsBatch(b *testing.B) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT15M")
+	ts := benchmarkTimestamps(*in)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ContainsBatch(*in, ts)
+	}
+}
+
+func BenchmarkContainsBatch_Naive(b *testing.B) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT15M")
+	ts := benchmarkTimestamps(*in)
+	start, end := in.StartsAt.UnixNano(), in.EndsAt.UnixNano()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := make([]bool, len(ts))
+		for j, t := range ts {
+			if t >= start && t <= end {
+				result[j] = true
+			}
+		}
+	}
+}
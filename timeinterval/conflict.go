@@ -0,0 +1,148 @@
+package timeinterval
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConflictPolicy determines how Collection#Insert resolves overlaps between a new and existing intervals.
+type ConflictPolicy uint8
+
+// ConflictReject rejects the new interval if it overlaps any existing interval.
+const ConflictReject ConflictPolicy = 0
+
+// ConflictMergeSilently replaces the new interval and all overlapping intervals with a single interval covering them all.
+const ConflictMergeSilently ConflictPolicy = 1
+
+// ConflictTruncateNew inserts only the parts of the new interval that do not overlap existing intervals.
+const ConflictTruncateNew ConflictPolicy = 2
+
+// ConflictTruncateExisting inserts the new interval and cuts the overlapping parts out of the existing intervals.
+const ConflictTruncateExisting ConflictPolicy = 3
+
+// ErrConflict is returned by Collection#Insert when the ConflictReject policy rejects an interval.
+var ErrConflict = errors.New("interval conflicts with existing intervals")
+
+// ConflictReport describes the adjustments made to a Collection by Collection#Insert.
+type ConflictReport struct {
+	// Conflicts holds the existing intervals that overlapped the new interval.
+	Conflicts []Labeled
+	// Inserted holds the intervals that were added to the collection.
+	Inserted []Labeled
+	// Removed holds the existing intervals that were removed from the collection.
+	Removed []Labeled
+	// Adjusted holds the remainders of truncated existing intervals that were added back to the collection.
+	Adjusted []Labeled
+}
+
+// HasConflicts returns a boolean indicating if the inserted interval overlapped any existing intervals.
+func (r ConflictReport) HasConflicts() bool {
+	return len(r.Conflicts) > 0
+}
+
+// Insert adds the labeled interval to the collection, resolving overlaps with the given policy.
+// Only existing intervals carrying exactly the same labels as the new interval (e.g. the same resource) can conflict.
+// Overlaps are evaluated as half-open, so intervals that merely touch do not conflict.
+// The returned ConflictReport describes what was adjusted. With ConflictReject, ErrConflict is returned
+// and the collection is left unchanged if any conflicts exist.
+func (c *Collection) Insert(l Labeled, policy ConflictPolicy) (ConflictReport, error) {
+	report := ConflictReport{}
+	var keep []Labeled
+	for _, existing := range c.items {
+		if labelsEqual(existing.Labels, l.Labels) && overlaps(existing.Interval, l.Interval) {
+			report.Conflicts = append(report.Conflicts, existing)
+		} else {
+			keep = append(keep, existing)
+		}
+	}
+	if !report.HasConflicts() {
+		c.Add(l)
+		report.Inserted = []Labeled{l}
+		return report, nil
+	}
+	switch policy {
+	case ConflictReject:
+		return report, ErrConflict
+	case ConflictMergeSilently:
+		merged := l.Interval
+		for _, existing := range report.Conflicts {
+			merged = span(merged, existing.Interval)
+		}
+		if merged.OpenStart() && merged.EndsAt.Equal(openEnd) {
+			return report, errors.New("merged interval would be open at both ends")
+		}
+		report.Removed = report.Conflicts
+		report.Inserted = []Labeled{{Interval: merged, Labels: l.Labels}}
+	case ConflictTruncateNew:
+		pieces := []Interval{l.Interval}
+		for _, existing := range report.Conflicts {
+			var next []Interval
+			for _, piece := range pieces {
+				next = append(next, subtract(piece, existing.Interval)...)
+			}
+			pieces = next
+		}
+		report.Inserted = labelAll(pieces, l.Labels)
+		keep = append(keep, report.Conflicts...)
+	case ConflictTruncateExisting:
+		for _, existing := range report.Conflicts {
+			report.Adjusted = append(report.Adjusted, labelAll(subtract(existing.Interval, l.Interval), existing.Labels)...)
+		}
+		report.Removed = report.Conflicts
+		report.Inserted = []Labeled{l}
+		keep = append(keep, report.Adjusted...)
+	default:
+		return report, fmt.Errorf("unknown conflict policy: %d", policy)
+	}
+	c.items = append(keep, report.Inserted...)
+	return report, nil
+}
+
+func labelAll(ins []Interval, labels map[string]string) []Labeled {
+	var result []Labeled
+	for _, in := range ins {
+		result = append(result, Labeled{Interval: in, Labels: labels})
+	}
+	return result
+}
+
+func labelsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// span returns the smallest interval covering both a and b. It is formatted as Time/Time unless a bound is open
+// (See: boundsFormat). The metadata of both is merged.
+func span(a, b Interval) Interval {
+	startsAt, endsAt := a.StartsAt, a.EndsAt
+	if b.StartsAt.Before(startsAt) {
+		startsAt = b.StartsAt
+	}
+	if b.EndsAt.After(endsAt) {
+		endsAt = b.EndsAt
+	}
+	return Interval{StartsAt: startsAt, EndsAt: endsAt, Format: boundsFormat(startsAt, endsAt), Meta: mergeMeta(a.Meta, b.Meta)}
+}
+
+// subtract returns the non-empty parts of a that are not covered by b. The parts keep the metadata and open bounds
+// of a.
+func subtract(a, b Interval) []Interval {
+	if !overlaps(a, b) {
+		return []Interval{a}
+	}
+	var result []Interval
+	if a.StartsAt.Before(b.StartsAt) {
+		result = append(result, Interval{StartsAt: a.StartsAt, EndsAt: b.StartsAt, Format: boundsFormat(a.StartsAt, b.StartsAt), Meta: a.Meta})
+	}
+	if b.EndsAt.Before(a.EndsAt) {
+		result = append(result, Interval{StartsAt: b.EndsAt, EndsAt: a.EndsAt, Format: boundsFormat(b.EndsAt, a.EndsAt), Meta: a.Meta})
+	}
+	return result
+}
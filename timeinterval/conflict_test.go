@@ -0,0 +1,110 @@
+package timeinterval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustLabeled(t *testing.T, iso string, labels map[string]string) Labeled {
+	in, err := ParseIntervalISO8601(iso)
+	assert.Nil(t, err)
+	return Labeled{Interval: *in, Labels: labels}
+}
+
+func intervalsOf(ls []Labeled) []string {
+	var result []string
+	for _, l := range ls {
+		iso, _ := l.Interval.ISO8601()
+		result = append(result, iso)
+	}
+	return result
+}
+
+func TestCollection_Insert(t *testing.T) {
+	room := map[string]string{"room": "a"}
+	existing := mustLabeled(t, "2019-01-02T10:00:00Z/2019-01-02T12:00:00Z", room)
+	other := mustLabeled(t, "2019-01-02T10:00:00Z/2019-01-02T12:00:00Z", map[string]string{"room": "b"})
+	overlapping := mustLabeled(t, "2019-01-02T11:00:00Z/2019-01-02T13:00:00Z", room)
+	adjacent := mustLabeled(t, "2019-01-02T12:00:00Z/2019-01-02T13:00:00Z", room)
+
+	// Non-conflicting inserts
+	c := NewCollection(existing, other)
+	report, err := c.Insert(adjacent, ConflictReject)
+	assert.Nil(t, err)
+	assert.False(t, report.HasConflicts())
+	assert.Equal(t, []Labeled{adjacent}, report.Inserted)
+	assert.Equal(t, 3, c.Len())
+
+	// Reject
+	c = NewCollection(existing, other)
+	report, err = c.Insert(overlapping, ConflictReject)
+	assert.Equal(t, ErrConflict, err)
+	assert.Equal(t, []Labeled{existing}, report.Conflicts)
+	assert.Empty(t, report.Inserted)
+	assert.Equal(t, 2, c.Len())
+
+	// MergeSilently
+	c = NewCollection(existing, other)
+	report, err = c.Insert(overlapping, ConflictMergeSilently)
+	assert.Nil(t, err)
+	assert.Equal(t, []Labeled{existing}, report.Removed)
+	assert.Equal(t, []string{"2019-01-02T10:00:00Z/2019-01-02T13:00:00Z"}, intervalsOf(report.Inserted))
+	assert.Equal(t, 2, c.Len())
+
+	// TruncateNew
+	c = NewCollection(existing, other)
+	report, err = c.Insert(overlapping, ConflictTruncateNew)
+	assert.Nil(t, err)
+	assert.Empty(t, report.Removed)
+	assert.Equal(t, []string{"2019-01-02T12:00:00Z/2019-01-02T13:00:00Z"}, intervalsOf(report.Inserted))
+	assert.Equal(t, 3, c.Len())
+
+	// TruncateExisting
+	c = NewCollection(existing, other)
+	report, err = c.Insert(overlapping, ConflictTruncateExisting)
+	assert.Nil(t, err)
+	assert.Equal(t, []Labeled{existing}, report.Removed)
+	assert.Equal(t, []string{"2019-01-02T10:00:00Z/2019-01-02T11:00:00Z"}, intervalsOf(report.Adjusted))
+	assert.Equal(t, []Labeled{overlapping}, report.Inserted)
+	assert.Equal(t, 3, c.Len())
+
+	// TruncateNew fully covered
+	inside := mustLabeled(t, "2019-01-02T10:30:00Z/2019-01-02T11:00:00Z", room)
+	c = NewCollection(existing)
+	report, err = c.Insert(inside, ConflictTruncateNew)
+	assert.Nil(t, err)
+	assert.Empty(t, report.Inserted)
+	assert.Equal(t, 1, c.Len())
+
+	// TruncateExisting splitting an existing interval
+	c = NewCollection(existing)
+	report, err = c.Insert(inside, ConflictTruncateExisting)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"2019-01-02T10:00:00Z/2019-01-02T10:30:00Z",
+		"2019-01-02T11:00:00Z/2019-01-02T12:00:00Z",
+	}, intervalsOf(report.Adjusted))
+	assert.Equal(t, 3, c.Len())
+
+	// Open bounds stay open
+	open := mustLabeled(t, "2019-01-02T11:00:00Z/..", room)
+	c = NewCollection(open)
+	report, err = c.Insert(existing, ConflictMergeSilently)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2019-01-02T10:00:00Z/.."}, intervalsOf(report.Inserted))
+	c = NewCollection(existing)
+	report, err = c.Insert(open, ConflictTruncateNew)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2019-01-02T12:00:00Z/.."}, intervalsOf(report.Inserted))
+	c = NewCollection(open)
+	_, err = c.Insert(mustLabeled(t, "../2019-01-02T12:00:00Z", room), ConflictMergeSilently)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, c.Len())
+
+	// Unknown policy
+	c = NewCollection(existing)
+	_, err = c.Insert(inside, ConflictPolicy(42))
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, c.Len())
+}
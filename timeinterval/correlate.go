@@ -0,0 +1,67 @@
+package timeinterval
+
+import "time"
+
+type correlateStrategy uint8
+
+// CorrelateNearest pairs each interval in a with the nearest interval in b. Ties are broken by the earlier start.
+const CorrelateNearest correlateStrategy = 0
+
+// CorrelateAll pairs each interval in a with every interval in b within the maximum gap.
+const CorrelateAll correlateStrategy = 1
+
+// CorrelateFollowing pairs each interval in a with the first interval in b starting at or after its start,
+// e.g. the first incident after a deploy.
+const CorrelateFollowing correlateStrategy = 2
+
+// Pair is a pair of correlated intervals. See: Correlate.
+type Pair struct {
+	A Interval
+	B Interval
+	// Gap is the time between the intervals. It is zero if they overlap or touch.
+	Gap time.Duration
+}
+
+// Correlate matches the intervals in a to the intervals in b that overlap them or are at most maxGap apart,
+// e.g. to correlate deploys with incidents within 30 minutes. The strategy determines which intervals in b are paired
+// with an interval in a. Intervals in a without a match are omitted. The pairs are ordered like a (and b).
+func Correlate(a, b []Interval, maxGap time.Duration, strategy correlateStrategy) []Pair {
+	var pairs []Pair
+	for _, x := range a {
+		var best *Pair
+		for _, y := range b {
+			g := gap(x, y)
+			if g > maxGap {
+				continue
+			}
+			p := Pair{A: x, B: y, Gap: g}
+			switch strategy {
+			case CorrelateAll:
+				pairs = append(pairs, p)
+			case CorrelateFollowing:
+				if !y.StartsAt.Before(x.StartsAt) && (best == nil || y.StartsAt.Before(best.B.StartsAt)) {
+					best = &p
+				}
+			default:
+				if best == nil || g < best.Gap || (g == best.Gap && y.StartsAt.Before(best.B.StartsAt)) {
+					best = &p
+				}
+			}
+		}
+		if best != nil {
+			pairs = append(pairs, *best)
+		}
+	}
+	return pairs
+}
+
+// gap returns the time between a and b or zero if they overlap or touch.
+func gap(a, b Interval) time.Duration {
+	switch {
+	case b.StartsAt.After(a.EndsAt):
+		return b.StartsAt.Sub(a.EndsAt)
+	case a.StartsAt.After(b.EndsAt):
+		return a.StartsAt.Sub(b.EndsAt)
+	}
+	return 0
+}
@@ -0,0 +1,44 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelate(t *testing.T) {
+	deploys := mustIntervals(t,
+		"2019-01-02T10:00:00Z/PT5M",
+		"2019-01-02T12:00:00Z/PT5M",
+		"2019-01-02T15:00:00Z/PT5M",
+	)
+	incidents := mustIntervals(t,
+		"2019-01-02T09:50:00Z/PT1H",
+		"2019-01-02T10:20:00Z/PT10M",
+		"2019-01-02T12:30:00Z/PT10M",
+		"2019-01-02T16:00:00Z/PT10M",
+	)
+	maxGap := 30 * time.Minute
+
+	pairs := Correlate(deploys, incidents, maxGap, CorrelateNearest)
+	assert.Len(t, pairs, 2)
+	assert.Equal(t, incidents[0], pairs[0].B)
+	assert.Equal(t, time.Duration(0), pairs[0].Gap)
+	assert.Equal(t, deploys[1], pairs[1].A)
+	assert.Equal(t, incidents[2], pairs[1].B)
+	assert.Equal(t, 25*time.Minute, pairs[1].Gap)
+
+	pairs = Correlate(deploys, incidents, maxGap, CorrelateAll)
+	assert.Len(t, pairs, 3)
+	assert.Equal(t, incidents[1], pairs[1].B)
+	assert.Equal(t, 15*time.Minute, pairs[1].Gap)
+
+	pairs = Correlate(deploys, incidents, maxGap, CorrelateFollowing)
+	assert.Len(t, pairs, 2)
+	assert.Equal(t, incidents[1], pairs[0].B)
+	assert.Equal(t, incidents[2], pairs[1].B)
+
+	assert.Empty(t, Correlate(deploys, incidents, 0, CorrelateFollowing))
+	assert.Empty(t, Correlate(deploys, nil, maxGap, CorrelateNearest))
+}
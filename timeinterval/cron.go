@@ -0,0 +1,288 @@
+package timeinterval
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMaxYear is the last year of cron expressions.
+const cronMaxYear = 2199
+
+// cronField holds the values matched by a field of a cron expression.
+type cronField struct {
+	min    int
+	values []bool
+}
+
+// has returns a boolean indicating if the field matches the value.
+func (f cronField) has(v int) bool {
+	return v >= f.min && v-f.min < len(f.values) && f.values[v-f.min]
+}
+
+// CronSchedule is a Schedule with occurrences described by a cron expression.
+// See: ParseCron and ParseEventBridgeCron.
+type CronSchedule struct {
+	minutes cronField
+	hours   cronField
+	days    cronField
+	months  cronField
+	// weekdays holds the matched days of week numbered like time.Weekday.
+	weekdays cronField
+	years    cronField
+	// byWeekday indicates that the day-of-month field is ? and days are matched by the day-of-week field.
+	byWeekday bool
+	// eitherDay indicates that days are matched by either the day-of-month or the day-of-week field.
+	eitherDay bool
+	// lastDay is set for "L" in the day-of-month field.
+	lastDay bool
+	// nearestWeekday is the day of "W" in the day-of-month field (e.g. 15 for "15W").
+	nearestWeekday int
+	// nthWeekday is the week of "#" in the day-of-week field (e.g. 3 for "6#3").
+	nthWeekday int
+	// lastWeekday is set for "L" in the day-of-week field (e.g. "6L").
+	lastWeekday bool
+	location    *time.Location
+}
+
+// cronMonths and cronWeekdays map the names of months and weekdays in cron expressions to their values.
+var cronMonths = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6, "JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+var cronWeekdays = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+
+// cronMacros maps the predefined schedules of cron expressions to their fields.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses the five fields of a cron expression (minute, hour, day-of-month, month and day-of-week, e.g.
+// "*/15 9-17 * * MON-FRI") into a CronSchedule evaluated in the given location. The fields support "*", lists,
+// ranges and increments ("*/15", "1-30/2"). Months and weekdays may be named (JAN, MON) and weekdays are numbered
+// from 0 (SUN) to 6 (SAT), with 7 also being Sunday. The predefined schedules @yearly, @monthly, @weekly, @daily
+// and @hourly are supported as well.
+// Like in Vixie cron, a day matches either field if both the day-of-month and the day-of-week are restricted.
+func ParseCron(expr string, loc *time.Location) (*CronSchedule, error) {
+	if loc == nil {
+		return nil, errors.New("location cannot be nil")
+	}
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.New("cron expression must have 5 fields")
+	}
+	for i := range fields {
+		if fields[i] == "?" {
+			fields[i] = "*"
+		}
+	}
+	s := CronSchedule{location: loc}
+	var err error
+	if s.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if s.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if s.days, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if s.months, err = parseCronField(fields[3], 1, 12, cronMonths); err != nil {
+		return nil, err
+	}
+	weekdays, err := parseCronField(fields[4], 0, 7, cronWeekdays)
+	if err != nil {
+		return nil, err
+	}
+	// Both 0 and 7 are Sunday.
+	weekdays.values[0] = weekdays.values[0] || weekdays.values[7]
+	s.weekdays = cronField{min: 0, values: weekdays.values[:7]}
+	s.years, _ = parseCronField("*", 1970, cronMaxYear, nil)
+	switch {
+	case fields[2] == "*":
+		s.byWeekday = true
+	case fields[4] != "*":
+		s.eitherDay = true
+	}
+	return &s, nil
+}
+
+// parseCronField parses a comma separated list of "*", values, ranges ("1-5") and increments ("0/15", "1-30/2")
+// within [min, max]. Values may be given by the names in the map.
+func parseCronField(field string, min, max int, names map[string]int) (cronField, error) {
+	f := cronField{min: min, values: make([]bool, max-min+1)}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return f, errors.New("invalid cron increment")
+			}
+			part = part[:i]
+		}
+		from, to := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if from, err = cronValue(bounds[0], min, max, names); err != nil {
+				return f, err
+			}
+			if to, err = cronValue(bounds[1], min, max, names); err != nil {
+				return f, err
+			}
+			if to < from {
+				return f, errors.New("invalid cron range")
+			}
+		default:
+			var err error
+			if from, err = cronValue(part, min, max, names); err != nil {
+				return f, err
+			}
+			if step == 1 {
+				to = from
+			}
+		}
+		for v := from; v <= to; v += step {
+			f.values[v-min] = true
+		}
+	}
+	return f, nil
+}
+
+func cronValue(s string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, errors.New("invalid cron value")
+	}
+	return v, nil
+}
+
+// Next returns the time of the first occurrence after the given time or nil if there is none until cronMaxYear.
+func (s CronSchedule) Next(t time.Time) *time.Time {
+	t = t.In(s.location)
+	year, month, day := t.Date()
+	hour, minute := t.Hour(), t.Minute()+1
+	for year <= cronMaxYear {
+		switch {
+		case !s.years.has(year):
+			year, month, day, hour, minute = year+1, time.January, 1, 0, 0
+			continue
+		case !s.months.has(int(month)):
+			month, day, hour, minute = month+1, 1, 0, 0
+		case !s.dayMatches(year, month, day):
+			day, hour, minute = day+1, 0, 0
+		default:
+			if nxt := s.nextOnDay(year, month, day, hour, minute); nxt != nil && nxt.After(t) {
+				return nxt
+			}
+			day, hour, minute = day+1, 0, 0
+		}
+		// Normalize the date after stepping past the end of a month or year.
+		d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		year, month, day = d.Date()
+	}
+	return nil
+}
+
+// Started returns a boolean indicating if the first occurrence of the schedule is at or before the given time.
+func (s CronSchedule) Started(t time.Time) bool {
+	first := s.Next(time.Date(1970, time.January, 1, 0, 0, 0, 0, s.location).Add(-time.Nanosecond))
+	return first != nil && !t.Before(*first)
+}
+
+// Ended returns a boolean indicating if the schedule has no occurrences after the given time.
+func (s CronSchedule) Ended(t time.Time) bool {
+	return s.Next(t) == nil
+}
+
+// In returns a boolean indicating if the given time is when the schedule is active (Started and not Ended).
+func (s CronSchedule) In(t time.Time) bool {
+	return s.Started(t) && !s.Ended(t)
+}
+
+// nextOnDay returns the first time on the day at or after the given hour and minute matched by the schedule.
+func (s CronSchedule) nextOnDay(year int, month time.Month, day, hour, minute int) *time.Time {
+	for h := hour; h < 24; h++ {
+		if !s.hours.has(h) {
+			continue
+		}
+		m := 0
+		if h == hour {
+			m = minute
+		}
+		for ; m < 60; m++ {
+			if s.minutes.has(m) {
+				nxt := time.Date(year, month, day, h, m, 0, 0, s.location)
+				return &nxt
+			}
+		}
+	}
+	return nil
+}
+
+// dayMatches returns a boolean indicating if the schedule matches the day.
+func (s CronSchedule) dayMatches(year int, month time.Month, day int) bool {
+	last := daysIn(year, month)
+	if day > last {
+		return false
+	}
+	weekday := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+	if s.eitherDay {
+		return s.days.has(day) || s.weekdays.has(int(weekday))
+	}
+	if !s.byWeekday {
+		switch {
+		case s.lastDay:
+			return day == last
+		case s.nearestWeekday > 0:
+			return day == nearestWeekday(year, month, s.nearestWeekday)
+		}
+		return s.days.has(day)
+	}
+	if !s.weekdays.has(int(weekday)) {
+		return false
+	}
+	switch {
+	case s.nthWeekday > 0:
+		return (day-1)/7+1 == s.nthWeekday
+	case s.lastWeekday:
+		return day+7 > last
+	}
+	return true
+}
+
+// nearestWeekday returns the weekday (Monday to Friday) of the month nearest to the given day.
+func nearestWeekday(year int, month time.Month, day int) int {
+	last := daysIn(year, month)
+	if day > last {
+		day = last
+	}
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return 3
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
@@ -0,0 +1,61 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	// 2019-01-04 is a Friday.
+	from := time.Date(2019, 1, 4, 16, 50, 0, 0, time.UTC)
+	expectations := map[string][]string{
+		"*/15 9-17 * * MON-FRI": {"2019-01-04T17:00:00Z", "2019-01-04T17:15:00Z", "2019-01-04T17:30:00Z", "2019-01-04T17:45:00Z", "2019-01-07T09:00:00Z"},
+		"0 9 * * 1-5":           {"2019-01-07T09:00:00Z", "2019-01-08T09:00:00Z"},
+		"30 8 * * 7":            {"2019-01-06T08:30:00Z", "2019-01-13T08:30:00Z"},
+		"0 0 1 jan,jul ?":       {"2019-07-01T00:00:00Z", "2020-01-01T00:00:00Z"},
+		// Both day fields are restricted, so either matches: the 13th or Fridays.
+		"0 12 13 * FRI": {"2019-01-11T12:00:00Z", "2019-01-13T12:00:00Z", "2019-01-18T12:00:00Z"},
+		"@daily":        {"2019-01-05T00:00:00Z", "2019-01-06T00:00:00Z"},
+		"@hourly":       {"2019-01-04T17:00:00Z", "2019-01-04T18:00:00Z"},
+		"0 0 29 2 *":    {"2020-02-29T00:00:00Z", "2024-02-29T00:00:00Z"},
+	}
+	for given, expected := range expectations {
+		s, err := ParseCron(given, time.UTC)
+		assert.Nil(t, err, given)
+		at := from
+		for _, e := range expected {
+			next := s.Next(at)
+			if !assert.NotNil(t, next, given) {
+				break
+			}
+			assert.Equal(t, e, next.Format(time.RFC3339), given)
+			at = *next
+		}
+	}
+
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	s, err := ParseCron("0 9 * * MON-FRI", loc)
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-07T14:00:00Z", s.Next(from).UTC().Format(time.RFC3339))
+	assert.True(t, s.Started(from))
+	assert.False(t, s.Ended(from))
+	assert.True(t, s.In(from))
+	assert.False(t, s.Started(time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	for _, given := range []string{"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "* * * * FOO", "*/0 * * * *", "@every"} {
+		_, err := ParseCron(given, time.UTC)
+		assert.NotNil(t, err, given)
+	}
+	_, err = ParseCron("* * * * *", nil)
+	assert.NotNil(t, err)
+}
+
+func TestCronSchedule_Ended(t *testing.T) {
+	s, err := ParseEventBridgeCron("0 12 * * ? 2020", time.UTC)
+	assert.Nil(t, err)
+	assert.False(t, s.Started(time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, s.In(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, s.Ended(time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC)))
+}
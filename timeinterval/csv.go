@@ -0,0 +1,205 @@
+package timeinterval
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// CSVColumn identifies the content of a column in a CSV interval dataset.
+type CSVColumn uint8
+
+// CSVColumnSkip indicates that the column is ignored when reading and left empty when writing.
+const CSVColumnSkip CSVColumn = 0
+
+// CSVColumnName indicates that the column holds the name of the interval.
+const CSVColumnName CSVColumn = 1
+
+// CSVColumnStartsAt indicates that the column holds the StartsAt time of the interval.
+const CSVColumnStartsAt CSVColumn = 2
+
+// CSVColumnEndsAt indicates that the column holds the EndsAt time of the interval.
+const CSVColumnEndsAt CSVColumn = 3
+
+// CSVColumnISO8601 indicates that the column holds the interval as an ISO8601 "interval" string.
+const CSVColumnISO8601 CSVColumn = 4
+
+var defaultCSVColumns = []CSVColumn{CSVColumnName, CSVColumnStartsAt, CSVColumnEndsAt}
+
+var csvColumnHeaders = map[CSVColumn]string{
+	CSVColumnSkip:     "",
+	CSVColumnName:     "name",
+	CSVColumnStartsAt: "starts_at",
+	CSVColumnEndsAt:   "ends_at",
+	CSVColumnISO8601:  "interval",
+}
+
+// NamedInterval is an Interval identified by a name, e.g. a shift or a blackout window.
+type NamedInterval struct {
+	Name     string
+	Interval Interval
+}
+
+// CSVOptions configures how intervals are read from and written to CSV.
+// The zero value reads and writes the columns "name,starts_at,ends_at" with time.RFC3339 timestamps and no header.
+type CSVOptions struct {
+	// Columns describes the content of each column in order. Defaults to Name, StartsAt, EndsAt.
+	Columns []CSVColumn
+	// Layout is the time layout used for the StartsAt and EndsAt columns. Defaults to time.RFC3339.
+	Layout string
+	// Location is used for timestamps without time zone information. Defaults to UTC.
+	Location *time.Location
+	// Comma is the field delimiter. Defaults to ','.
+	Comma rune
+	// Header indicates that the first record is a header. It is skipped when reading and emitted when writing.
+	Header bool
+}
+
+func (o CSVOptions) columns() []CSVColumn {
+	if len(o.Columns) == 0 {
+		return defaultCSVColumns
+	}
+	return o.Columns
+}
+
+func (o CSVOptions) layout() string {
+	if o.Layout == "" {
+		return time.RFC3339
+	}
+	return o.Layout
+}
+
+func (o CSVOptions) location() *time.Location {
+	if o.Location == nil {
+		return time.UTC
+	}
+	return o.Location
+}
+
+// ReadIntervalsCSV reads all records of the given CSV and returns them as named intervals.
+// Every record must either contain both a StartsAt and an EndsAt column or an ISO8601 column.
+// The returned error identifies the first record that could not be read by its number, counting from 1 and including
+// the header. It differs from the line number if quoted fields span multiple lines.
+func ReadIntervalsCSV(r io.Reader, opts CSVOptions) ([]NamedInterval, error) {
+	columns := opts.columns()
+	cr := csv.NewReader(r)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.FieldsPerRecord = len(columns)
+	var result []NamedInterval
+	for n := 1; ; n++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if n == 1 && opts.Header {
+			continue
+		}
+		ni, err := parseCSVRecord(record, columns, opts)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %v", n, err)
+		}
+		result = append(result, ni)
+	}
+}
+
+func parseCSVRecord(record []string, columns []CSVColumn, opts CSVOptions) (NamedInterval, error) {
+	ni := NamedInterval{}
+	var startsAt, endsAt *time.Time
+	var in *Interval
+	for i, column := range columns {
+		switch column {
+		case CSVColumnName:
+			ni.Name = record[i]
+		case CSVColumnStartsAt, CSVColumnEndsAt:
+			t, err := time.ParseInLocation(opts.layout(), record[i], opts.location())
+			if err != nil {
+				return ni, err
+			}
+			if column == CSVColumnStartsAt {
+				startsAt = &t
+			} else {
+				endsAt = &t
+			}
+		case CSVColumnISO8601:
+			parsed, err := ParseIntervalISO8601(record[i])
+			if err != nil {
+				return ni, err
+			}
+			in = parsed
+		}
+	}
+	if in == nil {
+		if startsAt == nil || endsAt == nil {
+			return ni, errors.New("record must contain both a start and an end time")
+		}
+		i, err := NewInterval(startsAt, endsAt, nil)
+		if err != nil {
+			return ni, err
+		}
+		in = i
+	}
+	ni.Interval = *in
+	return ni, nil
+}
+
+// WriteIntervalsCSV writes the given named intervals as CSV records.
+// Open intervals have no time to write to StartsAt and EndsAt columns and return an error there, while ISO8601 columns
+// hold them as e.g. "2019-01-02T21:00:00Z/..". All records are formatted before any is written, so nothing is written
+// if one of them fails. The returned error identifies that record like ReadIntervalsCSV does.
+func WriteIntervalsCSV(w io.Writer, ins []NamedInterval, opts CSVOptions) error {
+	columns := opts.columns()
+	var records [][]string
+	if opts.Header {
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = csvColumnHeaders[column]
+		}
+		records = append(records, header)
+	}
+	for _, ni := range ins {
+		record, err := formatCSVRecord(ni, columns, opts)
+		if err != nil {
+			return fmt.Errorf("record %d: %v", len(records)+1, err)
+		}
+		records = append(records, record)
+	}
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+	return cw.WriteAll(records)
+}
+
+func formatCSVRecord(ni NamedInterval, columns []CSVColumn, opts CSVOptions) ([]string, error) {
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case CSVColumnName:
+			record[i] = ni.Name
+		case CSVColumnStartsAt:
+			if ni.Interval.OpenStart() {
+				return nil, errors.New("open start cannot be written to a starts_at column")
+			}
+			record[i] = ni.Interval.StartsAt.In(opts.location()).Format(opts.layout())
+		case CSVColumnEndsAt:
+			if ni.Interval.OpenEnd() {
+				return nil, errors.New("open end cannot be written to an ends_at column")
+			}
+			record[i] = ni.Interval.EndsAt.In(opts.location()).Format(opts.layout())
+		case CSVColumnISO8601:
+			iso, err := ni.Interval.ISO8601()
+			if err != nil {
+				return nil, err
+			}
+			record[i] = iso
+		}
+	}
+	return record, nil
+}
@@ -0,0 +1,100 @@
+package timeinterval
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadIntervalsCSV(t *testing.T) {
+	input := "name,starts_at,ends_at\n" +
+		"early,2019-01-02T06:00:00Z,2019-01-02T14:00:00Z\n" +
+		"late,2019-01-02T14:00:00Z,2019-01-02T22:00:00Z\n"
+	result, err := ReadIntervalsCSV(strings.NewReader(input), CSVOptions{Header: true})
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "early", result[0].Name)
+	assert.Equal(t, 8*time.Hour, result[0].Interval.Duration())
+	assert.Equal(t, "late", result[1].Name)
+	assert.Equal(t, ISOFormatTimeAndTime, result[1].Interval.Format)
+}
+
+func TestReadIntervalsCSV_Options(t *testing.T) {
+	loc := time.FixedZone("CET", 3600)
+	input := "x;02.01.2019 21:00;03.01.2019 21:00;blackout\n"
+	opts := CSVOptions{
+		Columns:  []CSVColumn{CSVColumnSkip, CSVColumnStartsAt, CSVColumnEndsAt, CSVColumnName},
+		Layout:   "02.01.2006 15:04",
+		Location: loc,
+		Comma:    ';',
+	}
+	result, err := ReadIntervalsCSV(strings.NewReader(input), opts)
+	assert.Nil(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "blackout", result[0].Name)
+	assert.Equal(t, "2019-01-02T20:00:00Z", result[0].Interval.StartsAt.UTC().Format(time.RFC3339))
+
+	input = "weekly,2019-01-02T21:00:00Z/P1W\n"
+	opts = CSVOptions{Columns: []CSVColumn{CSVColumnName, CSVColumnISO8601}}
+	result, err = ReadIntervalsCSV(strings.NewReader(input), opts)
+	assert.Nil(t, err)
+	assert.Equal(t, ISOFormatTimeAndDuration, result[0].Interval.Format)
+}
+
+func TestReadIntervalsCSV_Errors(t *testing.T) {
+	inputs := []string{
+		"a,2019-01-02T21:00:00Z,2019-01-01T21:00:00Z\n", // ends before it starts
+		"a,2019-01-02T21:00:00Z,yesterday\n",            // invalid time
+		"a,2019-01-02T21:00:00Z\n",                      // missing column
+	}
+	for _, input := range inputs {
+		_, err := ReadIntervalsCSV(strings.NewReader(input), CSVOptions{})
+		assert.NotNil(t, err)
+	}
+	_, err := ReadIntervalsCSV(strings.NewReader("a,b\n"), CSVOptions{Columns: []CSVColumn{CSVColumnName, CSVColumnStartsAt}})
+	assert.NotNil(t, err)
+
+	// Errors identify the record, which may span several lines.
+	input := "name,starts_at,ends_at\n\"multi\nline\",2019-01-02T21:00:00Z,2019-01-03T21:00:00Z\nb,2019-01-02T21:00:00Z,yesterday\n"
+	_, err = ReadIntervalsCSV(strings.NewReader(input), CSVOptions{Header: true})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "record 3:")
+	}
+}
+
+func TestWriteIntervalsCSV(t *testing.T) {
+	in, err := ParseIntervalISO8601("2019-01-02T21:00:00Z/P1W")
+	assert.Nil(t, err)
+	ins := []NamedInterval{{Name: "weekly", Interval: *in}}
+
+	var buf bytes.Buffer
+	err = WriteIntervalsCSV(&buf, ins, CSVOptions{Header: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "name,starts_at,ends_at\nweekly,2019-01-02T21:00:00Z,2019-01-09T21:00:00Z\n", buf.String())
+
+	buf.Reset()
+	opts := CSVOptions{Columns: []CSVColumn{CSVColumnName, CSVColumnISO8601}}
+	err = WriteIntervalsCSV(&buf, ins, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, "weekly,2019-01-02T21:00:00Z/P1W\n", buf.String())
+
+	result, err := ReadIntervalsCSV(&buf, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, ins, result)
+
+	// Open bounds have no time to write.
+	open := []NamedInterval{{Name: "open", Interval: *NewOpenEndInterval(in.StartsAt)}}
+	buf.Reset()
+	assert.NotNil(t, WriteIntervalsCSV(&buf, open, CSVOptions{}))
+	// Nothing is written when a later record fails.
+	buf.Reset()
+	err = WriteIntervalsCSV(&buf, append(ins, open...), CSVOptions{Header: true})
+	assert.EqualError(t, err, "record 3: open end cannot be written to an ends_at column")
+	assert.Empty(t, buf.String())
+	buf.Reset()
+	assert.Nil(t, WriteIntervalsCSV(&buf, open, opts))
+	assert.Equal(t, "open,2019-01-02T21:00:00Z/..\n", buf.String())
+}
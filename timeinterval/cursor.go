@@ -0,0 +1,70 @@
+package timeinterval
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrCursorMismatch is returned by ResumeCursor when the checkpoint was taken from a cursor over another schedule.
+var ErrCursorMismatch = errors.New("cursor checkpoint belongs to another schedule")
+
+// Cursor enumerates the repetitions of a repeating interval one at a time. Its position can be checkpointed
+// (see: Cursor.MarshalText) and resumed later by ResumeCursor, so batch jobs expanding huge schedules can continue
+// where they left off after a restart.
+type Cursor struct {
+	schedule Repeating
+	id       string
+	index    int
+}
+
+// NewCursor returns a Cursor positioned at the first repetition of the repeating interval.
+func NewCursor(r Repeating) *Cursor {
+	return &Cursor{schedule: r, id: newID(r.canonical())}
+}
+
+// ResumeCursor returns a Cursor over the repeating interval positioned at the checkpoint returned by
+// Cursor.MarshalText. ErrCursorMismatch is returned if the checkpoint was taken from a cursor over another schedule.
+func ResumeCursor(r Repeating, checkpoint []byte) (*Cursor, error) {
+	c := NewCursor(r)
+	parts := strings.Split(string(checkpoint), ":")
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor checkpoint format")
+	}
+	index, err := strconv.Atoi(parts[1])
+	if err != nil || index < 0 {
+		return nil, errors.New("invalid cursor checkpoint index")
+	}
+	if parts[0] != c.id {
+		return nil, ErrCursorMismatch
+	}
+	c.index = index
+	return c, nil
+}
+
+// Next returns the next repetition and advances the cursor. It returns false when a bounded repeating interval
+// has no more repetitions.
+func (c *Cursor) Next() (Interval, bool) {
+	if c.schedule.Repetitions != nil && c.index >= int(*c.schedule.Repetitions) {
+		return Interval{}, false
+	}
+	in := Interval{
+		Format:   ISOFormatTimeAndTime,
+		StartsAt: c.schedule.occurrence(c.index),
+		EndsAt:   c.schedule.occurrence(c.index + 1),
+	}
+	c.index++
+	return in, true
+}
+
+// Index returns the index of the repetition returned by the next call to Next (0 is the first).
+func (c *Cursor) Index() int {
+	return c.index
+}
+
+// MarshalText returns a checkpoint of the cursor holding an identifier of its schedule and its index,
+// e.g. "3hbn5rfvx2qk4mzj:42". See: ResumeCursor.
+func (c *Cursor) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s:%d", c.id, c.index)), nil
+}
@@ -0,0 +1,53 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	r := MustParseRepeatingIntervalISO8601("R5/2019-01-31T21:00:00Z/P1M")
+	c := NewCursor(*r)
+	var seen []Interval
+	for i := 0; i < 2; i++ {
+		in, ok := c.Next()
+		assert.True(t, ok)
+		seen = append(seen, in)
+	}
+	assert.Equal(t, time.Date(2019, 2, 28, 21, 0, 0, 0, time.UTC), seen[1].StartsAt)
+	assert.Equal(t, time.Date(2019, 3, 31, 21, 0, 0, 0, time.UTC), seen[1].EndsAt)
+	assert.Equal(t, 2, c.Index())
+
+	checkpoint, err := c.MarshalText()
+	assert.Nil(t, err)
+	resumed, err := ResumeCursor(*MustParseRepeatingIntervalISO8601("R5/2019-01-31T22:00:00+01:00/P1M"), checkpoint)
+	assert.Nil(t, err)
+	for {
+		in, ok := resumed.Next()
+		if !ok {
+			break
+		}
+		seen = append(seen, in)
+	}
+	assert.Len(t, seen, 5)
+	assert.True(t, r.EndsAt().Equal(seen[4].EndsAt))
+	_, ok := resumed.Next()
+	assert.False(t, ok)
+
+	_, err = ResumeCursor(*MustParseRepeatingIntervalISO8601("R6/2019-01-31T21:00:00Z/P1M"), checkpoint)
+	assert.Equal(t, ErrCursorMismatch, err)
+	for _, given := range []string{"", "abc", "abc:def", "abc:-1", "a:b:1"} {
+		_, err = ResumeCursor(*r, []byte(given))
+		assert.NotNil(t, err, given)
+	}
+
+	unbounded := NewCursor(*MustParseRepeatingIntervalISO8601("R/2019-01-02T21:00:00Z/PT1H"))
+	for i := 0; i < 1000; i++ {
+		_, ok = unbounded.Next()
+	}
+	in, ok := unbounded.Next()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC).Add(1000*time.Hour), in.StartsAt)
+}
@@ -0,0 +1,61 @@
+package timeinterval
+
+import "reflect"
+
+var (
+	intervalType  = reflect.TypeOf(Interval{})
+	repeatingType = reflect.TypeOf(Repeating{})
+	periodType    = reflect.TypeOf(Period{})
+)
+
+// StringToIntervalHookFunc returns a decode hook for github.com/mitchellh/mapstructure converting ISO8601 strings
+// into Interval, Repeating and Period fields (and pointers to them), so intervals can be read from configuration,
+// e.g. with viper:
+//
+//	var config struct {
+//		Maintenance timeinterval.Interval
+//		Backup      timeinterval.Repeating
+//	}
+//	err := viper.Unmarshal(&config, viper.DecodeHook(timeinterval.StringToIntervalHookFunc()))
+//
+// The hook has the signature of mapstructure.DecodeHookFuncType, so this package does not depend on mapstructure.
+// Use mapstructure.ComposeDecodeHookFunc to combine it with other hooks. Data other than strings is returned as is.
+func StringToIntervalHookFunc() func(from, to reflect.Type, data interface{}) (interface{}, error) {
+	return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		s, ok := data.(string)
+		if !ok || from.Kind() != reflect.String {
+			return data, nil
+		}
+		pointer := to.Kind() == reflect.Ptr
+		if pointer {
+			to = to.Elem()
+		}
+		var result interface{}
+		switch to {
+		case intervalType:
+			in, err := ParseIntervalISO8601(s)
+			if err != nil {
+				return nil, err
+			}
+			result = in
+		case repeatingType:
+			r, err := ParseRepeatingIntervalISO8601(s)
+			if err != nil {
+				return nil, err
+			}
+			result = r
+		case periodType:
+			p, err := ParsePeriodISO8601(s)
+			if err != nil {
+				return nil, err
+			}
+			result = &p
+		default:
+			return data, nil
+		}
+		if pointer {
+			return result, nil
+		}
+		return reflect.ValueOf(result).Elem().Interface(), nil
+	}
+}
@@ -0,0 +1,37 @@
+package timeinterval
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringToIntervalHookFunc(t *testing.T) {
+	hook := StringToIntervalHookFunc()
+	stringType := reflect.TypeOf("")
+
+	result, err := hook(stringType, reflect.TypeOf(Interval{}), "2019-01-02T21:00:00Z/P1W")
+	assert.Nil(t, err)
+	assert.Equal(t, *MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1W"), result)
+
+	result, err = hook(stringType, reflect.TypeOf(&Repeating{}), "R5/2019-01-02T21:00:00Z/PT1H")
+	assert.Nil(t, err)
+	assert.Equal(t, MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H"), result)
+
+	result, err = hook(stringType, reflect.TypeOf(Period{}), "P1M")
+	assert.Nil(t, err)
+	assert.Equal(t, Period{Months: 1}, result)
+
+	// Other types and data are passed through.
+	result, err = hook(stringType, stringType, "P1M")
+	assert.Nil(t, err)
+	assert.Equal(t, "P1M", result)
+	data := map[string]interface{}{"startsAt": "2019-01-02T21:00:00Z"}
+	result, err = hook(reflect.TypeOf(data), reflect.TypeOf(Interval{}), data)
+	assert.Nil(t, err)
+	assert.Equal(t, data, result)
+
+	_, err = hook(stringType, reflect.TypeOf(Interval{}), "P1W")
+	assert.NotNil(t, err)
+}
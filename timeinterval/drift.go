@@ -0,0 +1,131 @@
+package timeinterval
+
+import (
+	"sort"
+	"time"
+)
+
+// Drift pairs a planned occurrence with the actual run it was matched to.
+type Drift struct {
+	Planned time.Time
+	Actual  time.Time
+}
+
+// Offset returns how much later (positive) or earlier (negative) the actual run happened compared to the plan.
+func (d Drift) Offset() time.Duration {
+	return d.Actual.Sub(d.Planned)
+}
+
+// DriftReport describes how a set of actual runs adhered to a schedule. See: CompareToActual.
+type DriftReport struct {
+	// OnTime holds the runs that happened exactly as planned.
+	OnTime []time.Time
+	// Late holds the runs that happened after the planned occurrence (within the tolerance).
+	Late []Drift
+	// Early holds the runs that happened before the planned occurrence (within the tolerance).
+	Early []Drift
+	// Missed holds the planned occurrences without a matching run.
+	Missed []time.Time
+	// Extra holds the runs that did not match any planned occurrence.
+	Extra []time.Time
+}
+
+// Adherent returns a boolean indicating if every planned occurrence was matched by exactly one run.
+func (r DriftReport) Adherent() bool {
+	return len(r.Missed) == 0 && len(r.Extra) == 0
+}
+
+// CompareToActual matches the actual run times against the occurrences planned by the repeating interval.
+// An occurrence is the start of each repetition and is matched by the first unmatched run within ± tolerance.
+// For bounded repeating intervals all repetitions are evaluated, while unbounded repeating intervals are only
+// evaluated within the period spanned by the actual runs (± tolerance).
+func CompareToActual(r Repeating, actual []time.Time, tolerance time.Duration) DriftReport {
+	runs := make([]time.Time, len(actual))
+	copy(runs, actual)
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].Before(runs[j])
+	})
+	var planned []time.Time
+	if r.Repetitions != nil {
+		planned = r.occurrences(*r.StartsAt(), *r.EndsAt())
+	} else if len(runs) > 0 {
+		planned = r.occurrences(runs[0].Add(-tolerance), runs[len(runs)-1].Add(tolerance))
+	}
+
+	report := DriftReport{}
+	i := 0
+	for _, p := range planned {
+		for i < len(runs) && runs[i].Before(p.Add(-tolerance)) {
+			report.Extra = append(report.Extra, runs[i])
+			i++
+		}
+		if i >= len(runs) || runs[i].After(p.Add(tolerance)) {
+			report.Missed = append(report.Missed, p)
+			continue
+		}
+		d := Drift{Planned: p, Actual: runs[i]}
+		switch {
+		case d.Offset() > 0:
+			report.Late = append(report.Late, d)
+		case d.Offset() < 0:
+			report.Early = append(report.Early, d)
+		default:
+			report.OnTime = append(report.OnTime, runs[i])
+		}
+		i++
+	}
+	report.Extra = append(report.Extra, runs[i:]...)
+	return report
+}
+
+// DetectGaps returns the windows in which occurrences planned by the expected repeating interval were missed by the
+// actual runs, e.g. to alert on schedules that silently stopped running. Occurrences are matched as by
+// CompareToActual. Consecutive missed occurrences are reported as one window spanning from the first (- tolerance)
+// to the last (+ tolerance) of them. Like CompareToActual, unbounded repeating intervals are only evaluated within
+// the period spanned by the actual runs, so pass a bounded repeating interval to detect gaps after the last run.
+func DetectGaps(actual []time.Time, expected Repeating, tolerance time.Duration) []Interval {
+	missed := CompareToActual(expected, actual, tolerance).Missed
+	var gaps []Interval
+	for i := 0; i < len(missed); {
+		j := i + 1
+		for j < len(missed) && expected.occurrenceIndex(missed[j]) == expected.occurrenceIndex(missed[j-1])+1 {
+			j++
+		}
+		gaps = append(gaps, Interval{
+			Format:   ISOFormatTimeAndTime,
+			StartsAt: missed[i].Add(-tolerance),
+			EndsAt:   missed[j-1].Add(tolerance),
+		})
+		i = j
+	}
+	return gaps
+}
+
+// occurrences returns the start of every repetition within [from, to).
+// Repetitions of a bounded repeating interval outside its bounds are never returned.
+func (r Repeating) occurrences(from, to time.Time) []time.Time {
+	anchor := r.Interval.StartsAt
+	if startsAt := r.StartsAt(); startsAt != nil && from.Before(*startsAt) {
+		from = *startsAt
+	}
+	if endsAt := r.EndsAt(); endsAt != nil && to.After(*endsAt) {
+		to = *endsAt
+	}
+	if r.RepeatEvery() <= 0 {
+		if !anchor.Before(from) && anchor.Before(to) {
+			return []time.Time{anchor}
+		}
+		return nil
+	}
+	// Align "from" with the first repetition at or after it.
+	k := r.occurrenceIndex(from)
+	if r.occurrence(k).Before(from) {
+		k++
+	}
+	var result []time.Time
+	for t := r.occurrence(k); t.Before(to); t = r.occurrence(k) {
+		result = append(result, t)
+		k++
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareToActual(t *testing.T) {
+	r, err := ParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/P1D")
+	assert.Nil(t, err)
+	startsAt := r.Interval.StartsAt
+	actual := []time.Time{
+		startsAt.Add(3*durationDay + 5*time.Minute), // late
+		startsAt,                                // on time
+		startsAt.Add(12 * time.Hour),            // extra
+		startsAt.Add(durationDay - time.Minute), // early
+		// day 2 is missed
+		startsAt.Add(4*durationDay + 2*time.Hour), // extra (outside tolerance)
+		startsAt.Add(4 * durationDay),             // on time
+	}
+	report := CompareToActual(*r, actual, 10*time.Minute)
+	assert.Equal(t, []time.Time{startsAt, startsAt.Add(4 * durationDay)}, report.OnTime)
+	assert.Equal(t, []Drift{{Planned: startsAt.Add(3 * durationDay), Actual: actual[0]}}, report.Late)
+	assert.Equal(t, 5*time.Minute, report.Late[0].Offset())
+	assert.Equal(t, []Drift{{Planned: startsAt.Add(durationDay), Actual: actual[3]}}, report.Early)
+	assert.Equal(t, -time.Minute, report.Early[0].Offset())
+	assert.Equal(t, []time.Time{startsAt.Add(2 * durationDay)}, report.Missed)
+	assert.Equal(t, []time.Time{actual[2], actual[4]}, report.Extra)
+	assert.False(t, report.Adherent())
+}
+
+func TestCompareToActual_Unbounded(t *testing.T) {
+	r, err := ParseRepeatingIntervalISO8601("R/2019-01-02T21:00:00Z/PT1H")
+	assert.Nil(t, err)
+	startsAt := r.Interval.StartsAt.Add(100 * time.Hour)
+	actual := []time.Time{startsAt, startsAt.Add(time.Hour), startsAt.Add(2 * time.Hour)}
+	report := CompareToActual(*r, actual, time.Minute)
+	assert.Equal(t, actual, report.OnTime)
+	assert.True(t, report.Adherent())
+
+	report = CompareToActual(*r, nil, time.Minute)
+	assert.True(t, report.Adherent())
+}
+
+func TestDetectGaps(t *testing.T) {
+	r := MustParseRepeatingIntervalISO8601("R8/2019-01-02T21:00:00Z/PT1H")
+	startsAt := r.Interval.StartsAt
+	actual := []time.Time{
+		startsAt,
+		startsAt.Add(time.Hour + time.Minute),
+		// hours 2 and 3 are missed
+		startsAt.Add(4 * time.Hour),
+		// hour 5 is missed
+		startsAt.Add(6*time.Hour - 20*time.Minute), // extra (outside tolerance)
+		startsAt.Add(6 * time.Hour),
+		// hour 7 is missed
+	}
+	gaps := DetectGaps(actual, *r, 5*time.Minute)
+	assert.Equal(t, []Interval{
+		{Format: ISOFormatTimeAndTime, StartsAt: startsAt.Add(115 * time.Minute), EndsAt: startsAt.Add(185 * time.Minute)},
+		{Format: ISOFormatTimeAndTime, StartsAt: startsAt.Add(295 * time.Minute), EndsAt: startsAt.Add(305 * time.Minute)},
+		{Format: ISOFormatTimeAndTime, StartsAt: startsAt.Add(415 * time.Minute), EndsAt: startsAt.Add(425 * time.Minute)},
+	}, gaps)
+
+	assert.Empty(t, DetectGaps(actual[:2], *MustParseRepeatingIntervalISO8601("R2/2019-01-02T21:00:00Z/PT1H"), time.Minute))
+	assert.Len(t, DetectGaps(nil, *MustParseRepeatingIntervalISO8601("R3/2019-01-31T21:00:00Z/P1M"), 0), 1)
+	assert.Empty(t, DetectGaps(nil, *MustParseRepeatingIntervalISO8601("R/2019-01-02T21:00:00Z/PT1H"), 0))
+}
@@ -0,0 +1,55 @@
+package timeinterval
+
+import "time"
+
+// dstProbeStep is the step used to search for offset changes. Time zones rarely change offset more than once a day.
+const dstProbeStep = 24 * time.Hour
+
+// DSTTransitionsWithin returns the instants within the interval (after StartsAt, up to and including EndsAt)
+// at which the UTC offset of the given location changes, e.g. at the start and end of daylight saving time.
+// Each returned time is the first instant with the new offset. ErrOpenInterval is returned for open intervals.
+//
+// The offset is probed once a day, so an offset that changes and changes back within a day (between two probes) is
+// not detected.
+func DSTTransitionsWithin(in Interval, loc *time.Location) ([]time.Time, error) {
+	if in.OpenStart() || in.OpenEnd() {
+		return nil, ErrOpenInterval
+	}
+	var result []time.Time
+	from := in.StartsAt
+	_, offset := from.In(loc).Zone()
+	for from.Before(in.EndsAt) {
+		to := from.Add(dstProbeStep)
+		if to.After(in.EndsAt) {
+			to = in.EndsAt
+		}
+		if _, toOffset := to.In(loc).Zone(); toOffset != offset {
+			result = append(result, transitionBetween(from, to, loc).In(loc))
+			offset = toOffset
+		}
+		from = to
+	}
+	return result, nil
+}
+
+// CrossesDST returns a boolean indicating if the UTC offset of the given location changes within the interval.
+// Such intervals have days that are not 24 hours long on the wall clock. It is false for open intervals.
+// See: DSTTransitionsWithin.
+func (in Interval) CrossesDST(loc *time.Location) bool {
+	transitions, _ := DSTTransitionsWithin(in, loc)
+	return len(transitions) > 0
+}
+
+// transitionBetween returns the first instant after "from" with the UTC offset of "to" (to the second).
+func transitionBetween(from, to time.Time, loc *time.Location) time.Time {
+	_, offset := from.In(loc).Zone()
+	for to.Sub(from) > time.Second {
+		middle := from.Add(to.Sub(from) / 2)
+		if _, o := middle.In(loc).Zone(); o == offset {
+			from = middle
+		} else {
+			to = middle
+		}
+	}
+	return to.Truncate(time.Second)
+}
@@ -0,0 +1,43 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDSTTransitionsWithin(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	in, err := ParseIntervalISO8601("2019-01-01T00:00:00Z/2020-01-01T00:00:00Z")
+	assert.Nil(t, err)
+	result, err := DSTTransitionsWithin(*in, loc)
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "2019-03-31T03:00:00+02:00", result[0].Format(time.RFC3339))
+	assert.Equal(t, "2019-10-27T02:00:00+01:00", result[1].Format(time.RFC3339))
+	assert.True(t, in.CrossesDST(loc))
+
+	// The transition at 01:00 UTC is included when the interval ends there, but not when it starts there.
+	in, err = ParseIntervalISO8601("2019-03-30T12:00:00Z/2019-03-31T01:00:00Z")
+	assert.Nil(t, err)
+	assert.True(t, in.CrossesDST(loc))
+	in, err = ParseIntervalISO8601("2019-03-31T01:00:00Z/P1D")
+	assert.Nil(t, err)
+	assert.False(t, in.CrossesDST(loc))
+
+	in, err = ParseIntervalISO8601("2019-03-30T12:00:00Z/P1W")
+	assert.Nil(t, err)
+	assert.False(t, in.CrossesDST(time.UTC))
+
+	// Open intervals are not walked up to the sentinels of their open bounds.
+	open := NewOpenEndInterval(mustTime(t, "2019-01-01T00:00:00Z"))
+	_, err = DSTTransitionsWithin(*open, loc)
+	assert.Equal(t, ErrOpenInterval, err)
+	assert.False(t, open.CrossesDST(loc))
+	_, err = DSTTransitionsWithin(*NewOpenStartInterval(mustTime(t, "2019-01-01T00:00:00Z")), loc)
+	assert.Equal(t, ErrOpenInterval, err)
+}
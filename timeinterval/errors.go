@@ -0,0 +1,157 @@
+package timeinterval
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrZeroLengthRepeating is the underlying error of a ParseError for repeating intervals with a zero-length interval,
+// which would repeat every 0s. See: ParseOptions.ZeroLengthAsSingleOccurrence
+var ErrZeroLengthRepeating = errors.New("repeating interval must not have a zero-length interval")
+
+var regexTimeStringNoZoneISO = regexp.MustCompile("^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}(?::[0-9]{2}(?:\\.[0-9]+)?)?$")
+
+var regexDurationNoPrefix = regexp.MustCompile("^T?[0-9][0-9.,YMWDHS]*[YMWDHS]$")
+
+// ParseError is returned when parsing an ISO8601 interval, repeating interval or duration fails.
+// It carries suggestions of likely intended input for tools that show parse errors to humans.
+type ParseError struct {
+	// Input is the string that failed to parse.
+	Input string
+	// Err is the underlying error.
+	Err error
+	// Offset is the byte offset in Input at which the invalid part of it begins, e.g. 24 for the trailing "foo" of
+	// "2019-01-02T21:00:00Z/P1Wfoo". When ParseOptions normalize tolerated deviations, it refers to the normalized
+	// input instead.
+	Offset int
+	parse  func(string) error
+}
+
+// Error returns the message of the underlying error.
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Suggestions returns corrections of the input that parse successfully, e.g. "PT1H" for "2019-01-02T21:00:00Z/P1H".
+// It returns nil when no likely correction was found or the input is too long to be a mistyped interval.
+// The corrections are computed on each call, so failing to parse costs nothing unless they are used.
+func (e *ParseError) Suggestions() []string {
+	if e.parse == nil || len(e.Input) > maxSuggestionInputLength || len(splitParts(e.Input)) > maxSuggestionParts {
+		return nil
+	}
+	return suggestCorrections(e.Input, e.parse)
+}
+
+// maxSuggestionInputLength is the length above which no corrections are suggested. The longest valid inputs,
+// repeating intervals of two times with nanoseconds, offsets and IXDTF suffixes, are shorter.
+const maxSuggestionInputLength = 160
+
+// maxSuggestionParts is the number of "/" separated parts above which no corrections are suggested.
+const maxSuggestionParts = 4
+
+// newParseError returns a ParseError for s whose suggestions are the corrections of s accepted by parse.
+func newParseError(s string, err error, parse func(string) error) *ParseError {
+	offset, err := splitOffset(err)
+	return &ParseError{Input: s, Err: err, Offset: offset, parse: parse}
+}
+
+// offsetError annotates a parse error with the byte offset of the invalid part of the input.
+type offsetError struct {
+	offset int
+	err    error
+}
+
+func (e *offsetError) Error() string {
+	return e.err.Error()
+}
+
+// atOffset annotates err with the given offset. Offsets of errors that are already annotated are relative to a part
+// of the input beginning at the given offset and are shifted accordingly.
+func atOffset(offset int, err error) error {
+	if e, ok := err.(*offsetError); ok {
+		return &offsetError{offset: offset + e.offset, err: e.err}
+	}
+	return &offsetError{offset: offset, err: err}
+}
+
+// splitOffset returns the offset err is annotated with (or 0) and the annotated error.
+func splitOffset(err error) (int, error) {
+	if e, ok := err.(*offsetError); ok {
+		return e.offset, e.err
+	}
+	return 0, err
+}
+
+// suggestCorrections returns the candidate corrections of s that are accepted by parse.
+// Candidates undo common deviations such as lowercase designators, missing "T" in durations, missing "P" or time
+// zone designators and swapped start and end times. More likely corrections are returned first.
+func suggestCorrections(s string, parse func(string) error) []string {
+	normalized := ParseOptions{
+		AllowLowercase:      true,
+		AllowSpaceSeparator: true,
+		TrimWhitespace:      true,
+		AllowGoDuration:     true,
+	}.normalize(s)
+	candidates := []string{normalized}
+	parts := splitParts(normalized)
+	fixedParts := append([]string{}, parts...)
+	for i, part := range parts {
+		for _, fixed := range correctPart(part) {
+			candidates = append(candidates, replacePart(parts, i, fixed))
+			if fixedParts[i] == part && parse(replacePart(parts, i, fixed)) == nil {
+				fixedParts[i] = fixed
+			}
+		}
+	}
+	candidates = append(candidates, strings.Join(fixedParts, "/"))
+	if n := len(fixedParts); n >= 2 && isTimeStringISO(fixedParts[n-2]) && isTimeStringISO(fixedParts[n-1]) {
+		swapped := append([]string{}, fixedParts...)
+		swapped[n-2], swapped[n-1] = swapped[n-1], swapped[n-2]
+		candidates = append(candidates, strings.Join(swapped, "/"))
+	}
+	var result []string
+	seen := map[string]bool{s: true}
+	for _, candidate := range candidates {
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		if parse(candidate) == nil {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// correctPart returns candidate corrections of a single part of an interval.
+func correctPart(part string) []string {
+	var result []string
+	if regexTimeStringNoZoneISO.MatchString(part) {
+		result = append(result, part+"Z")
+	}
+	if regexDurationNoPrefix.MatchString(part) {
+		part = "P" + part
+		result = append(result, part)
+	}
+	if strings.HasPrefix(part, "P") && !strings.Contains(part, "T") {
+		// Insert the missing time designator after each component, e.g. P1D2H becomes P1DT2H.
+		for i := 1; i < len(part); i++ {
+			if part[i-1] == 'P' || strings.IndexByte("YMWD", part[i-1]) >= 0 {
+				result = append(result, part[:i]+"T"+part[i:])
+			}
+		}
+	}
+	return result
+}
+
+func replacePart(parts []string, i int, part string) string {
+	replaced := append([]string{}, parts...)
+	replaced[i] = part
+	return strings.Join(replaced, "/")
+}
@@ -0,0 +1,97 @@
+package timeinterval
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError_Suggestions(t *testing.T) {
+	expectations := map[string][]string{
+		"2019-01-02T21:00:00Z/P1H":                      {"2019-01-02T21:00:00Z/PT1H"},
+		"2019-01-02T21:00:00Z/P1D2H":                    {"2019-01-02T21:00:00Z/P1DT2H"},
+		"2019-01-02T21:00:00Z/1D":                       {"2019-01-02T21:00:00Z/P1D"},
+		"2019-01-02t21:00:00z/p1w":                      {"2019-01-02T21:00:00Z/P1W"},
+		"2019-01-02T21:00:00/P1W":                       {"2019-01-02T21:00:00Z/P1W"},
+		"2019-01-03T21:00:00Z/2019-01-02T21:00:00Z":     {"2019-01-02T21:00:00Z/2019-01-03T21:00:00Z"},
+		"2019-01-02T21:00:00Z/1h30m":                    {"2019-01-02T21:00:00Z/PT1H30M"},
+		"P1D/P1D":                                       nil,
+		"2019-01-02 21:00:00/2019-01-03T21:00:00Z/PT1H": nil,
+	}
+	for given, expected := range expectations {
+		_, err := ParseIntervalISO8601(given)
+		parseErr, ok := err.(*ParseError)
+		assert.True(t, ok, given)
+		assert.Equal(t, given, parseErr.Input)
+		assert.Equal(t, expected, parseErr.Suggestions(), given)
+	}
+}
+
+func TestParseError_SuggestionsLimits(t *testing.T) {
+	// Corrections are not computed for input too long or with too many parts to be a mistyped interval.
+	for _, given := range []string{
+		"2019-01-02T21:00:00Z/P1H" + strings.Repeat(" ", 9000),
+		"2019-01-02T21:00:00Z/P1H/P1H/P1H/P1H",
+	} {
+		_, err := ParseIntervalISO8601(given)
+		if assert.NotNil(t, err) {
+			assert.Nil(t, err.(*ParseError).Suggestions())
+		}
+	}
+}
+
+func TestParseError_RepeatingSuggestions(t *testing.T) {
+	_, err := ParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/P15M30S")
+	parseErr, ok := err.(*ParseError)
+	assert.True(t, ok)
+	// P15M30S is ambiguous, so both readings are suggested.
+	assert.Equal(t, []string{"R5/2019-01-02T21:00:00Z/PT15M30S", "R5/2019-01-02T21:00:00Z/P15MT30S"}, parseErr.Suggestions())
+}
+
+func TestParseError_Unwrap(t *testing.T) {
+	_, err := ParseIntervalISO8601("P1D/P1D")
+	assert.EqualError(t, err, "interval cannot consist of two durations")
+	assert.EqualError(t, err.(*ParseError).Unwrap(), "interval cannot consist of two durations")
+}
+
+func TestParseError_Offset(t *testing.T) {
+	expectations := map[string]int{
+		"2019-01-02T21:00:00Z/P1Wfoo":                24,
+		"2019-01-02T21:00:00Z/P1W/foo":               24,
+		"2019-01-02T21:00:00Z/P1W/":                  24,
+		"2019-01-02T21:00:00Z":                       20,
+		"2019-01-02T21:00:00Zfoo/P1W":                0,
+		"2019-01-02T21:00:00Z/2019-01-03T21:00:00Zx": 21,
+		"2019-01-02T21:00:00Z/PT1H1H":                26,
+		"2019-01-02T21:00:00Z/PT":                    23,
+		"P1D/P1D":                                    4,
+		"P1D/..":                                     0,
+	}
+	for given, expected := range expectations {
+		_, err := ParseIntervalISO8601(given)
+		if assert.NotNil(t, err, given) {
+			assert.Equal(t, expected, err.(*ParseError).Offset, given)
+		}
+	}
+
+	repeating := map[string]int{
+		"R5x/2019-01-02T21:00:00Z/P1W":  1,
+		"R5/2019-01-02T21:00:00Z/P1Wx":  27,
+		"R5/2019-01-02T21:00:00Z/P1W/x": 27,
+		"R5/2019-01-02T21:00:00Z/PT0S":  3,
+		"5/2019-01-02T21:00:00Z/P1W":    0,
+	}
+	for given, expected := range repeating {
+		_, err := ParseRepeatingIntervalISO8601(given)
+		if assert.NotNil(t, err, given) {
+			assert.Equal(t, expected, err.(*ParseError).Offset, given)
+		}
+	}
+
+	_, err := ParseDurationISO8601("PT1H30Mfoo")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, 7, err.(*ParseError).Offset)
+		assert.Equal(t, "invalid duration format", err.Error())
+	}
+}
@@ -0,0 +1,173 @@
+package timeinterval
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseEventBridgeExpression parses an AWS EventBridge schedule expression into a Schedule, so schedules deployed to
+// EventBridge can be evaluated locally:
+//
+//   - rate(5 minutes) returns an unbounded *Repeating starting at ref
+//   - cron(0 12 * * ? *) returns a *CronSchedule
+//   - at(2019-01-02T21:00:00) returns a Schedule with a single occurrence
+//
+// Times of cron and at expressions are evaluated in the given location, which is UTC for EventBridge rules.
+func ParseEventBridgeExpression(expr string, ref time.Time, loc *time.Location) (Schedule, error) {
+	if loc == nil {
+		return nil, errors.New("location cannot be nil")
+	}
+	expr = strings.TrimSpace(expr)
+	open := strings.IndexByte(expr, '(')
+	if open < 0 || !strings.HasSuffix(expr, ")") {
+		return nil, errors.New("invalid schedule expression format")
+	}
+	body := strings.TrimSpace(expr[open+1 : len(expr)-1])
+	switch expr[:open] {
+	case "rate":
+		return parseEventBridgeRate(body, ref)
+	case "cron":
+		return ParseEventBridgeCron(body, loc)
+	case "at":
+		t, err := time.ParseInLocation(timeLayoutLocal, body, loc)
+		if err != nil {
+			return nil, errors.New("invalid at expression format")
+		}
+		return onceSchedule(t), nil
+	}
+	return nil, errors.New("invalid schedule expression type")
+}
+
+// parseEventBridgeRate parses the value and unit of a rate expression, e.g. "5 minutes".
+func parseEventBridgeRate(body string, ref time.Time) (*Repeating, error) {
+	fields := strings.Fields(body)
+	if len(fields) != 2 {
+		return nil, errors.New("invalid rate expression format")
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n <= 0 {
+		return nil, errors.New("invalid rate expression value")
+	}
+	units := map[string]time.Duration{"minute": time.Minute, "hour": time.Hour, "day": durationDay}
+	unit := fields[1]
+	if n > 1 {
+		// EventBridge requires the singular unit for a value of 1 and the plural otherwise.
+		if !strings.HasSuffix(unit, "s") {
+			return nil, errors.New("invalid rate expression unit")
+		}
+		unit = unit[:len(unit)-1]
+	}
+	d, ok := units[unit]
+	if !ok {
+		return nil, errors.New("invalid rate expression unit")
+	}
+	d *= time.Duration(n)
+	in, err := NewInterval(&ref, nil, &d)
+	if err != nil {
+		return nil, err
+	}
+	return &Repeating{Interval: *in}, nil
+}
+
+// onceSchedule is a Schedule with a single occurrence at the given time.
+type onceSchedule time.Time
+
+// Next returns the occurrence if it is after the given time.
+func (s onceSchedule) Next(t time.Time) *time.Time {
+	at := time.Time(s)
+	if !at.After(t) {
+		return nil
+	}
+	return &at
+}
+
+// eventBridgeWeekdays maps the names of weekdays in EventBridge cron expressions to their values.
+var eventBridgeWeekdays = map[string]int{"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7}
+
+// ParseEventBridgeCron parses the six fields of an EventBridge cron expression (minutes, hours, day-of-month,
+// month, day-of-week and year, e.g. "0 12 * * ? *") into a CronSchedule evaluated in the given location.
+// The fields support "*", lists, ranges and increments ("0/15"). Months and weekdays may be named (JAN, MON) and
+// weekdays are numbered from 1 (SUN) to 7 (SAT). Either day-of-month or day-of-week must be "?".
+// Day-of-month supports "L" (last day) and "W" (nearest weekday, e.g. "15W"), day-of-week supports "L" (last in
+// month, e.g. "6L") and "#" (nth in month, e.g. "6#3").
+func ParseEventBridgeCron(expr string, loc *time.Location) (*CronSchedule, error) {
+	if loc == nil {
+		return nil, errors.New("location cannot be nil")
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 6 {
+		return nil, errors.New("cron expression must have 6 fields")
+	}
+	if (fields[2] == "?") == (fields[4] == "?") {
+		return nil, errors.New("either day-of-month or day-of-week must be ?")
+	}
+	s := CronSchedule{location: loc, byWeekday: fields[2] == "?"}
+	var err error
+	if s.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if s.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if s.months, err = parseCronField(fields[3], 1, 12, cronMonths); err != nil {
+		return nil, err
+	}
+	if s.years, err = parseCronField(fields[5], 1970, cronMaxYear, nil); err != nil {
+		return nil, err
+	}
+	if s.byWeekday {
+		err = s.parseWeekdays(fields[4])
+	} else {
+		err = s.parseDays(fields[2])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// parseDays parses the day-of-month field.
+func (s *CronSchedule) parseDays(field string) error {
+	switch {
+	case field == "L":
+		s.lastDay = true
+		return nil
+	case strings.HasSuffix(field, "W"):
+		day, err := strconv.Atoi(strings.TrimSuffix(field, "W"))
+		if err != nil || day < 1 || day > 31 {
+			return errors.New("invalid cron day-of-month")
+		}
+		s.nearestWeekday = day
+		return nil
+	}
+	var err error
+	s.days, err = parseCronField(field, 1, 31, nil)
+	return err
+}
+
+// parseWeekdays parses the day-of-week field.
+func (s *CronSchedule) parseWeekdays(field string) error {
+	if i := strings.IndexByte(field, '#'); i >= 0 {
+		nth, err := strconv.Atoi(field[i+1:])
+		if err != nil || nth < 1 || nth > 5 {
+			return errors.New("invalid cron day-of-week")
+		}
+		s.nthWeekday = nth
+		field = field[:i]
+	} else if len(field) > 1 && strings.HasSuffix(field, "L") {
+		s.lastWeekday = true
+		field = strings.TrimSuffix(field, "L")
+	}
+	weekdays, err := parseCronField(field, 1, 7, eventBridgeWeekdays)
+	if err != nil {
+		return err
+	}
+	if (s.nthWeekday > 0 || s.lastWeekday) && strings.ContainsAny(field, ",-/*") {
+		return errors.New("invalid cron day-of-week")
+	}
+	// Shift SUN=1 ... SAT=7 to time.Weekday.
+	s.weekdays = cronField{min: 0, values: weekdays.values}
+	return nil
+}
@@ -0,0 +1,106 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEventBridgeExpression_Rate(t *testing.T) {
+	ref := time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC)
+	expectations := map[string]string{
+		"rate(1 minute)":  "R/2019-01-02T21:00:00Z/PT1M",
+		"rate(5 minutes)": "R/2019-01-02T21:00:00Z/PT5M",
+		"rate(12 hours)":  "R/2019-01-02T21:00:00Z/PT12H",
+		"rate(1 day)":     "R/2019-01-02T21:00:00Z/P1D",
+	}
+	for given, expected := range expectations {
+		s, err := ParseEventBridgeExpression(given, ref, time.UTC)
+		assert.Nil(t, err, given)
+		r, ok := s.(*Repeating)
+		assert.True(t, ok, given)
+		iso, err := r.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso)
+	}
+	for _, given := range []string{"rate(1 minutes)", "rate(5 minute)", "rate(0 minutes)", "rate(5 weeks)", "rate(5)", "rate 5 minutes"} {
+		_, err := ParseEventBridgeExpression(given, ref, time.UTC)
+		assert.NotNil(t, err, given)
+	}
+}
+
+func TestParseEventBridgeExpression_At(t *testing.T) {
+	ref := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	s, err := ParseEventBridgeExpression("at(2019-01-02T21:00:00)", ref, time.UTC)
+	assert.Nil(t, err)
+	at := time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC)
+	assert.Equal(t, at, *s.Next(ref))
+	assert.Nil(t, s.Next(at))
+
+	_, err = ParseEventBridgeExpression("at(2019-01-02)", ref, time.UTC)
+	assert.NotNil(t, err)
+	_, err = ParseEventBridgeExpression("every(5 minutes)", ref, time.UTC)
+	assert.NotNil(t, err)
+	_, err = ParseEventBridgeExpression("rate(5 minutes)", ref, nil)
+	assert.NotNil(t, err)
+}
+
+func TestParseEventBridgeCron(t *testing.T) {
+	from := time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC)
+	expectations := map[string][]string{
+		// Every day at noon.
+		"0 12 * * ? *": {"2019-01-03T12:00:00Z", "2019-01-04T12:00:00Z"},
+		// Every 15 minutes on weekdays.
+		"0/15 * ? * MON-FRI *": {"2019-01-02T21:15:00Z", "2019-01-02T21:30:00Z"},
+		// 10:15 on the last day of each month.
+		"15 10 L * ? *": {"2019-01-31T10:15:00Z", "2019-02-28T10:15:00Z"},
+		// 6:00 on the weekday nearest the 15th (2019-06-15 is a Saturday).
+		"0 6 15W JUN ? *": {"2019-06-14T06:00:00Z", "2020-06-15T06:00:00Z"},
+		// 8:00 on the third Friday of each month.
+		"0 8 ? * 6#3 *": {"2019-01-18T08:00:00Z", "2019-02-15T08:00:00Z"},
+		// 8:00 on the last Friday of each month.
+		"0 8 ? * 6L *": {"2019-01-25T08:00:00Z", "2019-02-22T08:00:00Z"},
+		// 9:30 and 17:30 on the 1st of March in 2020 and 2021.
+		"30 9,17 1 3 ? 2020-2021": {"2020-03-01T09:30:00Z", "2020-03-01T17:30:00Z", "2021-03-01T09:30:00Z"},
+	}
+	for given, expected := range expectations {
+		s, err := ParseEventBridgeCron(given, time.UTC)
+		assert.Nil(t, err, given)
+		at := from
+		for _, e := range expected {
+			next := s.Next(at)
+			if !assert.NotNil(t, next, given) {
+				break
+			}
+			assert.Equal(t, e, next.Format(time.RFC3339), given)
+			at = *next
+		}
+	}
+
+	s, err := ParseEventBridgeExpression("cron(0 12 * * ? 2019)", from, time.UTC)
+	assert.Nil(t, err)
+	assert.Nil(t, s.Next(time.Date(2019, 12, 31, 12, 0, 0, 0, time.UTC)))
+
+	// Times are evaluated in the given location.
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	c, err := ParseEventBridgeCron("0 12 * * ? *", loc)
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-03T10:00:00Z", c.Next(from).UTC().Format(time.RFC3339))
+
+	for _, given := range []string{
+		"0 12 * * *",
+		"0 12 * * * *",
+		"0 12 ? * ? *",
+		"60 12 * * ? *",
+		"0 12 * FOO ? *",
+		"0 12 32W * ? *",
+		"0 12 ? * 6#6 *",
+		"0 12 ? * MON-FRI#2 *",
+		"0/0 12 * * ? *",
+		"0 12 5-1 * ? *",
+	} {
+		_, err := ParseEventBridgeCron(given, time.UTC)
+		assert.NotNil(t, err, given)
+	}
+}
@@ -2,6 +2,7 @@ package timeinterval_test
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/corthmann/go-time-intervals/timeinterval"
@@ -67,3 +68,28 @@ func ExampleRepeating() {
 	// false
 	// 2019-01-02T21:15:00Z
 }
+
+func ExampleParseDurationISO8601() {
+	d, err := timeinterval.ParseDurationISO8601("P1DT1H30M")
+	fmt.Println(d, err)
+
+	s, err := timeinterval.FormatDurationISO8601(d)
+	fmt.Println(s, err)
+
+	// Output:
+	// 25h30m0s <nil>
+	// P1DT1H30M <nil>
+}
+
+func ExampleStringToIntervalHookFunc() {
+	// mapstructure calls the hook for every field while decoding configuration maps.
+	hook := timeinterval.StringToIntervalHookFunc()
+	v, err := hook(reflect.TypeOf(""), reflect.TypeOf(timeinterval.Interval{}), "2019-01-02T21:00:00Z/PT1H")
+	fmt.Println(err)
+	in := v.(timeinterval.Interval)
+	fmt.Println(in.EndsAt.Format(time.RFC3339))
+
+	// Output:
+	// <nil>
+	// 2019-01-02T22:00:00Z
+}
@@ -0,0 +1,35 @@
+package timeinterval
+
+import "sort"
+
+// FillGaps returns a continuous partition of the window: the intervals clipped to the window in order of their start,
+// with the time not covered by any of them filled by the intervals returned by filler, e.g. the gap with Meta marking
+// it as unassigned. Overlaps are resolved in favour of the earlier interval, whose successor starts when it ends.
+// The filler must return an interval covering exactly the given gap. If filler is nil, the gaps themselves are used.
+func FillGaps(ins []Interval, window Interval, filler func(gap Interval) Interval) []Interval {
+	if filler == nil {
+		filler = func(gap Interval) Interval { return gap }
+	}
+	clipped := NewPipeline(ins).Clip(window).Filter(func(in Interval) bool { return in.Duration() > 0 }).Collect()
+	sort.SliceStable(clipped, func(i, j int) bool {
+		return clipped[i].StartsAt.Before(clipped[j].StartsAt)
+	})
+	var result []Interval
+	cursor := window.StartsAt
+	for _, in := range clipped {
+		if !in.EndsAt.After(cursor) {
+			continue
+		}
+		if in.StartsAt.After(cursor) {
+			result = append(result, filler(Interval{StartsAt: cursor, EndsAt: in.StartsAt, Format: ISOFormatTimeAndTime}))
+		} else if in.StartsAt.Before(cursor) {
+			in = Interval{StartsAt: cursor, EndsAt: in.EndsAt, Format: ISOFormatTimeAndTime, Meta: in.Meta}
+		}
+		result = append(result, in)
+		cursor = in.EndsAt
+	}
+	if cursor.Before(window.EndsAt) {
+		result = append(result, filler(Interval{StartsAt: cursor, EndsAt: window.EndsAt, Format: ISOFormatTimeAndTime}))
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package timeinterval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillGaps(t *testing.T) {
+	window := MustParseIntervalISO8601("2019-01-02T00:00:00Z/P1D")
+	shifts := mustIntervals(t,
+		"2019-01-02T16:00:00Z/2019-01-03T02:00:00Z",
+		"2019-01-01T22:00:00Z/2019-01-02T06:00:00Z",
+		"2019-01-02T08:00:00Z/2019-01-02T12:00:00Z",
+		"2019-01-02T10:00:00Z/2019-01-02T11:00:00Z",
+		"2019-01-02T11:00:00Z/2019-01-02T14:00:00Z",
+	)
+	shifts[2] = shifts[2].WithMeta(MetaNote, "morning")
+	unassigned := func(gap Interval) Interval { return gap.WithMeta(MetaNote, "unassigned") }
+	result := FillGaps(shifts, *window, unassigned)
+	expected := []string{
+		"2019-01-02T00:00:00Z/2019-01-02T06:00:00Z",
+		"2019-01-02T06:00:00Z/2019-01-02T08:00:00Z",
+		"2019-01-02T08:00:00Z/2019-01-02T12:00:00Z",
+		"2019-01-02T12:00:00Z/2019-01-02T14:00:00Z",
+		"2019-01-02T14:00:00Z/2019-01-02T16:00:00Z",
+		"2019-01-02T16:00:00Z/2019-01-03T00:00:00Z",
+	}
+	if !assert.Equal(t, len(expected), len(result)) {
+		return
+	}
+	for i, e := range expected {
+		iso, err := result[i].ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, e, iso)
+	}
+	assert.Equal(t, "unassigned", result[1].Meta.Get(MetaNote))
+	assert.Equal(t, "morning", result[2].Meta.Get(MetaNote))
+	assert.Equal(t, "", result[3].Meta.Get(MetaNote))
+	assert.Equal(t, "unassigned", result[4].Meta.Get(MetaNote))
+
+	// Without intervals the window is a single gap.
+	result = FillGaps(nil, *window, nil)
+	assert.Equal(t, 1, len(result))
+	assert.True(t, result[0].StartsAt.Equal(window.StartsAt))
+	assert.True(t, result[0].EndsAt.Equal(window.EndsAt))
+}
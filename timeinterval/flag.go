@@ -0,0 +1,55 @@
+package timeinterval
+
+// IntervalFlag is a flag.Value accepting an ISO8601 "interval" string, so CLIs can take intervals as flags, e.g.:
+//
+//	var window timeinterval.IntervalFlag
+//	flag.Var(&window, "window", "maintenance window, e.g. 2024-01-01T00:00:00Z/PT15M")
+type IntervalFlag struct {
+	// Interval is the parsed interval or nil if the flag was not set.
+	Interval *Interval
+}
+
+// String returns the interval as an ISO8601 "interval" string or an empty string if it is unset.
+func (f *IntervalFlag) String() string {
+	if f == nil || f.Interval == nil {
+		return ""
+	}
+	s, _ := f.Interval.ISO8601()
+	return s
+}
+
+// Set parses the ISO8601 "interval" string into the flag.
+func (f *IntervalFlag) Set(s string) error {
+	in, err := ParseIntervalISO8601(s)
+	if err != nil {
+		return err
+	}
+	f.Interval = in
+	return nil
+}
+
+// RepeatingFlag is a flag.Value accepting an ISO8601 "repeating interval" string, e.g.
+// --schedule R5/PT15M/2024-01-01T00:00:00Z. See: IntervalFlag.
+type RepeatingFlag struct {
+	// Repeating is the parsed repeating interval or nil if the flag was not set.
+	Repeating *Repeating
+}
+
+// String returns the repeating interval as an ISO8601 "repeating interval" string or an empty string if it is unset.
+func (f *RepeatingFlag) String() string {
+	if f == nil || f.Repeating == nil {
+		return ""
+	}
+	s, _ := f.Repeating.ISO8601()
+	return s
+}
+
+// Set parses the ISO8601 "repeating interval" string into the flag.
+func (f *RepeatingFlag) Set(s string) error {
+	r, err := ParseRepeatingIntervalISO8601(s)
+	if err != nil {
+		return err
+	}
+	f.Repeating = r
+	return nil
+}
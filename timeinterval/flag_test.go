@@ -0,0 +1,31 @@
+package timeinterval
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalFlag(t *testing.T) {
+	var window IntervalFlag
+	var schedule RepeatingFlag
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Var(&window, "window", "")
+	fs.Var(&schedule, "schedule", "")
+	assert.Equal(t, "", window.String())
+	assert.Equal(t, "", schedule.String())
+
+	err := fs.Parse([]string{"--window", "2024-01-01T00:00:00Z/PT15M", "--schedule", "R5/PT15M/2024-01-01T00:00:00Z"})
+	assert.Nil(t, err)
+	assert.Equal(t, *MustParseIntervalISO8601("2024-01-01T00:00:00Z/PT15M"), *window.Interval)
+	assert.Equal(t, "2024-01-01T00:00:00Z/PT15M", window.String())
+	assert.Equal(t, uint32(5), *schedule.Repeating.Repetitions)
+	assert.Equal(t, "R5/PT15M/2024-01-01T00:00:00Z", schedule.String())
+
+	assert.NotNil(t, fs.Parse([]string{"--window", "PT15M"}))
+	assert.NotNil(t, fs.Parse([]string{"--schedule", "2024-01-01T00:00:00Z/PT15M"}))
+	assert.Equal(t, "2024-01-01T00:00:00Z/PT15M", window.String())
+}
@@ -0,0 +1,114 @@
+//go:build go1.18
+// +build go1.18
+
+package timeinterval
+
+import (
+	"testing"
+)
+
+// fuzzSeeds are inputs that must be consumed entirely, including regressions of trailing garbage and extra parts.
+var fuzzSeeds = []string{
+	"2019-01-02T21:00:00Z/P1W",
+	"2019-01-02T21:00:00Z/2019-01-03T21:00:00Z",
+	"P1W/2019-01-02T21:00:00Z",
+	"2019-01-02T21:00:00Z/..",
+	"2007-11-13T09:00Z/15:30",
+	"2019-01-02T21:00:00+01:00[Europe/Copenhagen]/P1D",
+	"2019-W01-3T21:00:00Z/PT1H30M",
+	"2019-01-02T21:00:00Z/P1Wfoo",
+	"2019-01-02T21:00:00Z/P1W/foo",
+	"2019-01-02T21:00:00Z/P1W/",
+	"2019-01-02T21:00:00Z/P1W ",
+	"2019-01-02T21:00:00Zfoo/P1W",
+	"2019-01-02T21:00:00Z/2019-01-03T21:00:00Zx",
+	"2019-01-02T21:00:00Z/..x",
+	"2019-01-02T21:00:00Z/15:30:00x",
+	"2019-01-02T21:00:00Z[Europe/Berlin]x/P1D",
+	"2019-01-02T21:00:00Z/PT1H1H",
+	"2019-01-02T21:00:00Z/P1.5DT",
+}
+
+// checkParseError verifies that a failed parse of s returned a ParseError pointing into s.
+func checkParseError(t *testing.T, s string, err error) {
+	parseErr, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("%q: expected a ParseError, got %T", s, err)
+	}
+	if parseErr.Err == nil || parseErr.Input != s {
+		t.Fatalf("%q: invalid ParseError %#v", s, parseErr)
+	}
+	if parseErr.Offset < 0 || parseErr.Offset > len(s) {
+		t.Fatalf("%q: offset %d out of range", s, parseErr.Offset)
+	}
+}
+
+func FuzzParseIntervalISO8601(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		in, err := ParseIntervalISO8601(s)
+		if err != nil {
+			checkParseError(t, s, err)
+			return
+		}
+		iso, err := in.ISO8601()
+		if err != nil {
+			return
+		}
+		// Formatted intervals parse again and format the same.
+		parsed, err := ParseIntervalISO8601(iso)
+		if err != nil {
+			t.Fatalf("%q: formatted as %q which fails to parse: %v", s, iso, err)
+		}
+		if again, err := parsed.ISO8601(); err != nil || again != iso {
+			t.Fatalf("%q: formatted as %q and then as %q", s, iso, again)
+		}
+	})
+}
+
+func FuzzParseRepeatingIntervalISO8601(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add("R5/" + seed)
+	}
+	f.Add("R5x/2019-01-02T21:00:00Z/P1W")
+	f.Add("R-1/2019-01-02T21:00:00Z/P1W")
+	f.Add("R5/2019-01-02T21:00:00Z/PT0S")
+	f.Fuzz(func(t *testing.T, s string) {
+		r, err := ParseRepeatingIntervalISO8601(s)
+		if err != nil {
+			checkParseError(t, s, err)
+			return
+		}
+		iso, err := r.ISO8601()
+		if err != nil {
+			return
+		}
+		if _, err := ParseRepeatingIntervalISO8601(iso); err != nil {
+			t.Fatalf("%q: formatted as %q which fails to parse: %v", s, iso, err)
+		}
+	})
+}
+
+func FuzzParseDurationISO8601(f *testing.F) {
+	for _, seed := range []string{"PT1H30M", "P1W", "P1DT0.5S", "PT0,5H", "PT1H30Mfoo", "P1D1D", "PT", "P1.5H"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := ParseDurationISO8601(s)
+		if err != nil {
+			if _, ok := err.(*ParseError); ok {
+				checkParseError(t, s, err)
+			}
+			return
+		}
+		iso, err := FormatDurationISO8601(d)
+		if err != nil {
+			return
+		}
+		if again, err := ParseDurationISO8601(iso); err != nil || again != d {
+			t.Fatalf("%q: formatted as %q which parses as %v", s, iso, again)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+/*
+Package gql provides gqlgen compatible GraphQL scalar implementations for the timeinterval types.
+
+The types in this package embed their timeinterval counterparts and are (un)marshaled as ISO8601 strings.
+They can be bound to custom scalars in gqlgen.yml, e.g.:
+
+	models:
+	  Interval:
+	    model: github.com/corthmann/go-time-intervals/timeinterval/gql.Interval
+	  RepeatingInterval:
+	    model: github.com/corthmann/go-time-intervals/timeinterval/gql.Repeating
+*/
+package gql
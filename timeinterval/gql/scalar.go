@@ -0,0 +1,82 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/corthmann/go-time-intervals/timeinterval"
+)
+
+// Interval is a GraphQL scalar representing a timeinterval.Interval as an ISO8601 "interval" string.
+type Interval struct {
+	timeinterval.Interval
+}
+
+// Repeating is a GraphQL scalar representing a timeinterval.Repeating as an ISO8601 "repeating interval" string.
+type Repeating struct {
+	timeinterval.Repeating
+}
+
+// MarshalGQL writes the interval as a quoted ISO8601 "interval" string.
+// null is written if the interval cannot be represented in ISO8601.
+func (in Interval) MarshalGQL(w io.Writer) {
+	writeISO8601(w, in.Interval.ISO8601)
+}
+
+// UnmarshalGQL unmarshal Interval from an ISO8601 "interval" string.
+func (in *Interval) UnmarshalGQL(v interface{}) error {
+	s, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+	i, err := timeinterval.ParseIntervalISO8601(s)
+	if err != nil {
+		return err
+	}
+	in.Interval = *i
+	return nil
+}
+
+// MarshalGQL writes the repeating interval as a quoted ISO8601 "repeating interval" string.
+// null is written if the repeating interval cannot be represented in ISO8601.
+func (in Repeating) MarshalGQL(w io.Writer) {
+	writeISO8601(w, in.Repeating.ISO8601)
+}
+
+// UnmarshalGQL unmarshal Repeating from an ISO8601 "repeating interval" string.
+func (in *Repeating) UnmarshalGQL(v interface{}) error {
+	s, err := stringValue(v)
+	if err != nil {
+		return err
+	}
+	ri, err := timeinterval.ParseRepeatingIntervalISO8601(s)
+	if err != nil {
+		return err
+	}
+	in.Repeating = *ri
+	return nil
+}
+
+func writeISO8601(w io.Writer, iso func() (string, error)) {
+	s, err := iso()
+	if err != nil {
+		io.WriteString(w, "null")
+		return
+	}
+	io.WriteString(w, strconv.Quote(s))
+}
+
+func stringValue(v interface{}) (string, error) {
+	switch s := v.(type) {
+	case string:
+		return s, nil
+	case []byte:
+		return string(s), nil
+	case nil:
+		return "", errors.New("scalar value must not be null")
+	default:
+		return "", fmt.Errorf("scalar value must be a string, got %T", v)
+	}
+}
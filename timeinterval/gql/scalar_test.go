@@ -0,0 +1,60 @@
+package gql
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval_MarshalGQL(t *testing.T) {
+	expectations := []string{
+		"2019-01-02T21:00:00Z/2022-01-03T21:00:00Z",
+		"2019-01-02T21:00:00Z/P1W",
+		"P1W/2022-01-03T21:00:00Z",
+	}
+	for _, expected := range expectations {
+		in := Interval{}
+		err := in.UnmarshalGQL(expected)
+		assert.Nil(t, err)
+		var buf bytes.Buffer
+		in.MarshalGQL(&buf)
+		result, err := strconv.Unquote(buf.String())
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+	}
+}
+
+func TestInterval_UnmarshalGQL(t *testing.T) {
+	in := Interval{}
+	assert.NotNil(t, in.UnmarshalGQL(nil))
+	assert.NotNil(t, in.UnmarshalGQL(42))
+	assert.NotNil(t, in.UnmarshalGQL("P1W/P1D"))
+	assert.Nil(t, in.UnmarshalGQL([]byte("2019-01-02T21:00:00Z/P1W")))
+	assert.Equal(t, "2019-01-09T21:00:00Z", in.EndsAt.Format("2006-01-02T15:04:05Z07:00"))
+}
+
+func TestRepeating_MarshalGQL(t *testing.T) {
+	expectations := []string{
+		"R/2019-01-02T21:00:00Z/P1W",
+		"R10/P1W/2022-01-03T21:00:00Z",
+	}
+	for _, expected := range expectations {
+		in := Repeating{}
+		err := in.UnmarshalGQL(expected)
+		assert.Nil(t, err)
+		var buf bytes.Buffer
+		in.MarshalGQL(&buf)
+		result, err := strconv.Unquote(buf.String())
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+	}
+}
+
+func TestRepeating_UnmarshalGQL(t *testing.T) {
+	in := Repeating{}
+	assert.NotNil(t, in.UnmarshalGQL(nil))
+	assert.NotNil(t, in.UnmarshalGQL(true))
+	assert.NotNil(t, in.UnmarshalGQL("2019-01-02T21:00:00Z/P1W"))
+}
@@ -0,0 +1,136 @@
+package timeinterval
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseGrafanaRange parses the "from" and "to" time expressions of a Grafana time range (e.g. the from and to
+// parameters of a dashboard URL) into an interval. Expressions are Unix times in milliseconds or relative to ref:
+// "now" followed by any number of offsets ("-6h", "+1d") and optionally rounded to the start of a unit ("/d").
+// The units are s, m, h, d, w, M (months) and y, where days and longer follow the calendar of the given location and
+// weeks start on Monday. Like in Grafana, "to" is rounded up to the end of the unit, so "now/d" to "now/d" is today.
+// Unlike Grafana, the end of a unit is the start of the next one.
+func ParseGrafanaRange(from, to string, ref time.Time, loc *time.Location) (*Interval, error) {
+	if loc == nil {
+		return nil, errors.New("location cannot be nil")
+	}
+	startsAt, err := parseGrafanaTime(from, ref.In(loc), loc, false)
+	if err != nil {
+		return nil, err
+	}
+	endsAt, err := parseGrafanaTime(to, ref.In(loc), loc, true)
+	if err != nil {
+		return nil, err
+	}
+	return NewInterval(&startsAt, &endsAt, nil)
+}
+
+// ParseGrafanaExpression parses a Grafana time range written as a single expression into an interval (see:
+// ParseGrafanaRange): "from/to" where "to" starts with "now" (e.g. "now-6h/now"), an expression rounded to a unit
+// covering that whole unit (e.g. "now/d" for today or "now-1d/d" for yesterday), or an expression without rounding
+// up to now (e.g. "now-6h").
+func ParseGrafanaExpression(expr string, ref time.Time, loc *time.Location) (*Interval, error) {
+	if i := strings.Index(expr, "/now"); i > 0 {
+		return ParseGrafanaRange(expr[:i], expr[i+1:], ref, loc)
+	}
+	if strings.Contains(expr, "/") {
+		return ParseGrafanaRange(expr, expr, ref, loc)
+	}
+	return ParseGrafanaRange(expr, "now", ref, loc)
+}
+
+// parseGrafanaTime parses a Grafana time expression. Rounding is up to the end of the unit if roundUp is set.
+func parseGrafanaTime(expr string, ref time.Time, loc *time.Location, roundUp bool) (time.Time, error) {
+	if ms, err := strconv.ParseInt(expr, 10, 64); err == nil {
+		return unixMilli(ms).In(loc), nil
+	}
+	if !strings.HasPrefix(expr, "now") {
+		return time.Time{}, errors.New("invalid grafana time expression")
+	}
+	t := ref
+	s := expr[len("now"):]
+	for len(s) > 0 {
+		switch s[0] {
+		case '/':
+			if len(s) != 2 {
+				return time.Time{}, errors.New("invalid grafana time rounding")
+			}
+			start, ok := startOfGrafanaUnit(t, s[1], loc)
+			if !ok {
+				return time.Time{}, errors.New("invalid grafana time unit")
+			}
+			if roundUp {
+				start, _ = addGrafanaUnits(start, 1, s[1])
+			}
+			return start, nil
+		case '+', '-':
+			i := 1
+			for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+				i++
+			}
+			if i >= len(s) {
+				return time.Time{}, errors.New("invalid grafana time offset")
+			}
+			n := 1
+			if i > 1 {
+				n, _ = strconv.Atoi(s[1:i])
+			}
+			if s[0] == '-' {
+				n = -n
+			}
+			var ok bool
+			if t, ok = addGrafanaUnits(t, n, s[i]); !ok {
+				return time.Time{}, errors.New("invalid grafana time unit")
+			}
+			s = s[i+1:]
+		default:
+			return time.Time{}, errors.New("invalid grafana time expression")
+		}
+	}
+	return t, nil
+}
+
+// addGrafanaUnits returns t moved by n of the given unit.
+func addGrafanaUnits(t time.Time, n int, unit byte) (time.Time, bool) {
+	switch unit {
+	case 's':
+		return t.Add(time.Duration(n) * time.Second), true
+	case 'm':
+		return t.Add(time.Duration(n) * time.Minute), true
+	case 'h':
+		return t.Add(time.Duration(n) * time.Hour), true
+	case 'd':
+		return t.AddDate(0, 0, n), true
+	case 'w':
+		return t.AddDate(0, 0, 7*n), true
+	case 'M':
+		return Period{Months: 1}.Shift(t, n), true
+	case 'y':
+		return Period{Years: 1}.Shift(t, n), true
+	}
+	return t, false
+}
+
+// startOfGrafanaUnit returns the start of the given unit containing t in the location.
+func startOfGrafanaUnit(t time.Time, unit byte, loc *time.Location) (time.Time, bool) {
+	switch unit {
+	case 's':
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc), true
+	case 'm':
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc), true
+	case 'h':
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc), true
+	case 'd':
+		return startOfCalendarUnit(t, calendarUnits["day"], time.Monday, loc), true
+	case 'w':
+		return startOfCalendarUnit(t, calendarUnits["week"], time.Monday, loc), true
+	case 'M':
+		return startOfCalendarUnit(t, calendarUnits["month"], time.Monday, loc), true
+	case 'y':
+		return startOfCalendarUnit(t, calendarUnits["year"], time.Monday, loc), true
+	}
+	return t, false
+}
@@ -0,0 +1,75 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGrafanaRange(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	assert.Nil(t, err)
+	ref := time.Date(2019, 3, 31, 14, 30, 15, 0, loc)
+	expectations := map[[2]string]string{
+		{"now-6h", "now"}:             "2019-03-31T08:30:15+02:00/2019-03-31T14:30:15+02:00",
+		{"now/d", "now/d"}:            "2019-03-31T00:00:00+01:00/2019-04-01T00:00:00+02:00",
+		{"now-1d/d", "now-1d/d"}:      "2019-03-30T00:00:00+01:00/2019-03-31T00:00:00+01:00",
+		{"now/w", "now"}:              "2019-03-25T00:00:00+01:00/2019-03-31T14:30:15+02:00",
+		{"now-1M/M", "now-1M/M"}:      "2019-02-01T00:00:00+01:00/2019-03-01T00:00:00+01:00",
+		{"now/y", "now/y"}:            "2019-01-01T00:00:00+01:00/2020-01-01T00:00:00+01:00",
+		{"now-1h/h", "now/m"}:         "2019-03-31T13:00:00+02:00/2019-03-31T14:31:00+02:00",
+		{"now-2d+12h", "now+1s/s"}:    "2019-03-30T02:30:15+01:00/2019-03-31T14:30:17+02:00",
+		{"1546462800000", "now"}:      "2019-01-02T22:00:00+01:00/2019-03-31T14:30:15+02:00",
+		{"now-1w", "now-d"}:           "2019-03-24T14:30:15+01:00/2019-03-30T14:30:15+01:00",
+		{"now-1y/y", "1546462800000"}: "2018-01-01T00:00:00+01:00/2019-01-02T22:00:00+01:00",
+	}
+	for given, expected := range expectations {
+		in, err := ParseGrafanaRange(given[0], given[1], ref, loc)
+		if !assert.Nil(t, err, given[0]) {
+			continue
+		}
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso, given[0]+" to "+given[1])
+	}
+
+	// Months are clamped to their last day.
+	in, err := ParseGrafanaRange("now-1M", "now", time.Date(2019, 3, 31, 12, 0, 0, 0, loc), loc)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 2, 28, 12, 0, 0, 0, loc), in.StartsAt)
+
+	for _, given := range [][2]string{
+		{"now", "now-1h"}, {"today", "now"}, {"now-", "now"}, {"now-6x", "now"}, {"now/x", "now"},
+		{"now/dd", "now"}, {"now*2", "now"}, {"now", "later"},
+	} {
+		_, err := ParseGrafanaRange(given[0], given[1], ref, loc)
+		assert.NotNil(t, err, given[0]+" to "+given[1])
+	}
+	_, err = ParseGrafanaRange("now-1h", "now", ref, nil)
+	assert.NotNil(t, err)
+}
+
+func TestParseGrafanaExpression(t *testing.T) {
+	ref := time.Date(2019, 1, 2, 21, 30, 0, 0, time.UTC)
+	expectations := map[string]string{
+		"now-6h/now":   "2019-01-02T15:30:00Z/2019-01-02T21:30:00Z",
+		"now/d":        "2019-01-02T00:00:00Z/2019-01-03T00:00:00Z",
+		"now-1d/d":     "2019-01-01T00:00:00Z/2019-01-02T00:00:00Z",
+		"now-6h":       "2019-01-02T15:30:00Z/2019-01-02T21:30:00Z",
+		"now-7d/now/d": "2018-12-26T21:30:00Z/2019-01-03T00:00:00Z",
+	}
+	for given, expected := range expectations {
+		in, err := ParseGrafanaExpression(given, ref, time.UTC)
+		if !assert.Nil(t, err, given) {
+			continue
+		}
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso, given)
+	}
+	for _, given := range []string{"", "now+1h", "now/now-1h", "now-1h/later"} {
+		_, err := ParseGrafanaExpression(given, ref, time.UTC)
+		assert.NotNil(t, err, given)
+	}
+}
@@ -0,0 +1,146 @@
+package timeinterval
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// icsTimeLayout is the iCalendar UTC DATE-TIME format. See: RFC 5545 section 3.3.5.
+const icsTimeLayout = "20060102T150405Z"
+
+// EncodeFreeBusy writes the intervals as busy periods of an iCalendar VFREEBUSY component.
+// See: RFC 5545 section 3.6.4.
+// The component is written without the surrounding VCALENDAR so that it can be embedded by the caller.
+// Its required DTSTAMP is the current time and its UID is derived from the busy periods (See: Interval.ID), so that
+// encoding the same periods again updates the same component.
+// An error is returned for open intervals, since busy periods must have a start and an end.
+func EncodeFreeBusy(w io.Writer, ins []Interval) error {
+	periods := make([]string, len(ins))
+	for i, in := range ins {
+		if in.OpenStart() || in.OpenEnd() {
+			return errors.New("open intervals cannot be encoded as busy periods")
+		}
+		periods[i] = in.canonical()
+	}
+	lines := []string{
+		"BEGIN:VFREEBUSY",
+		"DTSTAMP:" + time.Now().UTC().Format(icsTimeLayout),
+		"UID:" + newID(strings.Join(periods, ",")) + "@timeinterval",
+	}
+	if len(ins) > 0 {
+		covered := ins[0]
+		for _, in := range ins[1:] {
+			covered = span(covered, in)
+		}
+		lines = append(lines,
+			"DTSTART:"+covered.StartsAt.UTC().Format(icsTimeLayout),
+			"DTEND:"+covered.EndsAt.UTC().Format(icsTimeLayout))
+	}
+	for _, in := range ins {
+		lines = append(lines, fmt.Sprintf("FREEBUSY;FBTYPE=BUSY:%s/%s",
+			in.StartsAt.UTC().Format(icsTimeLayout), in.EndsAt.UTC().Format(icsTimeLayout)))
+	}
+	lines = append(lines, "END:VFREEBUSY")
+	for _, line := range lines {
+		if _, err := io.WriteString(w, line+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeFreeBusy reads the busy periods of all VFREEBUSY components in the given iCalendar data.
+// See: RFC 5545 section 3.6.4.
+// Periods explicitly marked as free (FBTYPE=FREE) are ignored.
+func DecodeFreeBusy(r io.Reader) ([]Interval, error) {
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, err
+	}
+	var result []Interval
+	inFreeBusy := false
+	for _, line := range lines {
+		sep := strings.Index(line, ":")
+		if sep < 0 {
+			continue
+		}
+		nameAndParams := strings.Split(line[:sep], ";")
+		value := line[sep+1:]
+		switch strings.ToUpper(nameAndParams[0]) {
+		case "BEGIN":
+			inFreeBusy = inFreeBusy || strings.EqualFold(value, "VFREEBUSY")
+		case "END":
+			if strings.EqualFold(value, "VFREEBUSY") {
+				inFreeBusy = false
+			}
+		case "FREEBUSY":
+			if !inFreeBusy || freeBusyType(nameAndParams[1:]) == "FREE" {
+				continue
+			}
+			for _, period := range strings.Split(value, ",") {
+				in, err := parseICSPeriod(period)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, *in)
+			}
+		}
+	}
+	return result, nil
+}
+
+// unfoldICSLines returns the content lines of the iCalendar data with folded lines joined.
+// See: RFC 5545 section 3.1.
+func unfoldICSLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+func freeBusyType(params []string) string {
+	for _, param := range params {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) == 2 && strings.EqualFold(kv[0], "FBTYPE") {
+			return strings.ToUpper(kv[1])
+		}
+	}
+	return "BUSY"
+}
+
+// parseICSPeriod parses an iCalendar PERIOD value (start/end or start/duration). See: RFC 5545 section 3.3.9.
+func parseICSPeriod(s string) (*Interval, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return nil, errors.New("invalid period format")
+	}
+	startsAt, err := time.Parse(icsTimeLayout, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(parts[1], "P") {
+		d, err := ParseDurationISO8601(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return NewInterval(&startsAt, nil, &d)
+	}
+	endsAt, err := time.Parse(icsTimeLayout, parts[1])
+	if err != nil {
+		return nil, err
+	}
+	return NewInterval(&startsAt, &endsAt, nil)
+}
@@ -0,0 +1,69 @@
+package timeinterval
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeFreeBusy(t *testing.T) {
+	a, err := ParseIntervalISO8601("2019-01-02T21:00:00Z/2019-01-02T22:00:00Z")
+	assert.Nil(t, err)
+	b, err := ParseIntervalISO8601("2019-01-03T07:00:00Z/P1D")
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	err = EncodeFreeBusy(&buf, []Interval{*a, *b})
+	assert.Nil(t, err)
+	expected := "BEGIN:VFREEBUSY\r\n" +
+		"DTSTART:20190102T210000Z\r\n" +
+		"DTEND:20190104T070000Z\r\n" +
+		"FREEBUSY;FBTYPE=BUSY:20190102T210000Z/20190102T220000Z\r\n" +
+		"FREEBUSY;FBTYPE=BUSY:20190103T070000Z/20190104T070000Z\r\n" +
+		"END:VFREEBUSY\r\n"
+	// DTSTAMP is the time of encoding and UID identifies the busy periods.
+	lines := strings.SplitN(buf.String(), "\r\n", 4)
+	if assert.Len(t, lines, 4) {
+		stamp, err := time.Parse(icsTimeLayout, strings.TrimPrefix(lines[1], "DTSTAMP:"))
+		assert.Nil(t, err)
+		assert.True(t, time.Since(stamp) < time.Minute)
+		assert.Equal(t, "UID:"+newID(a.canonical()+","+b.canonical())+"@timeinterval", lines[2])
+		assert.Equal(t, expected, lines[0]+"\r\n"+lines[3])
+	}
+
+	result, err := DecodeFreeBusy(&buf)
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	assert.True(t, result[1].StartsAt.Equal(b.StartsAt))
+	assert.True(t, result[1].EndsAt.Equal(b.EndsAt))
+
+	// Busy periods cannot be open.
+	buf.Reset()
+	assert.NotNil(t, EncodeFreeBusy(&buf, []Interval{*a, *NewOpenStartInterval(b.EndsAt)}))
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestDecodeFreeBusy(t *testing.T) {
+	input := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VFREEBUSY\r\n" +
+		"FREEBUSY:20190102T210000Z/P1D,\r\n" +
+		" 20190105T210000Z/20190105T230000Z\r\n" +
+		"FREEBUSY;FBTYPE=FREE:20190103T210000Z/P1D\r\n" +
+		"END:VFREEBUSY\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"FREEBUSY:20190103T210000Z/P1D\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	result, err := DecodeFreeBusy(strings.NewReader(input))
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, 24*time.Hour, result[0].Duration())
+	assert.Equal(t, ISOFormatTimeAndDuration, result[0].Format)
+	assert.Equal(t, 2*time.Hour, result[1].Duration())
+
+	_, err = DecodeFreeBusy(strings.NewReader("BEGIN:VFREEBUSY\nFREEBUSY:20190102T210000Z\nEND:VFREEBUSY\n"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,60 @@
+package timeinterval
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// idEncoding is the URL-safe encoding of identifiers.
+var idEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// idLength is the number of characters of an identifier (80 bits of the hash).
+const idLength = 16
+
+// ID returns a compact, URL-safe identifier of the interval which is derived from its start and end instants.
+// Intervals covering the same instants have the same ID, regardless of their time zone and ISO8601 format,
+// so it can be used to reference a window in APIs or as an idempotency key.
+func (in Interval) ID() string {
+	return newID(in.instants())
+}
+
+// OccurrenceID returns a compact, URL-safe identifier of the repetition with the given index (0 is the first).
+// It is derived from the repeating interval and the index, so it is stable across processes and restarts.
+func (in Repeating) OccurrenceID(index int) string {
+	return newID(fmt.Sprintf("%s#%d", in.canonical(), index))
+}
+
+// canonical returns a representation of the repeating interval that only depends on its repetitions, instants and
+// calendar period.
+func (in Repeating) canonical() string {
+	reps := "R"
+	if in.Repetitions != nil {
+		reps = fmt.Sprintf("R%d", *in.Repetitions)
+	}
+	return reps + "/" + in.Interval.canonical()
+}
+
+// instants returns a representation of the interval that only depends on its start and end instants.
+func (in Interval) instants() string {
+	return in.StartsAt.UTC().Format(time.RFC3339Nano) + "/" + in.EndsAt.UTC().Format(time.RFC3339Nano)
+}
+
+// canonical returns a representation of the interval that only depends on its instants and calendar period, which
+// determines the following repetitions of a repeating interval.
+func (in Interval) canonical() string {
+	s := in.instants()
+	if in.Period != nil {
+		if p, err := in.Period.ISO8601(); err == nil {
+			s += "/" + p
+		}
+	}
+	return s
+}
+
+func newID(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return strings.ToLower(idEncoding.EncodeToString(sum[:]))[:idLength]
+}
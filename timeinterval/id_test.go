@@ -0,0 +1,32 @@
+package timeinterval
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval_ID(t *testing.T) {
+	a := MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1D")
+	b := MustParseIntervalISO8601("2019-01-02T22:00:00+01:00/2019-01-03T22:00:00+01:00")
+	c := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT23H")
+	assert.Equal(t, a.ID(), b.ID())
+	assert.NotEqual(t, a.ID(), c.ID())
+	assert.True(t, regexp.MustCompile("^[a-z2-7]{16}$").MatchString(a.ID()))
+	assert.Equal(t, a.ID(), MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1D").ID())
+	// A calendar period does not change the instants.
+	assert.Equal(t, MustParseIntervalISO8601("2019-01-31T00:00:00Z/P1M").ID(), MustParseIntervalISO8601("2019-01-31T00:00:00Z/2019-02-28T00:00:00Z").ID())
+}
+
+func TestRepeating_OccurrenceID(t *testing.T) {
+	r := MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H")
+	ids := map[string]bool{}
+	for i := 0; i < 5; i++ {
+		ids[r.OccurrenceID(i)] = true
+	}
+	assert.Len(t, ids, 5)
+	assert.Equal(t, r.OccurrenceID(3), MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H").OccurrenceID(3))
+	assert.NotEqual(t, r.OccurrenceID(3), MustParseRepeatingIntervalISO8601("R6/2019-01-02T21:00:00Z/PT1H").OccurrenceID(3))
+	assert.NotEqual(t, r.OccurrenceID(0), r.Interval.ID())
+}
@@ -0,0 +1,179 @@
+package timeinterval
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// The index file format holds intervals sorted by start and then end for stabbing and range queries without
+// deserializing the whole file. It starts with a 16 byte header:
+//
+//	magic "TIVX" | version (1 byte) | reserved (3 bytes) | number of intervals (big-endian uint64)
+//
+// followed by one 24 byte record per interval:
+//
+//	packed interval (16 bytes, see: Interval.Pack) | largest end of this and all preceding records (8 bytes)
+//
+// The largest end is encoded like the end of a packed interval. It bounds how far queries scan backwards.
+const indexMagic = "TIVX"
+
+const indexVersion = 1
+
+const indexHeaderSize = 16
+
+const indexRecordSize = 24
+
+// WriteIndex writes the intervals to w in the index file format. See: OpenIndex.
+// Like Pack, the index keeps neither time zones, formats, periods nor Meta of the intervals.
+func WriteIndex(w io.Writer, ins []Interval) error {
+	records := make([][16]byte, len(ins))
+	for i, in := range ins {
+		b, err := in.Pack()
+		if err != nil {
+			return err
+		}
+		records[i] = b
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return bytes.Compare(records[i][:], records[j][:]) < 0
+	})
+
+	bw := bufio.NewWriter(w)
+	header := make([]byte, indexHeaderSize)
+	copy(header, indexMagic)
+	header[4] = indexVersion
+	binary.BigEndian.PutUint64(header[8:], uint64(len(records)))
+	bw.Write(header)
+	var maxEnd []byte
+	for _, r := range records {
+		if maxEnd == nil || bytes.Compare(r[8:], maxEnd) > 0 {
+			maxEnd = append([]byte{}, r[8:]...)
+		}
+		bw.Write(r[:])
+		bw.Write(maxEnd)
+	}
+	return bw.Flush()
+}
+
+// Index is a read-only view of an index file. It is safe for concurrent use until it is closed. See: OpenIndex.
+type Index struct {
+	data  []byte
+	n     int
+	close func() error
+}
+
+// OpenIndex opens the index file written by WriteIndex at the given path. The file is memory-mapped where supported
+// (and read otherwise), so that queries only decode the intervals they return. The Index must be closed when done.
+func OpenIndex(path string) (*Index, error) {
+	data, closeFn, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := newIndex(data)
+	if err != nil {
+		closeFn()
+		return nil, err
+	}
+	idx.close = closeFn
+	return idx, nil
+}
+
+// newIndex returns an Index over data in the index file format.
+func newIndex(data []byte) (*Index, error) {
+	if len(data) < indexHeaderSize || string(data[:4]) != indexMagic {
+		return nil, errors.New("invalid index file")
+	}
+	if data[4] != indexVersion {
+		return nil, errors.New("unsupported index file version")
+	}
+	n := binary.BigEndian.Uint64(data[8:indexHeaderSize])
+	if rest := len(data) - indexHeaderSize; rest%indexRecordSize != 0 || uint64(rest/indexRecordSize) != n {
+		return nil, errors.New("invalid index file length")
+	}
+	return &Index{data: data, n: int(n)}, nil
+}
+
+// Close releases the index file. The Index cannot be used afterwards.
+func (idx *Index) Close() error {
+	idx.data = nil
+	idx.n = 0
+	if idx.close == nil {
+		return nil
+	}
+	return idx.close()
+}
+
+// Len returns the number of intervals in the index.
+func (idx *Index) Len() int {
+	return idx.n
+}
+
+// At returns the i-th interval of the index in the order of their start and end. The times are in UTC.
+// An error is returned if i is not within [0, Len()).
+func (idx *Index) At(i int) (*Interval, error) {
+	if i < 0 || i >= idx.n {
+		return nil, errors.New("index out of range")
+	}
+	var b [16]byte
+	copy(b[:], idx.record(i)[:16])
+	return Unpack(b)
+}
+
+// Stab returns the intervals active (see: Interval#In) at the given time in the order of their start.
+func (idx *Index) Stab(t time.Time) ([]Interval, error) {
+	ns, _ := Interval{StartsAt: t, EndsAt: t}.clampedUnixNano()
+	return idx.query(ns, ns)
+}
+
+// Range returns the intervals overlapping the given window in the order of their start. Like Collection.Within,
+// intervals merely touching the window are not included.
+func (idx *Index) Range(window Interval) ([]Interval, error) {
+	from, to := window.clampedUnixNano()
+	if to == math.MinInt64 || from == math.MaxInt64 {
+		return nil, nil
+	}
+	return idx.query(to-1, from+1)
+}
+
+// query returns the intervals starting at or before maxStart and ending at or after minEnd. Records are scanned
+// backwards from maxStart while the largest end of the preceding records is at least minEnd.
+func (idx *Index) query(maxStart, minEnd int64) ([]Interval, error) {
+	i := sort.Search(idx.n, func(i int) bool {
+		return indexUnflip(idx.record(i)[:8]) > maxStart
+	})
+	var matched []int
+	for i--; i >= 0; i-- {
+		r := idx.record(i)
+		if indexUnflip(r[16:]) < minEnd {
+			break
+		}
+		if indexUnflip(r[8:16]) >= minEnd {
+			matched = append(matched, i)
+		}
+	}
+	result := make([]Interval, len(matched))
+	for k, i := range matched {
+		in, err := idx.At(i)
+		if err != nil {
+			return nil, err
+		}
+		result[len(matched)-1-k] = *in
+	}
+	return result, nil
+}
+
+func (idx *Index) record(i int) []byte {
+	offset := indexHeaderSize + i*indexRecordSize
+	return idx.data[offset : offset+indexRecordSize]
+}
+
+// indexUnflip decodes a time of a record encoded like the bounds of a packed interval.
+func indexUnflip(b []byte) int64 {
+	return int64(binary.BigEndian.Uint64(b) ^ (1 << 63))
+}
@@ -0,0 +1,121 @@
+package timeinterval
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex(t *testing.T) {
+	startsAt := time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC)
+	rnd := rand.New(rand.NewSource(1))
+	var ins []Interval
+	for i := 0; i < 500; i++ {
+		from := startsAt.Add(time.Duration(rnd.Intn(10000)) * time.Minute)
+		to := from.Add(time.Duration(rnd.Intn(600)) * time.Minute)
+		in, err := NewInterval(&from, &to, nil)
+		assert.Nil(t, err)
+		ins = append(ins, *in)
+	}
+	ins = append(ins, *NewOpenEndInterval(startsAt.Add(5000 * time.Minute)), *NewOpenStartInterval(startsAt.Add(time.Hour)))
+
+	dir, err := ioutil.TempDir("", "index")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "intervals.idx")
+	f, err := os.Create(path)
+	assert.Nil(t, err)
+	assert.Nil(t, WriteIndex(f, ins))
+	assert.Nil(t, f.Close())
+
+	idx, err := OpenIndex(path)
+	assert.Nil(t, err)
+	defer idx.Close()
+	assert.Equal(t, len(ins), idx.Len())
+	first, err := idx.At(0)
+	assert.Nil(t, err)
+	assert.True(t, first.OpenStart())
+	_, err = idx.At(-1)
+	assert.NotNil(t, err)
+	_, err = idx.At(idx.Len())
+	assert.NotNil(t, err)
+
+	for i := 0; i < 200; i++ {
+		at := startsAt.Add(time.Duration(rnd.Intn(12000)-1000) * time.Minute)
+		result, err := idx.Stab(at)
+		assert.Nil(t, err)
+		var expected []Interval
+		for _, in := range ins {
+			if in.In(at) {
+				expected = append(expected, in)
+			}
+		}
+		assertSameIntervals(t, expected, result)
+
+		window := Interval{Format: ISOFormatTimeAndTime, StartsAt: at, EndsAt: at.Add(time.Duration(rnd.Intn(300)) * time.Minute)}
+		result, err = idx.Range(window)
+		assert.Nil(t, err)
+		expected = nil
+		for _, in := range ins {
+			if overlaps(in, window) {
+				expected = append(expected, in)
+			}
+		}
+		assertSameIntervals(t, expected, result)
+	}
+
+	result, err := idx.Stab(time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Len(t, result, 1)
+	assert.True(t, result[0].OpenEnd())
+	result, err = idx.Range(*NewOpenStartInterval(time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+}
+
+func TestIndex_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, WriteIndex(&buf, []Interval{*MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT1H")}))
+	data := buf.Bytes()
+	idx, err := newIndex(data)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, idx.Len())
+	assert.Nil(t, idx.Close())
+
+	_, err = newIndex(data[:len(data)-1])
+	assert.NotNil(t, err)
+	_, err = newIndex(append([]byte("XXXX"), data[4:]...))
+	assert.NotNil(t, err)
+	_, err = newIndex(append(append([]byte{}, data[:4]...), append([]byte{2}, data[5:]...)...))
+	assert.NotNil(t, err)
+	_, err = newIndex(nil)
+	assert.NotNil(t, err)
+	assert.NotNil(t, WriteIndex(&buf, []Interval{*MustParseIntervalISO8601("2300-01-02T21:00:00Z/PT1H")}))
+	_, err = OpenIndex(filepath.Join(os.TempDir(), "does-not-exist.idx"))
+	assert.NotNil(t, err)
+}
+
+// assertSameIntervals asserts that the intervals have the same bounds in the same order.
+func assertSameIntervals(t *testing.T, expected, actual []Interval) {
+	sorted := append([]Interval{}, expected...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].StartsAt.Equal(sorted[j].StartsAt) {
+			return sorted[i].EndsAt.Before(sorted[j].EndsAt)
+		}
+		return sorted[i].StartsAt.Before(sorted[j].StartsAt)
+	})
+	if !assert.Len(t, actual, len(sorted)) {
+		return
+	}
+	for i := range sorted {
+		assert.True(t, sorted[i].StartsAt.Equal(actual[i].StartsAt))
+		assert.True(t, sorted[i].EndsAt.Equal(actual[i].EndsAt))
+	}
+}
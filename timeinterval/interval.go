@@ -21,6 +21,36 @@ const ISOFormatTimeAndDuration isoFormat = 2
 // ISOFormatTimeAndDuration means the interval.ISO8601() output will have the format Duration/Time.
 const ISOFormatDurationAndTime isoFormat = 3
 
+// ISOFormatOpenStart means the interval has no start and the interval.ISO8601() output will have the format ../Time.
+const ISOFormatOpenStart isoFormat = 4
+
+// ISOFormatOpenEnd means the interval has no end and the interval.ISO8601() output will have the format Time/...
+const ISOFormatOpenEnd isoFormat = 5
+
+// openStart and openEnd are the StartsAt and EndsAt times of intervals without a start or end respectively.
+// They make open bounds compare like minus and plus infinity.
+var openStart = time.Time{}
+var openEnd = time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC)
+
+// ErrOpenInterval is returned for intervals with an open start or end by functions that need the times of both
+// bounds, e.g. Stats and Interval#Unix. The sentinels of open bounds are not real times: Functions combining an
+// interval with others clip its open bounds to them instead (e.g. Overlap and SanitizeForQuery), and those without
+// an error to return skip open intervals (e.g. Observe).
+var ErrOpenInterval = errors.New("interval has an open start or end")
+
+// boundsFormat returns the format of an interval computed from the bounds of others, e.g. by span or subtract:
+// Time/Time unless a bound is the sentinel of an open bound, which stays open. An interval open at both ends has no
+// ISO8601 representation and is formatted as an open start.
+func boundsFormat(startsAt, endsAt time.Time) isoFormat {
+	switch {
+	case startsAt.Equal(openStart):
+		return ISOFormatOpenStart
+	case endsAt.Equal(openEnd):
+		return ISOFormatOpenEnd
+	}
+	return ISOFormatTimeAndTime
+}
+
 // Interval describes an interval bounded by a StartsAt and EndsAt time.
 // the unexported "iso8601" is used to store the user's ISO8601 string. This makes it possible to marshal/unmarshal
 // the interval to/from the same ISO8601 representation originally provided if desired.
@@ -28,6 +58,17 @@ type Interval struct {
 	Format   isoFormat
 	StartsAt time.Time
 	EndsAt   time.Time
+	// Period holds the calendar period of intervals defined by one (e.g. P1M). See: NewPeriodInterval.
+	// It is nil for intervals defined by two times or a fixed duration. Like Meta, it is compared by pointer.
+	Period *Period
+	// Meta holds optional provenance metadata. It is kept when unmarshaling into an existing interval and merged
+	// when intervals are combined by set operations. It is held by pointer, so that Interval stays comparable and
+	// can be used as a map key. Intervals compare equal only if they share the same metadata. See: WithMeta
+	Meta *Meta
+	// Layout is the time layout used by ISO8601() and the marshalers. It defaults to time.RFC3339 which drops
+	// fractional seconds, so use time.RFC3339Nano to keep them. Times must remain parseable by ParseIntervalISO8601
+	// for the interval to round-trip. Like Meta, it is kept when unmarshaling into an existing interval.
+	Layout string
 }
 
 // NewInterval returns an Interval instance with set StartsAt, EndsAt and Format fields
@@ -63,6 +104,26 @@ func NewInterval(startsAt, endsAt *time.Time, duration *time.Duration) (*Interva
 	return &in, in.Validate()
 }
 
+// NewOpenStartInterval returns an Interval without a start that ends at the given time.
+func NewOpenStartInterval(endsAt time.Time) *Interval {
+	return &Interval{StartsAt: openStart, EndsAt: endsAt, Format: ISOFormatOpenStart}
+}
+
+// NewOpenEndInterval returns an Interval starting at the given time without an end.
+func NewOpenEndInterval(startsAt time.Time) *Interval {
+	return &Interval{StartsAt: startsAt, EndsAt: openEnd, Format: ISOFormatOpenEnd}
+}
+
+// OpenStart returns a boolean indicating if the interval has no start. See: NewOpenStartInterval.
+func (in Interval) OpenStart() bool {
+	return in.Format == ISOFormatOpenStart
+}
+
+// OpenEnd returns a boolean indicating if the interval has no end. See: NewOpenEndInterval.
+func (in Interval) OpenEnd() bool {
+	return in.Format == ISOFormatOpenEnd
+}
+
 // Validate verifies that validity of the interval and returns an error if the:
 //
 // 1) EndsAt time is before the StartsAt time
@@ -78,20 +139,55 @@ func (in Interval) Validate() error {
 }
 
 // UnmarshalJSON unmarshal Interval from an ISO8601 "interval" string.
+// The JSON object representation of IntervalObject is accepted as well.
 func (in *Interval) UnmarshalJSON(data []byte) error {
+	if isJSONObject(data) {
+		i, reps, err := unmarshalJSONObject(data)
+		if err != nil {
+			return err
+		}
+		if reps != nil {
+			return errors.New("interval cannot have repetitions")
+		}
+		in.assign(i)
+		return nil
+	}
 	var s string
 	err := json.Unmarshal(data, &s)
 	if err != nil {
 		return err
 	}
-	i, err := ParseIntervalISO8601(s)
+	return in.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText unmarshal Interval from an ISO8601 "interval" string. See: encoding.TextUnmarshaler.
+func (in *Interval) UnmarshalText(text []byte) error {
+	i, err := ParseIntervalISO8601(string(text))
 	if err != nil {
 		return err
 	}
-	*in = *i
+	in.assign(*i)
 	return nil
 }
 
+// assign sets the interval to i while keeping its Meta and Layout, which are not part of the encodings.
+func (in *Interval) assign(i Interval) {
+	i.Meta = in.Meta
+	i.Layout = in.Layout
+	*in = i
+}
+
+// MarshalText marshals Interval into an ISO8601 "interval" string. See: encoding.TextMarshaler.
+// It allows intervals as keys of JSON objects, e.g. map[Interval]string. Decoded keys equal the original intervals
+// unless those have a calendar Period or Meta, which are held by pointer.
+func (in Interval) MarshalText() ([]byte, error) {
+	s, err := in.ISO8601()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
 // String returns a string that describes of the interval.
 func (in Interval) String() string {
 	return fmt.Sprintf("%v -> %v", in.StartsAt, in.EndsAt)
@@ -107,6 +203,7 @@ func (in Interval) MarshalJSON() ([]byte, error) {
 }
 
 // Duration returns the duration of the interval.
+// Intervals with an open start or end return the maximum time.Duration.
 func (in Interval) Duration() time.Duration {
 	return in.EndsAt.Sub(in.StartsAt)
 }
@@ -126,22 +223,126 @@ func (in Interval) In(t time.Time) bool {
 	return in.Started(t) && !in.Ended(t)
 }
 
+// Overlaps returns a boolean indicating if the interval shares a period of time with the other interval.
+// Intervals are treated as half-open [StartsAt, EndsAt), so intervals that only touch at a boundary (e.g. one ending
+// at 22:00 and the other starting at 22:00) do not overlap. A zero-length interval only overlaps intervals that
+// contain its time after their start and before their end. Open starts and ends extend indefinitely.
+func (in Interval) Overlaps(other Interval) bool {
+	return overlaps(in, other)
+}
+
+// Intersect returns the part of the interval that is covered by the other interval, e.g. to clip a schedule to a
+// billing period. It returns nil and false if the intervals do not overlap (See: Overlaps). The intersection is
+// formatted as Time/Time unless both intervals have an open start or end, which it keeps. Metadata of both intervals
+// is merged.
+func (in Interval) Intersect(other Interval) (*Interval, bool) {
+	if !overlaps(in, other) {
+		return nil, false
+	}
+	result := Interval{StartsAt: in.StartsAt, EndsAt: in.EndsAt, Format: ISOFormatTimeAndTime, Meta: mergeMeta(in.Meta, other.Meta)}
+	if other.StartsAt.After(result.StartsAt) {
+		result.StartsAt = other.StartsAt
+	}
+	if other.EndsAt.Before(result.EndsAt) {
+		result.EndsAt = other.EndsAt
+	}
+	switch {
+	case in.OpenStart() && other.OpenStart():
+		result.Format = ISOFormatOpenStart
+	case in.OpenEnd() && other.OpenEnd():
+		result.Format = ISOFormatOpenEnd
+	}
+	return &result, true
+}
+
+// Union returns the interval covering both the interval and the other interval, e.g. to coalesce adjacent windows.
+// It returns nil and false if the intervals are disjoint, i.e. neither overlap nor touch at a boundary, since their
+// union is not a single interval. The same holds if one has an open start and the other an open end. The union is
+// formatted as Time/Time unless either interval has an open start or end, which it keeps. Metadata of both
+// intervals is merged.
+func (in Interval) Union(other Interval) (*Interval, bool) {
+	if in.StartsAt.After(other.EndsAt) || other.StartsAt.After(in.EndsAt) {
+		return nil, false
+	}
+	result := span(in, other)
+	openStart, openEnd := in.OpenStart() || other.OpenStart(), in.OpenEnd() || other.OpenEnd()
+	switch {
+	case openStart && openEnd:
+		return nil, false
+	case openStart:
+		result.Format = ISOFormatOpenStart
+	case openEnd:
+		result.Format = ISOFormatOpenEnd
+	}
+	return &result, true
+}
+
 // ISO8691 returns the interval formatted as an ISO8601 interval string.
 func (in Interval) ISO8601() (string, error) {
+	return in.format(in.formatTime)
+}
+
+// format returns the interval formatted as an ISO8601 interval string with its times formatted by formatTime.
+func (in Interval) format(formatTime func(time.Time) string) (string, error) {
 	switch in.Format {
 	case ISOFormatDurationAndTime:
-		d, err := durationToISO8601(in.Duration())
+		d, err := in.durationISO8601()
 		if err != nil {
 			return "", err
 		}
-		return fmt.Sprintf("%s/%s", d, in.EndsAt.Format(time.RFC3339)), nil
+		return fmt.Sprintf("%s/%s", d, formatTime(in.EndsAt)), nil
 	case ISOFormatTimeAndDuration:
-		d, err := durationToISO8601(in.Duration())
+		d, err := in.durationISO8601()
 		if err != nil {
 			return "", err
 		}
-		return fmt.Sprintf("%s/%s", in.StartsAt.Format(time.RFC3339), d), nil
+		return fmt.Sprintf("%s/%s", formatTime(in.StartsAt), d), nil
+	case ISOFormatOpenStart:
+		return fmt.Sprintf("../%s", formatTime(in.EndsAt)), nil
+	case ISOFormatOpenEnd:
+		return fmt.Sprintf("%s/..", formatTime(in.StartsAt)), nil
 	default:
-		return fmt.Sprintf("%s/%s", in.StartsAt.Format(time.RFC3339), in.EndsAt.Format(time.RFC3339)), nil
+		return fmt.Sprintf("%s/%s", formatTime(in.StartsAt), formatTime(in.EndsAt)), nil
+	}
+}
+
+// formatTime formats t with the Layout of the interval.
+func (in Interval) formatTime(t time.Time) string {
+	if in.Layout == "" {
+		return t.Format(time.RFC3339)
+	}
+	return t.Format(in.Layout)
+}
+
+// durationISO8601 returns the Period of the interval or otherwise its Duration as an ISO8601 duration string.
+func (in Interval) durationISO8601() (string, error) {
+	if in.Period != nil {
+		return in.Period.ISO8601()
+	}
+	return FormatDurationISO8601(in.Duration())
+}
+
+// ConciseISO8601 returns the interval formatted as an ISO8601 interval string where the end omits the leading
+// components and the time zone it shares with the start, e.g. "2007-11-13T09:00:00Z/15:30:00".
+// Intervals that are not formatted as Time/Time are returned as by ISO8601().
+func (in Interval) ConciseISO8601() (string, error) {
+	if in.Format != ISOFormatTimeAndTime {
+		return in.ISO8601()
+	}
+	start := in.StartsAt.Format(time.RFC3339)
+	end := in.EndsAt.Format(time.RFC3339)
+	_, startOffset := in.StartsAt.Zone()
+	_, endOffset := in.EndsAt.Zone()
+	if startOffset != endOffset {
+		return fmt.Sprintf("%s/%s", start, end), nil
+	}
+	startTime, _ := splitZone(start)
+	endTime, _ := splitZone(end)
+	// Omit the year, month and day (in that order) while they are shared with the start.
+	for _, cut := range []int{len("2006-01-02T"), len("2006-01-"), len("2006-")} {
+		if startTime[:cut] == endTime[:cut] {
+			return fmt.Sprintf("%s/%s", start, endTime[cut:]), nil
+		}
 	}
+	return fmt.Sprintf("%s/%s", start, endTime), nil
 }
@@ -2,6 +2,8 @@ package timeinterval
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"math"
 	"strconv"
 	"testing"
 	"time"
@@ -57,11 +59,135 @@ func TestInterval_In(t *testing.T) {
 	}
 }
 
+func TestInterval_Overlaps(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/2019-01-02T22:00:00Z")
+	expectations := map[string]bool{
+		"2019-01-02T21:30:00Z/2019-01-02T23:00:00Z": true,
+		"2019-01-02T20:00:00Z/2019-01-02T21:30:00Z": true,
+		"2019-01-02T21:15:00Z/PT15M":                true,
+		"2019-01-02T20:00:00Z/2019-01-02T23:00:00Z": true,
+		"2019-01-02T21:00:00Z/2019-01-02T22:00:00Z": true,
+		"2019-01-02T22:00:00Z/2019-01-02T23:00:00Z": false,
+		"2019-01-02T20:00:00Z/2019-01-02T21:00:00Z": false,
+		"2019-01-02T23:00:00Z/PT1H":                 false,
+		"2019-01-02T21:30:00Z/PT0S":                 true,
+		"2019-01-02T21:00:00Z/PT0S":                 false,
+		"2019-01-02T22:00:00Z/PT0S":                 false,
+		"2019-01-02T21:30:00Z/..":                   true,
+		"2019-01-02T22:00:00Z/..":                   false,
+		"../2019-01-02T21:00:01Z":                   true,
+		"../2019-01-02T21:00:00Z":                   false,
+	}
+	for given, expected := range expectations {
+		other := MustParseIntervalISO8601(given)
+		assert.Equal(t, expected, in.Overlaps(*other), given)
+		assert.Equal(t, expected, other.Overlaps(*in), given)
+	}
+}
+
+func TestInterval_Intersect(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/2019-01-02T22:00:00Z")
+	expectations := map[string]string{
+		"2019-01-02T21:30:00Z/2019-01-02T23:00:00Z": "2019-01-02T21:30:00Z/2019-01-02T22:00:00Z",
+		"2019-01-02T20:00:00Z/PT90M":                "2019-01-02T21:00:00Z/2019-01-02T21:30:00Z",
+		"2019-01-02T21:15:00Z/PT15M":                "2019-01-02T21:15:00Z/2019-01-02T21:30:00Z",
+		"2019-01-02T20:00:00Z/2019-01-02T23:00:00Z": "2019-01-02T21:00:00Z/2019-01-02T22:00:00Z",
+		"../2019-01-02T21:30:00Z":                   "2019-01-02T21:00:00Z/2019-01-02T21:30:00Z",
+		"2019-01-02T21:30:00Z/..":                   "2019-01-02T21:30:00Z/2019-01-02T22:00:00Z",
+		"2019-01-02T22:00:00Z/2019-01-02T23:00:00Z": "",
+		"2019-01-02T23:00:00Z/PT1H":                 "",
+		"../2019-01-02T21:00:00Z":                   "",
+	}
+	for given, expected := range expectations {
+		other := MustParseIntervalISO8601(given)
+		result, ok := in.Intersect(*other)
+		reversed, reversedOK := other.Intersect(*in)
+		assert.Equal(t, expected != "", ok, given)
+		assert.Equal(t, ok, reversedOK, given)
+		for _, result := range []*Interval{result, reversed} {
+			if expected == "" {
+				assert.Nil(t, result, given)
+				continue
+			}
+			if assert.NotNil(t, result, given) {
+				iso, err := result.ISO8601()
+				assert.Nil(t, err)
+				assert.Equal(t, expected, iso, given)
+			}
+		}
+	}
+
+	// Open bounds shared by both intervals are kept.
+	openStart := MustParseIntervalISO8601("../2019-01-02T22:00:00Z")
+	result, ok := openStart.Intersect(*MustParseIntervalISO8601("../2019-01-02T21:00:00Z"))
+	assert.True(t, ok)
+	assert.Equal(t, NewOpenStartInterval(time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC)), result)
+	openEnd := MustParseIntervalISO8601("2019-01-02T21:00:00Z/..")
+	result, ok = openEnd.Intersect(*MustParseIntervalISO8601("2019-01-02T22:00:00Z/.."))
+	assert.True(t, ok)
+	assert.Equal(t, NewOpenEndInterval(time.Date(2019, 1, 2, 22, 0, 0, 0, time.UTC)), result)
+
+	// Metadata of both intervals is merged.
+	result, ok = in.WithMeta("source", "a").Intersect(MustParseIntervalISO8601("2019-01-02T21:30:00Z/PT1H").WithMeta("source", "b"))
+	assert.True(t, ok)
+	assert.Equal(t, &Meta{"source": "a,b"}, result.Meta)
+}
+
+func TestInterval_Union(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/2019-01-02T22:00:00Z")
+	expectations := map[string]string{
+		"2019-01-02T21:30:00Z/2019-01-02T23:00:00Z": "2019-01-02T21:00:00Z/2019-01-02T23:00:00Z",
+		"2019-01-02T20:00:00Z/PT90M":                "2019-01-02T20:00:00Z/2019-01-02T22:00:00Z",
+		"2019-01-02T21:15:00Z/PT15M":                "2019-01-02T21:00:00Z/2019-01-02T22:00:00Z",
+		"2019-01-02T22:00:00Z/2019-01-02T23:00:00Z": "2019-01-02T21:00:00Z/2019-01-02T23:00:00Z",
+		"PT1H/2019-01-02T21:00:00Z":                 "2019-01-02T20:00:00Z/2019-01-02T22:00:00Z",
+		"2019-01-02T22:00:00Z/PT0S":                 "2019-01-02T21:00:00Z/2019-01-02T22:00:00Z",
+		"../2019-01-02T21:00:00Z":                   "../2019-01-02T22:00:00Z",
+		"2019-01-02T21:30:00Z/..":                   "2019-01-02T21:00:00Z/..",
+		"2019-01-02T22:00:01Z/2019-01-02T23:00:00Z": "",
+		"2019-01-02T23:00:00Z/PT1H":                 "",
+		"../2019-01-02T20:59:59Z":                   "",
+	}
+	for given, expected := range expectations {
+		other := MustParseIntervalISO8601(given)
+		result, ok := in.Union(*other)
+		reversed, reversedOK := other.Union(*in)
+		assert.Equal(t, expected != "", ok, given)
+		assert.Equal(t, ok, reversedOK, given)
+		for _, result := range []*Interval{result, reversed} {
+			if expected == "" {
+				assert.Nil(t, result, given)
+				continue
+			}
+			if assert.NotNil(t, result, given) {
+				iso, err := result.ISO8601()
+				assert.Nil(t, err)
+				assert.Equal(t, expected, iso, given)
+			}
+		}
+	}
+
+	// A union without start and end cannot be represented.
+	result, ok := MustParseIntervalISO8601("../2019-01-02T22:00:00Z").Union(*MustParseIntervalISO8601("2019-01-02T21:00:00Z/.."))
+	assert.False(t, ok)
+	assert.Nil(t, result)
+
+	// Metadata of both intervals is merged.
+	result, ok = in.WithMeta("source", "a").Union(MustParseIntervalISO8601("2019-01-02T22:00:00Z/PT1H").WithMeta("source", "b"))
+	assert.True(t, ok)
+	assert.Equal(t, &Meta{"source": "a,b"}, result.Meta)
+}
+
 func TestInterval_ISO8601(t *testing.T) {
 	expectations := []string{
 		"2019-01-02T21:00:00Z/2022-01-03T21:00:00Z",
 		"2019-01-02T21:00:00Z/P1W",
 		"P1W/2022-01-03T21:00:00Z",
+		"2019-01-02T21:00:00Z/PT0.5S",
+		"PT1H15M/2022-01-03T21:00:00Z",
+		"2019-01-02T21:00:00Z/PT1H30M",
+		"2019-01-02T21:00:00Z/P1DT6H",
+		"2019-01-02T21:00:00Z/PT0S",
 	}
 	for _, expectation := range expectations {
 		in, err := ParseIntervalISO8601(expectation)
@@ -110,3 +236,168 @@ func TestInterval_UnmarshalJSON(t *testing.T) {
 		assert.Equal(t, expected, &result)
 	}
 }
+
+func TestInterval_Open(t *testing.T) {
+	at, err := time.Parse(time.RFC3339, "2019-01-02T21:00:00Z")
+	assert.Nil(t, err)
+	expectations := map[string]*Interval{
+		"2019-01-02T21:00:00Z/..": NewOpenEndInterval(at),
+		"../2019-01-02T21:00:00Z": NewOpenStartInterval(at),
+	}
+	for given, expected := range expectations {
+		in, err := ParseIntervalISO8601(given)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, in)
+		assert.Nil(t, in.Validate())
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, given, iso)
+		b, err := json.Marshal(in)
+		assert.Nil(t, err)
+		assert.Equal(t, strconv.Quote(given), string(b))
+		result := Interval{}
+		assert.Nil(t, json.Unmarshal(b, &result))
+		assert.Equal(t, expected, &result)
+		assert.Equal(t, time.Duration(math.MaxInt64), in.Duration())
+	}
+
+	openEnd := NewOpenEndInterval(at)
+	assert.True(t, openEnd.OpenEnd())
+	assert.False(t, openEnd.OpenStart())
+	assert.False(t, openEnd.Started(at.Add(-time.Hour)))
+	assert.True(t, openEnd.In(at.Add(100*365*24*time.Hour)))
+	assert.False(t, openEnd.Ended(at.Add(100*365*24*time.Hour)))
+
+	openStart := NewOpenStartInterval(at)
+	assert.True(t, openStart.OpenStart())
+	assert.True(t, openStart.In(at.Add(-100*365*24*time.Hour)))
+	assert.True(t, openStart.Ended(at.Add(time.Second)))
+
+	for _, given := range []string{"../..", "../P1D", "P1D/..", "R/2019-01-02T21:00:00Z/.."} {
+		_, err := ParseIntervalISO8601(given)
+		assert.NotNil(t, err, given)
+	}
+	_, err = ParseRepeatingIntervalISO8601("R/2019-01-02T21:00:00Z/..")
+	assert.NotNil(t, err)
+}
+
+func TestInterval_ConciseISO8601(t *testing.T) {
+	expectations := map[string]string{
+		"2007-11-13T09:00:00Z/2007-11-13T15:30:00Z":           "2007-11-13T09:00:00Z/15:30:00",
+		"2007-11-13T09:00:00Z/2007-11-15T15:30:00Z":           "2007-11-13T09:00:00Z/15T15:30:00",
+		"2007-11-13T09:00:00Z/2007-12-15T15:30:00Z":           "2007-11-13T09:00:00Z/12-15T15:30:00",
+		"2007-11-13T09:00:00Z/2008-11-13T15:30:00Z":           "2007-11-13T09:00:00Z/2008-11-13T15:30:00",
+		"2007-11-13T09:00:00+01:00/2007-11-13T15:30:00+02:00": "2007-11-13T09:00:00+01:00/2007-11-13T15:30:00+02:00",
+		"2007-11-13T09:00:00Z/P1D":                            "2007-11-13T09:00:00Z/P1D",
+	}
+	for given, expected := range expectations {
+		in, err := ParseIntervalISO8601(given)
+		assert.Nil(t, err)
+		result, err := in.ConciseISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+		// The concise representation parses into the same interval.
+		parsed, err := ParseIntervalISO8601(result)
+		assert.Nil(t, err)
+		assert.True(t, in.StartsAt.Equal(parsed.StartsAt))
+		assert.True(t, in.EndsAt.Equal(parsed.EndsAt))
+	}
+}
+
+func TestParseIntervalISO8601_ConciseEnd(t *testing.T) {
+	expectations := map[string]string{
+		"2007-11-13T09:00Z/15:30":                  "2007-11-13T15:30:00Z",
+		"2007-11-13T09:00:00+01:00/15:30:00":       "2007-11-13T15:30:00+01:00",
+		"2007-11-13T09:00:00+01:00/15:30:00Z":      "2007-11-13T15:30:00Z",
+		"2007-11-13T09:00:00.250Z/15:30:00.5":      "2007-11-13T15:30:00.5Z",
+		"2007-11-13T09:00:00Z/14T15:30:00":         "2007-11-14T15:30:00Z",
+		"2007-12-14T13:30:00Z/2008-01-01T00:00:00": "2008-01-01T00:00:00Z",
+	}
+	for given, expected := range expectations {
+		in, err := ParseIntervalISO8601(given)
+		assert.Nil(t, err, given)
+		assert.Equal(t, expected, in.EndsAt.Format(time.RFC3339Nano), given)
+	}
+	for _, given := range []string{"2007-11-13T09:00:00Z/5:30:00", "2007-11-13T09:00:00Z/25:30:00", "2007-11-13T09:00:00Z/08:00:00", "2007-11-13T09:00:00Z/foo"} {
+		_, err := ParseIntervalISO8601(given)
+		assert.NotNil(t, err, given)
+	}
+}
+
+func TestInterval_MapKey(t *testing.T) {
+	shifts := map[Interval]string{
+		*MustParseIntervalISO8601("2019-01-02T08:00:00Z/PT8H"):                 "morning",
+		*MustParseIntervalISO8601("2019-01-02T16:00:00Z/2019-01-03T00:00:00Z"): "evening",
+	}
+	b, err := json.Marshal(shifts)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"2019-01-02T08:00:00Z/PT8H":"morning","2019-01-02T16:00:00Z/2019-01-03T00:00:00Z":"evening"}`, string(b))
+
+	var result map[Interval]string
+	assert.Nil(t, json.Unmarshal(b, &result))
+	assert.Equal(t, shifts, result)
+	assert.Equal(t, "morning", result[*MustParseIntervalISO8601("2019-01-02T08:00:00Z/PT8H")])
+	assert.NotNil(t, json.Unmarshal([]byte(`{"P1D":"invalid"}`), &result))
+}
+
+func TestInterval_MarshalText(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1W")
+	text, err := in.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00Z/P1W", string(text))
+
+	var result Interval
+	assert.Nil(t, result.UnmarshalText(text))
+	assert.Equal(t, *in, result)
+	assert.NotNil(t, result.UnmarshalText([]byte("P1W")))
+
+	type window struct {
+		XMLName  xml.Name `xml:"window"`
+		Interval Interval `xml:"interval,attr"`
+		Backup   Interval `xml:"backup"`
+	}
+	b, err := xml.Marshal(window{Interval: *in, Backup: *in})
+	assert.Nil(t, err)
+	assert.Equal(t, `<window interval="2019-01-02T21:00:00Z/P1W"><backup>2019-01-02T21:00:00Z/P1W</backup></window>`, string(b))
+	var w window
+	assert.Nil(t, xml.Unmarshal(b, &w))
+	assert.Equal(t, *in, w.Interval)
+	assert.Equal(t, *in, w.Backup)
+}
+
+func TestInterval_Layout(t *testing.T) {
+	given := "2019-01-02T21:00:00.25Z/2019-01-02T22:00:00.125Z"
+	in := MustParseIntervalISO8601(given)
+	iso, err := in.ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00Z/2019-01-02T22:00:00Z", iso)
+
+	in.Layout = time.RFC3339Nano
+	iso, err = in.ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, given, iso)
+	b, err := json.Marshal(in)
+	assert.Nil(t, err)
+	assert.Equal(t, strconv.Quote(given), string(b))
+
+	// The Layout is kept when unmarshaling, so fractional seconds survive round-trips.
+	result := Interval{Layout: time.RFC3339Nano}
+	assert.Nil(t, json.Unmarshal(b, &result))
+	assert.Equal(t, *in, result)
+	var plain Interval
+	assert.Nil(t, plain.UnmarshalText(b[1:len(b)-1]))
+	assert.Equal(t, "", plain.Layout)
+
+	in.Layout = "2006-01-02T15:04:05.000Z07:00"
+	iso, err = in.ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00.250Z/2019-01-02T22:00:00.125Z", iso)
+	parsed := MustParseIntervalISO8601(iso)
+	assert.True(t, in.StartsAt.Equal(parsed.StartsAt))
+
+	r := Repeating{Interval: Interval{Layout: time.RFC3339Nano}}
+	assert.Nil(t, r.UnmarshalText([]byte("R5/2019-01-02T21:00:00.5Z/PT15M")))
+	iso, err = r.ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "R5/2019-01-02T21:00:00.5Z/PT15M", iso)
+}
@@ -0,0 +1,70 @@
+package timeinterval
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var regexWeekDateISO = regexp.MustCompile("^([0-9]{4})-W(5[0-3]|[0-4][0-9])-([1-7])(T.*)$")
+
+var regexOrdinalDateISO = regexp.MustCompile("^([0-9]{4})-([0-3][0-9]{2})(T.*)$")
+
+// expandDate returns the time string s with an ISO week date or ordinal date rewritten to the calendar date.
+// Other strings are returned unchanged.
+func expandDate(s string) (string, error) {
+	// Calendar dates have a "-" after the month, which rules out week and ordinal dates without matching them.
+	if len(s) > 7 && s[7] == '-' {
+		return s, nil
+	}
+	s, err := expandWeekDate(s)
+	if err != nil {
+		return "", err
+	}
+	return expandOrdinalDate(s)
+}
+
+// expandOrdinalDate returns the time string s with an ISO ordinal date (e.g. "2019-002T21:00:00Z") rewritten to the
+// calendar date (e.g. "2019-01-02T21:00:00Z"). Other strings are returned unchanged.
+// See: ref: https://en.wikipedia.org/wiki/ISO_8601#Ordinal_dates
+func expandOrdinalDate(s string) (string, error) {
+	match := regexOrdinalDateISO.FindStringSubmatch(s)
+	if match == nil {
+		return s, nil
+	}
+	year, _ := strconv.Atoi(match[1])
+	day, _ := strconv.Atoi(match[2])
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if day < 1 || day > jan1.AddDate(1, 0, -1).YearDay() {
+		return "", errors.New("invalid day of year")
+	}
+	return jan1.AddDate(0, 0, day-1).Format("2006-01-02") + match[3], nil
+}
+
+// expandWeekDate returns the time string s with an ISO week date (e.g. "2019-W01-3T21:00:00Z") rewritten to the
+// calendar date (e.g. "2019-01-02T21:00:00Z"). Other strings are returned unchanged.
+// See: ref: https://en.wikipedia.org/wiki/ISO_week_date
+func expandWeekDate(s string) (string, error) {
+	match := regexWeekDateISO.FindStringSubmatch(s)
+	if match == nil {
+		return s, nil
+	}
+	year, _ := strconv.Atoi(match[1])
+	week, _ := strconv.Atoi(match[2])
+	weekday, _ := strconv.Atoi(match[3])
+	if week < 1 || week > weeksInYear(year) {
+		return "", errors.New("invalid week of year")
+	}
+	return weekStart(year).AddDate(0, 0, (week-1)*7+weekday-1).Format("2006-01-02") + match[4], nil
+}
+
+// weekStart returns the Monday of the first ISO week of the year, which is the week containing January 4th.
+func weekStart(year int) time.Time {
+	return ISOWeekNumbering.firstWeek(year)
+}
+
+// weeksInYear returns the number of ISO weeks in the year (52 or 53).
+func weeksInYear(year int) int {
+	return int(weekStart(year+1).Sub(weekStart(year)) / durationWeek)
+}
@@ -0,0 +1,69 @@
+package timeinterval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIntervalISO8601_WeekDate(t *testing.T) {
+	expectations := map[string]string{
+		"2019-W01-3T21:00:00Z/P1W":                      "2019-01-02T21:00:00Z/P1W",
+		"2019-W01-1T00:00:00Z/2019-W02-1T00:00:00Z":     "2018-12-31T00:00:00Z/2019-01-07T00:00:00Z",
+		"2020-W53-7T12:00:00+01:00/PT1H":                "2021-01-03T12:00:00+01:00/PT1H",
+		"2021-W01-1T08:00:00Z/P1D":                      "2021-01-04T08:00:00Z/P1D",
+		"PT1H/2019-W52-5T10:00:00Z":                     "PT1H/2019-12-27T10:00:00Z",
+		"2019-W01-3T21:00:00+01:00[Europe/Berlin]/PT1H": "2019-01-02T21:00:00+01:00/PT1H",
+	}
+	for given, expected := range expectations {
+		in, err := ParseIntervalISO8601(given)
+		assert.Nil(t, err, given)
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso, given)
+	}
+
+	invalid := []string{
+		"2019-W53-1T00:00:00Z/P1D",
+		"2019-W00-1T00:00:00Z/P1D",
+		"2019-W01-8T00:00:00Z/P1D",
+	}
+	for _, given := range invalid {
+		_, err := ParseIntervalISO8601(given)
+		assert.NotNil(t, err, given)
+	}
+}
+
+func TestWeeksInYear(t *testing.T) {
+	expectations := map[int]int{2015: 53, 2019: 52, 2020: 53, 2021: 52, 2026: 53}
+	for year, expected := range expectations {
+		assert.Equal(t, expected, weeksInYear(year), year)
+	}
+}
+
+func TestParseIntervalISO8601_OrdinalDate(t *testing.T) {
+	expectations := map[string]string{
+		"2019-002T21:00:00Z/P1D":                "2019-01-02T21:00:00Z/P1D",
+		"2019-365T00:00:00Z/2020-366T00:00Z":    "2019-12-31T00:00:00Z/2020-12-31T00:00:00Z",
+		"2020-060T12:00:00+01:00/PT1H":          "2020-02-29T12:00:00+01:00/PT1H",
+		"PT1H/2019-032T10:00:00Z":               "PT1H/2019-02-01T10:00:00Z",
+		"2019-002T21:00:00Z/2019-003T09:00:00Z": "2019-01-02T21:00:00Z/2019-01-03T09:00:00Z",
+	}
+	for given, expected := range expectations {
+		in, err := ParseIntervalISO8601(given)
+		assert.Nil(t, err, given)
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso, given)
+	}
+
+	invalid := []string{
+		"2019-366T00:00:00Z/P1D",
+		"2019-000T00:00:00Z/P1D",
+		"2019-400T00:00:00Z/P1D",
+	}
+	for _, given := range invalid {
+		_, err := ParseIntervalISO8601(given)
+		assert.NotNil(t, err, given)
+	}
+}
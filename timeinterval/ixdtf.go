@@ -0,0 +1,123 @@
+package timeinterval
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var regexIXDTFSuffix = regexp.MustCompile("^(.*?)((?:\\[[^\\[\\]]+\\])+)$")
+
+var regexIXDTFTag = regexp.MustCompile("\\[(!?)([^\\[\\]]+)\\]")
+
+var regexOffsetZone = regexp.MustCompile("^[+-](?:2[0-3]|[01][0-9]):[0-5][0-9]$")
+
+// splitIXDTF splits an RFC 9557 (IXDTF) time string such as "2019-01-02T21:00:00+01:00[Europe/Copenhagen]" into the
+// time and the location named by its time zone suffix. The location is nil if there is no time zone suffix.
+// Elective suffix tags with a key (e.g. "[u-ca=iso8601]") are ignored and critical ones (e.g. "[!u-ca=iso8601]")
+// are rejected since they are not supported.
+// See: ref: https://www.rfc-editor.org/rfc/rfc9557
+func splitIXDTF(s string) (string, *time.Location, error) {
+	if strings.IndexByte(s, '[') < 0 {
+		return s, nil, nil
+	}
+	match := regexIXDTFSuffix.FindStringSubmatch(s)
+	if match == nil {
+		return s, nil, nil
+	}
+	var loc *time.Location
+	for _, tag := range regexIXDTFTag.FindAllStringSubmatch(match[2], -1) {
+		critical, value := tag[1] == "!", tag[2]
+		if strings.Contains(value, "=") {
+			if critical {
+				return "", nil, fmt.Errorf("unsupported critical suffix tag: %v", value)
+			}
+			continue
+		}
+		if loc != nil {
+			return "", nil, errors.New("time can only have one time zone suffix")
+		}
+		if regexOffsetZone.MatchString(value) {
+			t, _ := time.Parse("-07:00", value)
+			_, offset := t.Zone()
+			loc = fixedZone(offset)
+			continue
+		}
+		l, err := loadLocation(value)
+		if err != nil {
+			return "", nil, err
+		}
+		loc = l
+	}
+	return match[1], loc, nil
+}
+
+// splitParts splits s into its "/" separated parts. Separators inside the brackets of time zone suffixes
+// (e.g. "[Europe/Copenhagen]") do not split.
+func splitParts(s string) []string {
+	var parts []string
+	depth, last := 0, 0
+	for i, c := range s {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '/':
+			if depth == 0 {
+				parts = append(parts, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(parts, s[last:])
+}
+
+// parseZonedTimeString parses an ISO8601 time string in the location of its time zone suffix (See: splitIXDTF).
+// Without a suffix it is parsed by parseTimeString. An error is returned if the offset of the time does not match
+// the offset of the suffix location at that time.
+func parseZonedTimeString(s string, zone, loc *time.Location) (time.Time, error) {
+	if zone == nil {
+		return parseTimeString(s, loc)
+	}
+	t, err := parseTimeString(s, zone)
+	if err != nil {
+		return t, err
+	}
+	_, designator := splitZone(s)
+	_, parsedOffset := t.Zone()
+	t = t.In(zone)
+	if _, offset := t.Zone(); designator != "" && designator != "Z" && offset != parsedOffset {
+		return t, fmt.Errorf("time zone offset %v does not match time zone %v", designator, zone)
+	}
+	return t, nil
+}
+
+// IXDTF returns the interval formatted as an ISO8601 interval string where times in a named location
+// (e.g. Europe/Copenhagen) have an RFC 9557 time zone suffix, e.g. "2019-01-02T21:00:00+01:00[Europe/Copenhagen]/P1D".
+func (in Interval) IXDTF() (string, error) {
+	return in.format(formatIXDTF)
+}
+
+// IXDTF returns the repeating interval formatted as an ISO8601 repeating interval string where times in a named
+// location have an RFC 9557 time zone suffix. See: Interval.IXDTF.
+func (in Repeating) IXDTF() (string, error) {
+	iso, err := in.Interval.IXDTF()
+	if err != nil {
+		return "", err
+	}
+	return in.prefix() + iso, nil
+}
+
+// formatIXDTF formats t as RFC3339 with a time zone suffix if its location is named.
+func formatIXDTF(t time.Time) string {
+	s := t.Format(time.RFC3339)
+	switch name := t.Location().String(); name {
+	case "", "UTC", "Local":
+		return s
+	default:
+		return s + "[" + name + "]"
+	}
+}
@@ -0,0 +1,89 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIntervalISO8601_IXDTF(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	assert.Nil(t, err)
+
+	in, err := ParseIntervalISO8601("2019-01-02T21:00:00+01:00[Europe/Copenhagen]/P1D")
+	assert.Nil(t, err)
+	assert.Equal(t, loc.String(), in.StartsAt.Location().String())
+	assert.Equal(t, time.Date(2019, 1, 2, 20, 0, 0, 0, time.UTC), in.StartsAt.UTC())
+	assert.Equal(t, time.Date(2019, 1, 3, 20, 0, 0, 0, time.UTC), in.EndsAt.UTC())
+
+	// Days follow the calendar of the named zone across DST transitions.
+	in, err = ParseIntervalISO8601("2019-03-30T21:00:00+01:00[Europe/Copenhagen]/P1D")
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-03-31T21:00:00+02:00", in.EndsAt.Format(time.RFC3339))
+	assert.Equal(t, 23*time.Hour, in.Duration())
+
+	in, err = ParseIntervalISO8601("2019-01-02T21:00:00Z[Europe/Copenhagen]/2019-01-03T09:00:00[Europe/Copenhagen]")
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T22:00:00+01:00", in.StartsAt.Format(time.RFC3339))
+	assert.Equal(t, "2019-01-03T09:00:00+01:00", in.EndsAt.Format(time.RFC3339))
+
+	in, err = ParseIntervalISO8601("2019-03-30T21:00:00+01:00[Europe/Copenhagen]/04-01T09:00:00")
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-04-01T09:00:00+02:00", in.EndsAt.Format(time.RFC3339))
+
+	in, err = ParseIntervalISO8601("2019-01-02T21:00:00+01:00[+01:00][u-ca=iso8601]/..")
+	assert.Nil(t, err)
+	assert.Equal(t, ISOFormatOpenEnd, in.Format)
+
+	invalid := []string{
+		"2019-01-02T21:00:00+02:00[Europe/Copenhagen]/P1D",
+		"2019-01-02T21:00:00+01:00[Europe/Nowhere]/P1D",
+		"2019-01-02T21:00:00+01:00[Europe/Copenhagen][Europe/Berlin]/P1D",
+		"2019-01-02T21:00:00+01:00[!u-ca=hebrew]/P1D",
+		"2019-01-02T21:00:00+01:00/P1D[Europe/Copenhagen]",
+	}
+	for _, given := range invalid {
+		_, err := ParseIntervalISO8601(given)
+		assert.NotNil(t, err, given)
+	}
+
+	// Lenient parsing splits parts outside the brackets only.
+	in, err = ParseIntervalISO8601WithOptions(" 2019-01-02T21:00:00+01:00[Europe/Copenhagen] / pt1h ", LenientParseOptions)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "Europe/Copenhagen", in.StartsAt.Location().String())
+		assert.Equal(t, time.Hour, in.Duration())
+	}
+	_, err = ParseIntervalISO8601WithOptions("2019-01-02T21:00:00+01:00[Europe/ Copenhagen]/pt1h", LenientParseOptions)
+	assert.NotNil(t, err)
+}
+
+func TestInterval_IXDTF(t *testing.T) {
+	expectations := []string{
+		"2019-01-02T21:00:00+01:00[Europe/Copenhagen]/P1D",
+		"2019-01-02T21:00:00+01:00[Europe/Copenhagen]/2019-07-02T21:00:00+02:00[Europe/Copenhagen]",
+		"2019-01-02T21:00:00Z/PT1H",
+		"2019-01-02T21:00:00+01:00/..",
+	}
+	for _, expected := range expectations {
+		in, err := ParseIntervalISO8601(expected)
+		assert.Nil(t, err, expected)
+		result, err := in.IXDTF()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+	}
+
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00+01:00[Europe/Copenhagen]/P1D")
+	iso, err := in.ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00+01:00/P1D", iso)
+}
+
+func TestRepeating_IXDTF(t *testing.T) {
+	r := MustParseRepeatingIntervalISO8601("R/2019-03-29T21:00:00+01:00[Europe/Copenhagen]/P1D")
+	nxt := r.Next(time.Date(2019, 3, 31, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2019-03-31T21:00:00+02:00", nxt.Format(time.RFC3339))
+	iso, err := r.IXDTF()
+	assert.Nil(t, err)
+	assert.Equal(t, "R/2019-03-29T21:00:00+01:00[Europe/Copenhagen]/P1D", iso)
+}
@@ -0,0 +1,298 @@
+package timeinterval
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jCalTimeLayout and jCalLocalTimeLayout are the jCal date-time formats with and without a UTC designator.
+// See: RFC 7265 section 3.5.
+const jCalTimeLayout = "2006-01-02T15:04:05Z"
+const jCalLocalTimeLayout = "2006-01-02T15:04:05"
+
+// JCalEvent is a VEVENT component of the JSON calendaring format jCal. See: RFC 7265.
+// The event starts and ends with the Interval, which is written with DTSTART and either DURATION (for intervals
+// formatted as Time/Duration) or DTEND. Times in named locations are written with a TZID parameter and other
+// times in UTC. Recurrence is the RRULE of the event and starts with the Interval. Other properties are ignored.
+type JCalEvent struct {
+	Interval   Interval
+	Recurrence *Recurrence
+}
+
+// MarshalJSON marshals the event into a jCal "vevent" component.
+func (e JCalEvent) MarshalJSON() ([]byte, error) {
+	in := e.Interval
+	if in.OpenStart() || in.OpenEnd() {
+		return nil, errors.New("open intervals cannot be represented in jCal")
+	}
+	properties := []interface{}{jCalTimeProperty("dtstart", in.StartsAt)}
+	if in.Format == ISOFormatTimeAndDuration {
+		d, err := in.durationISO8601()
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, []interface{}{"duration", map[string]string{}, "duration", d})
+	} else {
+		properties = append(properties, jCalTimeProperty("dtend", in.EndsAt))
+	}
+	if e.Recurrence != nil {
+		recur, err := jCalRecur(*e.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, []interface{}{"rrule", map[string]string{}, "recur", recur})
+	}
+	return json.Marshal([]interface{}{"vevent", properties, []interface{}{}})
+}
+
+// UnmarshalJSON unmarshal the event from a jCal "vevent" component.
+func (e *JCalEvent) UnmarshalJSON(data []byte) error {
+	var component []json.RawMessage
+	if err := json.Unmarshal(data, &component); err != nil {
+		return err
+	}
+	var name string
+	if len(component) != 3 || json.Unmarshal(component[0], &name) != nil || name != "vevent" {
+		return errors.New("invalid jCal vevent component")
+	}
+	var properties [][]json.RawMessage
+	if err := json.Unmarshal(component[1], &properties); err != nil {
+		return err
+	}
+	var startsAt, endsAt *time.Time
+	var duration, rrule, startTZID string
+	for _, property := range properties {
+		if len(property) < 4 {
+			return errors.New("invalid jCal property")
+		}
+		var name, valueType string
+		var params map[string]string
+		if err := json.Unmarshal(property[0], &name); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(property[1], &params); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(property[2], &valueType); err != nil {
+			return err
+		}
+		var err error
+		switch name {
+		case "dtstart", "dtend":
+			var value string
+			if err := json.Unmarshal(property[3], &value); err != nil {
+				return err
+			}
+			var t time.Time
+			if t, err = parseJCalTime(value, valueType, params["tzid"]); err != nil {
+				return err
+			}
+			if name == "dtstart" {
+				startsAt, startTZID = &t, params["tzid"]
+			} else {
+				endsAt = &t
+			}
+		case "duration":
+			err = json.Unmarshal(property[3], &duration)
+		case "rrule":
+			rrule, err = parseJCalRecur(property[3])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if startsAt == nil {
+		return errors.New("jCal vevent must have a dtstart")
+	}
+	var in *Interval
+	switch {
+	case duration != "":
+		start := startsAt.Format(time.RFC3339Nano)
+		if startTZID != "" {
+			// The zone suffix keeps the location, so that days of the duration follow its wall clock.
+			start += "[" + startTZID + "]"
+		}
+		var err error
+		if in, err = parseInterval(start+"/"+duration, StrictParseOptions); err != nil {
+			_, err = splitOffset(err)
+			return err
+		}
+	case endsAt != nil:
+		var err error
+		if in, err = NewInterval(startsAt, endsAt, nil); err != nil {
+			return err
+		}
+	default:
+		// Events without DTEND and DURATION have no duration. See: RFC 5545 section 3.6.1.
+		zero := time.Duration(0)
+		in, _ = NewInterval(startsAt, nil, &zero)
+	}
+	e.Interval = *in
+	e.Recurrence = nil
+	if rrule != "" {
+		r, err := ParseRecurrence(rrule, *startsAt)
+		if err != nil {
+			return err
+		}
+		e.Recurrence = r
+	}
+	return nil
+}
+
+// MarshalJCal marshals the events into a jCal "vcalendar" object.
+func MarshalJCal(events []JCalEvent) ([]byte, error) {
+	properties := []interface{}{
+		[]interface{}{"version", map[string]string{}, "text", "2.0"},
+		[]interface{}{"prodid", map[string]string{}, "text", "-//corthmann//go-time-intervals//EN"},
+	}
+	return json.Marshal([]interface{}{"vcalendar", properties, events})
+}
+
+// UnmarshalJCal reads the VEVENT components of a jCal "vcalendar" object. Other components are ignored.
+func UnmarshalJCal(data []byte) ([]JCalEvent, error) {
+	var calendar []json.RawMessage
+	if err := json.Unmarshal(data, &calendar); err != nil {
+		return nil, err
+	}
+	var name string
+	if len(calendar) != 3 || json.Unmarshal(calendar[0], &name) != nil || name != "vcalendar" {
+		return nil, errors.New("invalid jCal vcalendar object")
+	}
+	var components [][]json.RawMessage
+	if err := json.Unmarshal(calendar[2], &components); err != nil {
+		return nil, err
+	}
+	var events []JCalEvent
+	for _, component := range components {
+		var name string
+		if len(component) == 0 || json.Unmarshal(component[0], &name) != nil || name != "vevent" {
+			continue
+		}
+		data, err := json.Marshal(component)
+		if err != nil {
+			return nil, err
+		}
+		var e JCalEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// jCalTimeProperty returns a date-time property with the time in its named location or otherwise in UTC.
+func jCalTimeProperty(name string, t time.Time) []interface{} {
+	if loc := t.Location().String(); t.Location() != time.UTC && loc != "" && loc != "Local" {
+		return []interface{}{name, map[string]string{"tzid": loc}, "date-time", t.Format(jCalLocalTimeLayout)}
+	}
+	return []interface{}{name, map[string]string{}, "date-time", t.UTC().Format(jCalTimeLayout)}
+}
+
+// parseJCalTime parses a date or date-time value. Floating times without a TZID are interpreted as UTC.
+func parseJCalTime(value, valueType, tzid string) (time.Time, error) {
+	loc := time.UTC
+	if tzid != "" {
+		var err error
+		if loc, err = loadLocation(tzid); err != nil {
+			return time.Time{}, err
+		}
+	}
+	switch valueType {
+	case "date":
+		return time.ParseInLocation("2006-01-02", value, loc)
+	case "date-time":
+		if strings.HasSuffix(value, "Z") {
+			return time.Parse(jCalTimeLayout, value)
+		}
+		return time.ParseInLocation(jCalLocalTimeLayout, value, loc)
+	}
+	return time.Time{}, fmt.Errorf("unsupported jCal value type: %v", valueType)
+}
+
+// jCalRecur returns the recurrence as a jCal "recur" value, which holds the parts of the RRULE in an object.
+// See: RFC 7265 section 3.6.10.
+func jCalRecur(r Recurrence) (map[string]interface{}, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	recur := map[string]interface{}{}
+	for _, part := range strings.Split(r.RRule(), ";") {
+		kv := strings.SplitN(part, "=", 2)
+		name, value := strings.ToLower(kv[0]), kv[1]
+		switch name {
+		case "until":
+			recur[name] = r.Until.UTC().Format(jCalTimeLayout)
+		case "interval", "count":
+			recur[name], _ = strconv.Atoi(value)
+		case "bymonth", "bymonthday":
+			var values []interface{}
+			for _, v := range strings.Split(value, ",") {
+				n, _ := strconv.Atoi(v)
+				values = append(values, n)
+			}
+			recur[name] = jCalValues(values)
+		case "byday":
+			var values []interface{}
+			for _, v := range strings.Split(value, ",") {
+				values = append(values, v)
+			}
+			recur[name] = jCalValues(values)
+		default:
+			recur[name] = value
+		}
+	}
+	return recur, nil
+}
+
+// jCalValues returns a single value as is and multiple values as an array.
+func jCalValues(values []interface{}) interface{} {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return values
+}
+
+// parseJCalRecur returns the jCal "recur" value as an RRULE.
+func parseJCalRecur(data json.RawMessage) (string, error) {
+	var recur map[string]interface{}
+	if err := json.Unmarshal(data, &recur); err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(recur))
+	for name := range recur {
+		names = append(names, name)
+	}
+	// FREQ must be the first part of an RRULE, the order of the others does not matter.
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] == "freq" || (names[j] != "freq" && names[i] < names[j])
+	})
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		var values []string
+		items, ok := recur[name].([]interface{})
+		if !ok {
+			items = []interface{}{recur[name]}
+		}
+		for _, item := range items {
+			switch v := item.(type) {
+			case string:
+				if name == "until" {
+					v = strings.Replace(strings.Replace(v, "-", "", -1), ":", "", -1)
+				}
+				values = append(values, v)
+			case float64:
+				values = append(values, strconv.FormatFloat(v, 'f', -1, 64))
+			default:
+				return "", fmt.Errorf("invalid jCal recur value: %v", name)
+			}
+		}
+		parts = append(parts, strings.ToUpper(name)+"="+strings.Join(values, ","))
+	}
+	return strings.Join(parts, ";"), nil
+}
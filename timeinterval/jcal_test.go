@@ -0,0 +1,91 @@
+package timeinterval
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJCalEvent(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/2019-01-02T22:30:00Z")
+	r, err := ParseRecurrence("FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20190131T000000Z", in.StartsAt)
+	assert.Nil(t, err)
+	b, err := json.Marshal(JCalEvent{Interval: *in, Recurrence: r})
+	assert.Nil(t, err)
+	assert.Equal(t, `["vevent",[["dtstart",{},"date-time","2019-01-02T21:00:00Z"],["dtend",{},"date-time","2019-01-02T22:30:00Z"],`+
+		`["rrule",{},"recur",{"byday":["MO","WE"],"freq":"WEEKLY","until":"2019-01-31T00:00:00Z"}]],[]]`, string(b))
+
+	var e JCalEvent
+	assert.Nil(t, json.Unmarshal(b, &e))
+	assert.Equal(t, *in, e.Interval)
+	assert.Equal(t, r.RRule(), e.Recurrence.RRule())
+
+	// Durations and recurrences with numbers.
+	in = MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT1H")
+	r, err = ParseRecurrence("FREQ=MONTHLY;INTERVAL=2;COUNT=3;BYMONTHDAY=1,-1", in.StartsAt)
+	assert.Nil(t, err)
+	b, err = json.Marshal(JCalEvent{Interval: *in, Recurrence: r})
+	assert.Nil(t, err)
+	assert.Equal(t, `["vevent",[["dtstart",{},"date-time","2019-01-02T21:00:00Z"],["duration",{},"duration","PT1H"],`+
+		`["rrule",{},"recur",{"bymonthday":[1,-1],"count":3,"freq":"MONTHLY","interval":2}]],[]]`, string(b))
+	assert.Nil(t, json.Unmarshal(b, &e))
+	assert.Equal(t, *in, e.Interval)
+	assert.Equal(t, r.RRule(), e.Recurrence.RRule())
+
+	_, err = json.Marshal(JCalEvent{Interval: *NewOpenEndInterval(in.StartsAt)})
+	assert.NotNil(t, err)
+	for _, given := range []string{
+		`["vtodo",[],[]]`,
+		`["vevent",[["dtend",{},"date-time","2019-01-02T21:00:00Z"]],[]]`,
+		`["vevent",[["dtstart",{},"period","2019-01-02T21:00:00Z"]],[]]`,
+		`["vevent",[["dtstart",{},"date-time","2019-01-02T21:00:00Z"],["rrule",{},"recur",{"freq":"FORTNIGHTLY"}]],[]]`,
+	} {
+		assert.NotNil(t, json.Unmarshal([]byte(given), &e), given)
+	}
+}
+
+func TestJCalEvent_TZID(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	startsAt := time.Date(2019, 3, 30, 9, 0, 0, 0, loc)
+	in, err := NewPeriodInterval(&startsAt, nil, Period{Days: 1})
+	assert.Nil(t, err)
+	b, err := json.Marshal(JCalEvent{Interval: *in})
+	assert.Nil(t, err)
+	assert.Equal(t, `["vevent",[["dtstart",{"tzid":"Europe/Copenhagen"},"date-time","2019-03-30T09:00:00"],["duration",{},"duration","P1D"]],[]]`, string(b))
+
+	var e JCalEvent
+	assert.Nil(t, json.Unmarshal(b, &e))
+	assert.Equal(t, "Europe/Copenhagen", e.Interval.StartsAt.Location().String())
+	// The day of the duration follows the wall clock across the DST transition.
+	assert.Equal(t, 23*time.Hour, e.Interval.Duration())
+
+	// All-day events have date values.
+	assert.Nil(t, json.Unmarshal([]byte(`["vevent",[["dtstart",{"tzid":"Europe/Copenhagen"},"date","2019-01-02"],["dtend",{"tzid":"Europe/Copenhagen"},"date","2019-01-03"]],[]]`), &e))
+	assert.Equal(t, "2019-01-01T23:00:00Z", e.Interval.StartsAt.UTC().Format(time.RFC3339))
+	assert.Equal(t, 24*time.Hour, e.Interval.Duration())
+}
+
+func TestMarshalJCal(t *testing.T) {
+	events := []JCalEvent{
+		{Interval: *MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT1H")},
+		{Interval: *MustParseIntervalISO8601("2019-01-03T21:00:00Z/2019-01-03T23:00:00Z")},
+	}
+	b, err := MarshalJCal(events)
+	assert.Nil(t, err)
+	result, err := UnmarshalJCal(b)
+	assert.Nil(t, err)
+	assert.Equal(t, events, result)
+
+	result, err = UnmarshalJCal([]byte(`["vcalendar",[],[["vtimezone",[],[]],["vevent",[["dtstart",{},"date-time","2019-01-02T21:00:00Z"]],[]]]]`))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, time.Duration(0), result[0].Interval.Duration())
+
+	_, err = UnmarshalJCal([]byte(`["vevent",[],[]]`))
+	assert.NotNil(t, err)
+}
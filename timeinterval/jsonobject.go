@@ -0,0 +1,151 @@
+package timeinterval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// IntervalObject is an Interval marshaled into a JSON object with explicit fields instead of an ISO8601 string, e.g.
+// {"startsAt":"2019-01-02T21:00:00Z","endsAt":"2019-01-03T21:00:00Z"}, for consumers that prefer explicit fields.
+// Open bounds are null and intervals with a calendar Period also have a "period" field holding it in ISO8601.
+// The fields are always marshaled in the order startsAt, endsAt, period. Use CanonicalJSON for byte-stable output.
+// Both IntervalObject and Interval unmarshal from either representation.
+type IntervalObject struct {
+	Interval
+}
+
+// RepeatingObject is a Repeating marshaled into a JSON object with the fields of the first repetition
+// (see: IntervalObject) and the number of "repetitions", which is omitted for unbounded repeating intervals.
+// The fields are always marshaled in the order startsAt, endsAt, period, repetitions.
+// Both RepeatingObject and Repeating unmarshal from either representation.
+type RepeatingObject struct {
+	Repeating
+}
+
+// jsonObject is the JSON object representation of intervals and repeating intervals.
+type jsonObject struct {
+	StartsAt    *time.Time `json:"startsAt"`
+	EndsAt      *time.Time `json:"endsAt"`
+	Period      string     `json:"period,omitempty"`
+	Repetitions *uint32    `json:"repetitions,omitempty"`
+}
+
+// MarshalJSON marshals the interval into a JSON object.
+func (in IntervalObject) MarshalJSON() ([]byte, error) {
+	obj, err := newJSONObject(in.Interval)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
+
+// MarshalJSON marshals the repeating interval into a JSON object.
+func (in RepeatingObject) MarshalJSON() ([]byte, error) {
+	obj, err := newJSONObject(in.Interval)
+	if err != nil {
+		return nil, err
+	}
+	obj.Repetitions = in.Repetitions
+	return json.Marshal(obj)
+}
+
+// CanonicalJSON returns the JSON object representation of the interval (see: IntervalObject) in a canonical form
+// suitable for signing and diffing: keys are sorted, there is no insignificant whitespace and times are in UTC with
+// only the fractional seconds needed. Intervals describing the same time range have identical canonical JSON, so
+// it has no "period" field: a calendar Period does not change the time range of a single interval.
+func (in Interval) CanonicalJSON() ([]byte, error) {
+	in.Period = nil
+	return canonicalJSON(in, nil)
+}
+
+// CanonicalJSON returns the JSON object representation of the repeating interval (see: RepeatingObject) in the
+// canonical form described by Interval.CanonicalJSON. Unlike for intervals, the "period" field is kept, since it
+// determines the times of the following repetitions.
+func (in Repeating) CanonicalJSON() ([]byte, error) {
+	return canonicalJSON(in.Interval, in.Repetitions)
+}
+
+func canonicalJSON(in Interval, repetitions *uint32) ([]byte, error) {
+	obj, err := newJSONObject(in)
+	if err != nil {
+		return nil, err
+	}
+	// encoding/json writes map keys in sorted order.
+	fields := map[string]interface{}{"startsAt": nil, "endsAt": nil}
+	if obj.StartsAt != nil {
+		fields["startsAt"] = obj.StartsAt.UTC().Format(time.RFC3339Nano)
+	}
+	if obj.EndsAt != nil {
+		fields["endsAt"] = obj.EndsAt.UTC().Format(time.RFC3339Nano)
+	}
+	if obj.Period != "" {
+		fields["period"] = obj.Period
+	}
+	if repetitions != nil {
+		fields["repetitions"] = *repetitions
+	}
+	return json.Marshal(fields)
+}
+
+func newJSONObject(in Interval) (jsonObject, error) {
+	obj := jsonObject{}
+	if !in.OpenStart() {
+		obj.StartsAt = &in.StartsAt
+	}
+	if !in.OpenEnd() {
+		obj.EndsAt = &in.EndsAt
+	}
+	if in.Period != nil {
+		p, err := in.Period.ISO8601()
+		if err != nil {
+			return obj, err
+		}
+		obj.Period = p
+	}
+	return obj, nil
+}
+
+// isJSONObject returns a boolean indicating if the JSON data holds an object.
+func isJSONObject(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{'
+}
+
+// unmarshalJSONObject unmarshal the JSON object representation. See: IntervalObject.
+func unmarshalJSONObject(data []byte) (Interval, *uint32, error) {
+	var obj jsonObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return Interval{}, nil, err
+	}
+	var in *Interval
+	switch {
+	case obj.StartsAt == nil && obj.EndsAt == nil:
+		return Interval{}, nil, errors.New("invalid interval")
+	case obj.StartsAt == nil:
+		in = NewOpenStartInterval(*obj.EndsAt)
+	case obj.EndsAt == nil:
+		in = NewOpenEndInterval(*obj.StartsAt)
+	case obj.Period != "":
+		p, err := ParsePeriodISO8601(obj.Period)
+		if err != nil {
+			return Interval{}, nil, err
+		}
+		if in, err = NewPeriodInterval(obj.StartsAt, nil, p); err != nil {
+			return Interval{}, nil, err
+		}
+		if !in.EndsAt.Equal(*obj.EndsAt) {
+			return Interval{}, nil, errors.New("interval end does not match its period")
+		}
+	default:
+		var err error
+		if in, err = NewInterval(obj.StartsAt, obj.EndsAt, nil); err != nil {
+			return Interval{}, nil, err
+		}
+	}
+	if obj.Repetitions != nil && (in.OpenStart() || in.OpenEnd()) {
+		return Interval{}, nil, errors.New("repeating interval cannot be open")
+	}
+	return *in, obj.Repetitions, nil
+}
@@ -0,0 +1,117 @@
+package timeinterval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalObject(t *testing.T) {
+	expectations := map[string]string{
+		"2019-01-02T21:00:00Z/2019-01-03T21:00:00Z": `{"startsAt":"2019-01-02T21:00:00Z","endsAt":"2019-01-03T21:00:00Z"}`,
+		"2019-01-31T21:00:00+01:00/P1M":             `{"startsAt":"2019-01-31T21:00:00+01:00","endsAt":"2019-02-28T21:00:00+01:00","period":"P1M"}`,
+		"2019-01-02T21:00:00.25Z/PT1H":              `{"startsAt":"2019-01-02T21:00:00.25Z","endsAt":"2019-01-02T22:00:00.25Z"}`,
+		"2019-01-02T21:00:00Z/..":                   `{"startsAt":"2019-01-02T21:00:00Z","endsAt":null}`,
+		"../2019-01-02T21:00:00Z":                   `{"startsAt":null,"endsAt":"2019-01-02T21:00:00Z"}`,
+	}
+	for given, expected := range expectations {
+		in := MustParseIntervalISO8601(given)
+		b, err := json.Marshal(IntervalObject{*in})
+		assert.Nil(t, err)
+		assert.Equal(t, expected, string(b))
+
+		var obj IntervalObject
+		assert.Nil(t, json.Unmarshal(b, &obj), given)
+		assert.True(t, in.StartsAt.Equal(obj.StartsAt), given)
+		assert.True(t, in.EndsAt.Equal(obj.EndsAt), given)
+		assert.Equal(t, in.Period, obj.Period, given)
+
+		// Interval accepts both representations.
+		var result Interval
+		assert.Nil(t, json.Unmarshal(b, &result), given)
+		assert.True(t, in.EndsAt.Equal(result.EndsAt), given)
+		assert.Nil(t, json.Unmarshal([]byte(`"`+given+`"`), &obj), given)
+		assert.True(t, in.EndsAt.Equal(obj.EndsAt), given)
+	}
+
+	var in Interval
+	for _, given := range []string{
+		`{}`,
+		`{"startsAt":"2019-01-03T21:00:00Z","endsAt":"2019-01-02T21:00:00Z"}`,
+		`{"startsAt":"2019-01-02T21:00:00Z","endsAt":"2019-01-02T22:00:00Z","period":"P1D"}`,
+		`{"startsAt":"2019-01-02T21:00:00Z","endsAt":"2019-01-02T22:00:00Z","period":"1D"}`,
+		`{"startsAt":"2019-01-02T21:00:00Z","endsAt":"2019-01-02T22:00:00Z","repetitions":5}`,
+		`{"startsAt":1}`,
+	} {
+		assert.NotNil(t, json.Unmarshal([]byte(given), &in), given)
+	}
+}
+
+func TestRepeatingObject(t *testing.T) {
+	expectations := map[string]string{
+		"R5/2019-01-02T21:00:00Z/PT15M": `{"startsAt":"2019-01-02T21:00:00Z","endsAt":"2019-01-02T21:15:00Z","repetitions":5}`,
+		"R/2019-01-31T21:00:00Z/P1M":    `{"startsAt":"2019-01-31T21:00:00Z","endsAt":"2019-02-28T21:00:00Z","period":"P1M"}`,
+	}
+	for given, expected := range expectations {
+		r := MustParseRepeatingIntervalISO8601(given)
+		b, err := json.Marshal(RepeatingObject{*r})
+		assert.Nil(t, err)
+		assert.Equal(t, expected, string(b))
+
+		result := Repeating{Reference: OccurrenceEnd}
+		assert.Nil(t, json.Unmarshal(b, &result), given)
+		assert.Equal(t, OccurrenceEnd, result.Reference)
+		assert.Equal(t, r.Interval.StartsAt, result.Interval.StartsAt)
+		assert.Equal(t, r.RepeatEvery(), result.RepeatEvery())
+		assert.Equal(t, r.Interval.Period, result.Interval.Period)
+		assert.Equal(t, r.Repetitions, result.Repetitions)
+	}
+
+	var r RepeatingObject
+	assert.Nil(t, json.Unmarshal([]byte(`"R5/2019-01-02T21:00:00Z/PT15M"`), &r))
+	assert.Equal(t, uint32(5), *r.Repetitions)
+	assert.NotNil(t, json.Unmarshal([]byte(`{"startsAt":"2019-01-02T21:00:00Z","endsAt":null,"repetitions":5}`), &r))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"startsAt":"2019-01-02T21:00:00Z"}`), &r))
+	err := json.Unmarshal([]byte(`{"startsAt":"2019-01-02T21:00:00Z","endsAt":"2019-01-02T21:00:00Z","repetitions":5}`), &r)
+	assert.Equal(t, ErrZeroLengthRepeating, err)
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	expectations := map[string]string{
+		"2019-01-02T22:00:00+01:00/PT1H":            `{"endsAt":"2019-01-02T22:00:00Z","startsAt":"2019-01-02T21:00:00Z"}`,
+		"2019-01-02T21:00:00.500Z/P1M":              `{"endsAt":"2019-02-02T21:00:00.5Z","startsAt":"2019-01-02T21:00:00.5Z"}`,
+		"../2019-01-02T21:00:00Z":                   `{"endsAt":"2019-01-02T21:00:00Z","startsAt":null}`,
+		"2019-01-02T21:00:00Z/2019-01-03T21:00:00Z": `{"endsAt":"2019-01-03T21:00:00Z","startsAt":"2019-01-02T21:00:00Z"}`,
+	}
+	for given, expected := range expectations {
+		b, err := MustParseIntervalISO8601(given).CanonicalJSON()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, string(b), given)
+	}
+
+	// Equal time ranges have identical canonical JSON regardless of their format and zone.
+	a, err := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT24H").CanonicalJSON()
+	assert.Nil(t, err)
+	b, err := MustParseIntervalISO8601("2019-01-02T22:00:00+01:00/2019-01-03T22:00:00+01:00").CanonicalJSON()
+	assert.Nil(t, err)
+	assert.Equal(t, string(a), string(b))
+	a, err = MustParseIntervalISO8601("2019-01-31T00:00:00Z/P1M").CanonicalJSON()
+	assert.Nil(t, err)
+	b, err = MustParseIntervalISO8601("2019-01-31T00:00:00Z/2019-02-28T00:00:00Z").CanonicalJSON()
+	assert.Nil(t, err)
+	assert.Equal(t, string(a), string(b))
+
+	// The period of repeating intervals determines their repetitions and is kept.
+	b, err = MustParseRepeatingIntervalISO8601("R2/2019-01-31T00:00:00Z/P1M").CanonicalJSON()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"endsAt":"2019-02-28T00:00:00Z","period":"P1M","repetitions":2,"startsAt":"2019-01-31T00:00:00Z"}`, string(b))
+
+	r := MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT15M")
+	b, err = r.CanonicalJSON()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"endsAt":"2019-01-02T21:15:00Z","repetitions":5,"startsAt":"2019-01-02T21:00:00Z"}`, string(b))
+	var result Repeating
+	assert.Nil(t, json.Unmarshal(b, &result))
+	assert.Equal(t, *r.Repetitions, *result.Repetitions)
+}
@@ -0,0 +1,124 @@
+package timeinterval
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var regexCalendarNames = regexp.MustCompile("\\b(?:January|February|March|April|May|June|July|August|September|October|November|December|Jan|Feb|Mar|Apr|Jun|Jul|Aug|Sep|Oct|Nov|Dec|Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday|Mon|Tue|Wed|Thu|Fri|Sat|Sun)\\b")
+
+// Translator translates the English month and weekday names (e.g. "January", "Jan", "Monday" and "Mon") written by
+// time.Format into another language.
+type Translator interface {
+	Translate(name string) string
+}
+
+// MapTranslator is a Translator looking up translations in a map. Names without a translation are kept.
+type MapTranslator map[string]string
+
+// Translate returns the translation of the given name or the name itself if it has no translation.
+func (m MapTranslator) Translate(name string) string {
+	if translation, ok := m[name]; ok {
+		return translation
+	}
+	return name
+}
+
+// ISO8601InLocation returns the interval formatted as an ISO8601 interval string with its times in the given location
+// (UTC if nil).
+func (in Interval) ISO8601InLocation(loc *time.Location) (string, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if !in.OpenStart() {
+		in.StartsAt = in.StartsAt.In(loc)
+	}
+	if !in.OpenEnd() {
+		in.EndsAt = in.EndsAt.In(loc)
+	}
+	return in.ISO8601()
+}
+
+// FormatRange returns the interval formatted for display, e.g. "Jan 2, 21:00 – Jan 3, 02:00 CET" for the layout
+// "Jan 2, 15:04". Both times are formatted with the layout in the given location (UTC if nil), followed by the time
+// zone abbreviation, which is written once when both times share it. Month and weekday names are translated by lang
+// unless it is nil. Open bounds are written as "..".
+func (in Interval) FormatRange(layout string, loc *time.Location, lang Translator) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	start, startZone := "..", ""
+	if !in.OpenStart() {
+		start, startZone = formatLocalized(in.StartsAt.In(loc), layout, lang)
+	}
+	end, endZone := "..", ""
+	if !in.OpenEnd() {
+		end, endZone = formatLocalized(in.EndsAt.In(loc), layout, lang)
+	}
+	if startZone == endZone || startZone == "" || endZone == "" {
+		zone := startZone
+		if zone == "" {
+			zone = endZone
+		}
+		return fmt.Sprintf("%s – %s %s", start, end, zone)
+	}
+	return fmt.Sprintf("%s %s – %s %s", start, startZone, end, endZone)
+}
+
+// formatLocalized formats t with the layout, translating month and weekday names, and returns it with the
+// abbreviation of its time zone.
+func formatLocalized(t time.Time, layout string, lang Translator) (string, string) {
+	s := t.Format(layout)
+	if lang != nil {
+		s = regexCalendarNames.ReplaceAllStringFunc(s, lang.Translate)
+	}
+	return s, t.Format("MST")
+}
+
+// SmartString returns a compact description of the interval for display that omits parts shared by its start and end,
+// e.g. "2021-05-01 09:00–17:00" or "May 1–3, 2021" for intervals of whole days. The times are written in the location
+// of the start (of the end if the start is open). Seconds are only written when they are used.
+func (in Interval) SmartString() string {
+	start := in.StartsAt
+	end := in.EndsAt.In(start.Location())
+	clock := "15:04"
+	if (!in.OpenStart() && hasSeconds(start)) || (!in.OpenEnd() && hasSeconds(end)) {
+		clock = "15:04:05"
+	}
+	switch {
+	case in.OpenStart():
+		return ".. – " + in.EndsAt.Format("2006-01-02 "+clock)
+	case in.OpenEnd():
+		return start.Format("2006-01-02 "+clock) + " – .."
+	case isMidnight(start) && isMidnight(end) && end.After(start):
+		// Whole days are written with the inclusive last day.
+		last := end.AddDate(0, 0, -1)
+		switch {
+		case sameDay(start, last):
+			return start.Format("Jan 2, 2006")
+		case start.Year() == last.Year() && start.Month() == last.Month():
+			return fmt.Sprintf("%s–%d, %d", start.Format("Jan 2"), last.Day(), last.Year())
+		case start.Year() == last.Year():
+			return fmt.Sprintf("%s – %s", start.Format("Jan 2"), last.Format("Jan 2, 2006"))
+		}
+		return fmt.Sprintf("%s – %s", start.Format("Jan 2, 2006"), last.Format("Jan 2, 2006"))
+	case sameDay(start, end):
+		return fmt.Sprintf("%s–%s", start.Format("2006-01-02 "+clock), end.Format(clock))
+	}
+	return fmt.Sprintf("%s – %s", start.Format("2006-01-02 "+clock), end.Format("2006-01-02 "+clock))
+}
+
+func hasSeconds(t time.Time) bool {
+	return t.Second() != 0 || t.Nanosecond() != 0
+}
+
+func isMidnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,62 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval_ISO8601InLocation(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T20:00:00Z/2019-01-03T01:00:00Z")
+	iso, err := in.ISO8601InLocation(time.FixedZone("CET", 3600))
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00+01:00/2019-01-03T02:00:00+01:00", iso)
+
+	open := NewOpenEndInterval(in.StartsAt)
+	iso, err = open.ISO8601InLocation(time.FixedZone("CET", 3600))
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00+01:00/..", iso)
+
+	iso, err = in.ISO8601InLocation(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T20:00:00Z/2019-01-03T01:00:00Z", iso)
+}
+
+func TestInterval_FormatRange(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T20:00:00Z/2019-01-03T01:00:00Z")
+	cet := time.FixedZone("CET", 3600)
+	assert.Equal(t, "Jan 2, 21:00 – Jan 3, 02:00 CET", in.FormatRange("Jan 2, 15:04", cet, nil))
+	assert.Equal(t, "Jan 2, 20:00 – Jan 3, 01:00 UTC", in.FormatRange("Jan 2, 15:04", nil, nil))
+
+	german := MapTranslator{"Jan": "Jän.", "Wed": "Mi", "Thu": "Do"}
+	assert.Equal(t, "Mi 2. Jän. 21:00 – Do 3. Jän. 02:00 CET", in.FormatRange("Mon 2. Jan 15:04", cet, german))
+
+	loc, err := time.LoadLocation("Europe/Berlin")
+	assert.Nil(t, err)
+	dst := MustParseIntervalISO8601("2019-03-30T12:00:00Z/2019-03-31T12:00:00Z")
+	assert.Equal(t, "Mar 30, 13:00 CET – Mar 31, 14:00 CEST", dst.FormatRange("Jan 2, 15:04", loc, nil))
+
+	open := NewOpenStartInterval(in.EndsAt)
+	assert.Equal(t, ".. – Jan 3, 02:00 CET", open.FormatRange("Jan 2, 15:04", cet, nil))
+}
+
+func TestInterval_SmartString(t *testing.T) {
+	expectations := map[string]string{
+		"2021-05-01T09:00:00Z/2021-05-01T17:00:00Z":      "2021-05-01 09:00–17:00",
+		"2021-05-01T09:00:30Z/PT1H":                      "2021-05-01 09:00:30–10:00:30",
+		"2021-05-01T22:00:00Z/PT4H":                      "2021-05-01 22:00 – 2021-05-02 02:00",
+		"2021-05-01T00:00:00Z/P1D":                       "May 1, 2021",
+		"2021-05-01T00:00:00Z/2021-05-04T00:00:00Z":      "May 1–3, 2021",
+		"2021-05-30T00:00:00Z/2021-06-04T00:00:00Z":      "May 30 – Jun 3, 2021",
+		"2020-12-30T00:00:00Z/2021-01-03T00:00:00Z":      "Dec 30, 2020 – Jan 2, 2021",
+		"2021-05-01T09:00:00+02:00/2021-05-01T10:00:00Z": "2021-05-01 09:00–12:00",
+		"2021-05-01T09:00:00Z/..":                        "2021-05-01 09:00 – ..",
+		"../2021-05-01T09:00:00Z":                        ".. – 2021-05-01 09:00",
+		"2021-05-01T00:00:00Z/PT0S":                      "2021-05-01 00:00–00:00",
+	}
+	for given, expected := range expectations {
+		in := MustParseIntervalISO8601(given)
+		assert.Equal(t, expected, in.SmartString(), given)
+	}
+}
@@ -0,0 +1,99 @@
+package timeinterval
+
+import (
+	"sync"
+	"time"
+)
+
+// locationCache caches the locations loaded from the tz database by name. Names that failed to load are cached
+// as well, so that invalid input does not hit the file system on every parse. Since those names come from
+// untrusted input, at most maxLocationFailures of them are cached.
+var locationCache = struct {
+	sync.RWMutex
+	locations map[string]*time.Location
+	failures  map[string]error
+	hooks     []*func()
+}{locations: map[string]*time.Location{}, failures: map[string]error{}}
+
+// maxLocationFailures is the number of failed location names after which the cached failures are dropped.
+const maxLocationFailures = 256
+
+// loadLocation returns the location with the given name, loading it from the tz database on first use.
+func loadLocation(name string) (*time.Location, error) {
+	locationCache.RLock()
+	loc, ok := locationCache.locations[name]
+	err := locationCache.failures[name]
+	locationCache.RUnlock()
+	if ok || err != nil {
+		return loc, err
+	}
+	loc, err = time.LoadLocation(name)
+	locationCache.Lock()
+	if err != nil {
+		if len(locationCache.failures) >= maxLocationFailures {
+			locationCache.failures = map[string]error{}
+		}
+		locationCache.failures[name] = err
+	} else {
+		locationCache.locations[name] = loc
+	}
+	locationCache.Unlock()
+	return loc, err
+}
+
+// fixedZones interns the unnamed fixed zones of parsed UTC offsets, so that parsing does not allocate a location
+// per time.
+var fixedZones = struct {
+	sync.RWMutex
+	locations map[int]*time.Location
+}{locations: map[int]*time.Location{}}
+
+// fixedZone returns the unnamed location with the given offset in seconds east of UTC.
+func fixedZone(offset int) *time.Location {
+	fixedZones.RLock()
+	loc, ok := fixedZones.locations[offset]
+	fixedZones.RUnlock()
+	if ok {
+		return loc
+	}
+	fixedZones.Lock()
+	defer fixedZones.Unlock()
+	if loc, ok = fixedZones.locations[offset]; !ok {
+		loc = time.FixedZone("", offset)
+		fixedZones.locations[offset] = loc
+	}
+	return loc
+}
+
+// InvalidateLocationCache drops all cached locations, so that they are reloaded from the tz database on next use,
+// and runs the hooks registered with OnLocationCacheInvalidated.
+// Call it after the tz database has been updated at runtime.
+func InvalidateLocationCache() {
+	locationCache.Lock()
+	locationCache.locations = map[string]*time.Location{}
+	locationCache.failures = map[string]error{}
+	hooks := append([]*func(){}, locationCache.hooks...)
+	locationCache.Unlock()
+	for _, hook := range hooks {
+		(*hook)()
+	}
+}
+
+// OnLocationCacheInvalidated registers a hook that is run by InvalidateLocationCache, e.g. to Reload schedules.
+// It returns a function unregistering the hook, which must be called once the hook is no longer needed.
+func OnLocationCacheInvalidated(hook func()) (unregister func()) {
+	registered := &hook
+	locationCache.Lock()
+	defer locationCache.Unlock()
+	locationCache.hooks = append(locationCache.hooks, registered)
+	return func() {
+		locationCache.Lock()
+		defer locationCache.Unlock()
+		for i, h := range locationCache.hooks {
+			if h == registered {
+				locationCache.hooks = append(locationCache.hooks[:i:i], locationCache.hooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
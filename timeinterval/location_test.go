@@ -0,0 +1,132 @@
+package timeinterval
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidateLocationCache(t *testing.T) {
+	loc, err := loadLocation("Europe/Copenhagen")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	cached, err := loadLocation("Europe/Copenhagen")
+	assert.Nil(t, err)
+	assert.True(t, loc == cached)
+
+	invalidated := 0
+	unregister := OnLocationCacheInvalidated(func() { invalidated++ })
+	other := OnLocationCacheInvalidated(func() {})
+	InvalidateLocationCache()
+	assert.Equal(t, 1, invalidated)
+	unregister()
+	unregister()
+	other()
+	InvalidateLocationCache()
+	assert.Equal(t, 1, invalidated)
+	assert.Empty(t, locationCache.hooks)
+	reloaded, err := loadLocation("Europe/Copenhagen")
+	assert.Nil(t, err)
+	assert.False(t, loc == reloaded)
+
+	_, err = loadLocation("Nowhere/Unknown")
+	assert.NotNil(t, err)
+}
+
+func TestWallClockSchedule_Rebase(t *testing.T) {
+	s, err := At([]string{"09:00"}, time.UTC, DSTShift)
+	assert.Nil(t, err)
+	tm, err := time.Parse(time.RFC3339, "2019-01-02T00:00:00Z")
+	assert.Nil(t, err)
+
+	rebased := s.Rebase(time.FixedZone("Custom+1", 3600))
+	assert.Equal(t, "2019-01-02T09:00:00Z", s.Next(tm).Format(time.RFC3339))
+	assert.Equal(t, "2019-01-02T09:00:00+01:00", rebased.Next(tm).Format(time.RFC3339))
+
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	reloaded := s.Rebase(loc).Reload()
+	assert.Equal(t, "Europe/Copenhagen", reloaded.Location().String())
+	assert.Equal(t, "2019-01-02T09:00:00+01:00", reloaded.Next(tm).Format(time.RFC3339))
+
+	// IANA names without an area are reloaded as well.
+	summer := time.Date(2019, 7, 2, 0, 0, 0, 0, time.UTC)
+	reloaded = s.Rebase(time.FixedZone("CET", 3600)).Reload()
+	assert.Equal(t, "2019-07-02T09:00:00+02:00", reloaded.Next(summer).Format(time.RFC3339))
+	reloaded = s.Rebase(time.FixedZone("EST5EDT", -5*3600)).Reload()
+	assert.Equal(t, "2019-07-02T09:00:00-04:00", reloaded.Next(summer).Format(time.RFC3339))
+
+	// Locations that cannot be loaded by name are kept.
+	for _, loc := range []*time.Location{rebased.Location(), time.FixedZone("Nowhere/Unknown", 3600), time.FixedZone("", 3600), time.UTC, time.Local} {
+		reloaded = s.Rebase(loc).Reload()
+		assert.True(t, reloaded.Location() == loc, loc.String())
+	}
+}
+
+func TestLoadLocation_Failures(t *testing.T) {
+	_, err := loadLocation("Nowhere/Unknown")
+	assert.NotNil(t, err)
+	cached, err2 := loadLocation("Nowhere/Unknown")
+	assert.Nil(t, cached)
+	assert.True(t, err == err2)
+
+	InvalidateLocationCache()
+	_, err2 = loadLocation("Nowhere/Unknown")
+	assert.NotNil(t, err2)
+	assert.False(t, err == err2)
+
+	// The cached failures are bounded.
+	for i := 0; i < 2*maxLocationFailures; i++ {
+		_, err = loadLocation(fmt.Sprintf("Nowhere/Unknown%d", i))
+		assert.NotNil(t, err)
+	}
+	locationCache.RLock()
+	assert.True(t, len(locationCache.failures) <= maxLocationFailures)
+	locationCache.RUnlock()
+}
+
+func TestFixedZone(t *testing.T) {
+	loc := fixedZone(3600)
+	assert.True(t, loc == fixedZone(3600))
+	assert.False(t, loc == fixedZone(-3600))
+
+	a, err := parseTimeString("2019-01-02T21:00:00+01:00", nil)
+	assert.Nil(t, err)
+	b, err := parseTimeString("2019-01-03T21:00+01:00", nil)
+	assert.Nil(t, err)
+	assert.True(t, a.Location() == loc)
+	assert.True(t, b.Location() == loc)
+}
+
+func BenchmarkParseTimeString(b *testing.B) {
+	for name, s := range map[string]string{
+		"UTC":     "2019-01-02T21:00:00Z",
+		"Offset":  "2019-01-02T21:00:00+01:00",
+		"Minutes": "2019-01-02T21:00+01:00",
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				parseTimeString(s, nil)
+			}
+		})
+	}
+}
+
+func BenchmarkLoadLocation(b *testing.B) {
+	b.Run("Cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			loadLocation("Europe/Copenhagen")
+		}
+	})
+	b.Run("Uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			time.LoadLocation("Europe/Copenhagen")
+		}
+	})
+}
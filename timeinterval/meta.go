@@ -0,0 +1,76 @@
+package timeinterval
+
+import (
+	"sort"
+	"strings"
+)
+
+// MetaSource is the Meta key for the origin of an interval, e.g. the calendar or file it was read from.
+const MetaSource = "source"
+
+// MetaNote is the Meta key for a free-form note about an interval.
+const MetaNote = "note"
+
+// MetaID is the Meta key for an identifier of an interval in its source.
+const MetaID = "id"
+
+// Meta holds provenance metadata of an interval so that derived intervals can be traced back to their origin.
+// Metadata referenced by intervals is treated as immutable, so use WithMeta to change it.
+type Meta map[string]string
+
+// Get returns the value of the key or "" if it is not set. It is safe to call on nil metadata.
+func (m *Meta) Get(key string) string {
+	if m == nil {
+		return ""
+	}
+	return (*m)[key]
+}
+
+// WithMeta returns a copy of the interval with the given metadata set. The metadata of the receiver is not modified.
+func (in Interval) WithMeta(key, value string) Interval {
+	meta := Meta{}
+	if in.Meta != nil {
+		for k, v := range *in.Meta {
+			meta[k] = v
+		}
+	}
+	meta[key] = value
+	in.Meta = &meta
+	return in
+}
+
+// mergeMeta returns the metadata of an interval derived from two intervals with the metadata a and b.
+// Keys present in both with different values keep all distinct values, sorted and comma separated,
+// e.g. merging two windows from different sources results in "source": "calendar-a,calendar-b".
+func mergeMeta(a, b *Meta) *Meta {
+	result := Meta{}
+	for _, meta := range []*Meta{a, b} {
+		if meta == nil {
+			continue
+		}
+		for k, v := range *meta {
+			result[k] = joinMetaValues(result[k], v)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return &result
+}
+
+func joinMetaValues(a, b string) string {
+	values := map[string]bool{}
+	for _, s := range []string{a, b} {
+		for _, v := range strings.Split(s, ",") {
+			if v != "" {
+				values[v] = true
+			}
+		}
+	}
+	joined := make([]string, 0, len(values))
+	for v := range values {
+		joined = append(joined, v)
+	}
+	sort.Strings(joined)
+	return strings.Join(joined, ",")
+}
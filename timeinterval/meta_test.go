@@ -0,0 +1,60 @@
+package timeinterval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval_WithMeta(t *testing.T) {
+	in, err := ParseIntervalISO8601("2019-01-02T21:00:00Z/P1D")
+	assert.Nil(t, err)
+	a := in.WithMeta(MetaSource, "calendar-a")
+	b := a.WithMeta(MetaID, "42")
+	assert.Nil(t, in.Meta)
+	assert.Equal(t, &Meta{MetaSource: "calendar-a"}, a.Meta)
+	assert.Equal(t, &Meta{MetaSource: "calendar-a", MetaID: "42"}, b.Meta)
+	assert.Equal(t, "42", b.Meta.Get(MetaID))
+	assert.Equal(t, "", in.Meta.Get(MetaID))
+}
+
+func TestInterval_MetaUnmarshalJSON(t *testing.T) {
+	in := Interval{Meta: &Meta{MetaNote: "imported"}}
+	err := json.Unmarshal([]byte(`"2019-01-02T21:00:00Z/P1D"`), &in)
+	assert.Nil(t, err)
+	assert.Equal(t, &Meta{MetaNote: "imported"}, in.Meta)
+	assert.Equal(t, ISOFormatTimeAndDuration, in.Format)
+}
+
+func TestMergeMeta(t *testing.T) {
+	a := mustIntervals(t, "2019-01-02T08:00:00Z/2019-01-02T12:00:00Z")[0].WithMeta(MetaSource, "calendar-a")
+	b := mustIntervals(t, "2019-01-02T10:00:00Z/2019-01-02T14:00:00Z")[0].WithMeta(MetaSource, "calendar-b").WithMeta(MetaNote, "x")
+
+	merged := normalize([]Interval{a, b})
+	assert.Len(t, merged, 1)
+	assert.Equal(t, &Meta{MetaSource: "calendar-a,calendar-b", MetaNote: "x"}, merged[0].Meta)
+
+	pieces := subtract(a, b)
+	assert.Len(t, pieces, 1)
+	assert.Equal(t, a.Meta, pieces[0].Meta)
+
+	common := intersectNormalized([]Interval{a}, []Interval{b})
+	assert.Equal(t, &Meta{MetaSource: "calendar-a,calendar-b", MetaNote: "x"}, common[0].Meta)
+
+	assert.Nil(t, mergeMeta(nil, &Meta{}))
+	assert.Equal(t, &Meta{MetaSource: "a,b,c"}, mergeMeta(&Meta{MetaSource: "b,a"}, &Meta{MetaSource: "c,a"}))
+}
+
+func TestInterval_MetaComparable(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT1H")
+	annotated := in.WithMeta(MetaSource, "calendar-a")
+	// Intervals with metadata remain comparable and can be used as map keys.
+	counts := map[Interval]int{*in: 1, annotated: 2}
+	assert.Equal(t, 1, counts[*MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT1H")])
+	assert.Equal(t, 2, counts[annotated])
+	assert.True(t, annotated == annotated)
+	assert.False(t, *in == annotated)
+	schedules := map[Repeating]bool{*MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H"): true}
+	assert.Len(t, schedules, 1)
+}
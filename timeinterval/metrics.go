@@ -0,0 +1,24 @@
+package timeinterval
+
+import "time"
+
+// MetricRecorder is the minimal histogram abstraction used by Observe.
+// A thin adapter around e.g. an OpenTelemetry Float64Histogram satisfies it.
+type MetricRecorder interface {
+	Record(value float64)
+}
+
+// Observe records the duration (in seconds) of every completed interval into the given histogram.
+// Intervals that have not ended yet are skipped, as are those with an open start, which have no duration.
+func Observe(hist MetricRecorder, ins []Interval) {
+	observe(hist, ins, time.Now())
+}
+
+func observe(hist MetricRecorder, ins []Interval, t time.Time) {
+	for _, in := range ins {
+		if !in.Ended(t) || in.OpenStart() {
+			continue
+		}
+		hist.Record(in.Duration().Seconds())
+	}
+}
@@ -0,0 +1,31 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRecorder struct {
+	values []float64
+}
+
+func (r *testRecorder) Record(value float64) {
+	r.values = append(r.values, value)
+}
+
+func TestObserve(t *testing.T) {
+	now := time.Now()
+	startsAt := now.Add(-2 * time.Hour)
+	completed := now.Add(-30 * time.Minute)
+	running := now.Add(time.Hour)
+	a, err := NewInterval(&startsAt, &completed, nil)
+	assert.Nil(t, err)
+	b, err := NewInterval(&startsAt, &running, nil)
+	assert.Nil(t, err)
+
+	hist := &testRecorder{}
+	Observe(hist, []Interval{*a, *b, *NewOpenStartInterval(completed)})
+	assert.Equal(t, []float64{(90 * time.Minute).Seconds()}, hist.values)
+}
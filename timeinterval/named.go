@@ -0,0 +1,80 @@
+package timeinterval
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Today returns the current calendar day in the given location (time.Local if nil).
+func Today(loc *time.Location) Interval {
+	return calendarPeriod(time.Now(), Period{Days: 1}, time.Monday, 0, localIfNil(loc))
+}
+
+// ThisWeek returns the current calendar week starting on the given weekday in the given location
+// (time.Local if nil).
+func ThisWeek(loc *time.Location, weekStart time.Weekday) Interval {
+	return calendarPeriod(time.Now(), Period{Days: 7}, weekStart, 0, localIfNil(loc))
+}
+
+// ThisMonth returns the current calendar month in the given location (time.Local if nil).
+func ThisMonth(loc *time.Location) Interval {
+	return calendarPeriod(time.Now(), Period{Months: 1}, time.Monday, 0, localIfNil(loc))
+}
+
+// LastNDays returns the n calendar days up to now in the given location (time.Local if nil), like the "last7d"
+// preset of ResolveRelative. Values of n below 1 are treated as 1, so that the interval never ends before it starts.
+func LastNDays(n int, loc *time.Location) Interval {
+	if n < 1 {
+		n = 1
+	}
+	now := time.Now().In(localIfNil(loc))
+	return Interval{Format: ISOFormatDurationAndTime, StartsAt: now.AddDate(0, 0, -n), EndsAt: now, Period: &Period{Days: n}}
+}
+
+// ParseNamedPeriod returns the calendar period with the given name relative to now in the given location:
+// "today", "yesterday", "tomorrow" or "this", "last" or "next" followed by "day", "week" (starting Monday), "month"
+// or "year", separated by a hyphen, underscore or space (e.g. "this-week"). Other names are resolved by
+// ResolveRelative (e.g. "last-24h" or "month-to-date").
+func ParseNamedPeriod(name string, loc *time.Location) (*Interval, error) {
+	return namedPeriod(name, time.Now(), loc)
+}
+
+func namedPeriod(name string, ref time.Time, loc *time.Location) (*Interval, error) {
+	if loc == nil {
+		return nil, errors.New("location cannot be nil")
+	}
+	key := strings.NewReplacer("_", "-", " ", "-").Replace(strings.ToLower(name))
+	switch key {
+	case "today":
+		key = "this-day"
+	case "yesterday":
+		key = "last-day"
+	case "tomorrow":
+		key = "next-day"
+	}
+	parts := strings.SplitN(key, "-", 2)
+	offsets := map[string]int{"this": 0, "last": -1, "next": 1}
+	if offset, ok := offsets[parts[0]]; ok && len(parts) == 2 {
+		if unit, ok := calendarUnits[parts[1]]; ok {
+			in := calendarPeriod(ref, unit, time.Monday, offset, loc)
+			return &in, nil
+		}
+	}
+	return ResolveRelative(name, ref, loc)
+}
+
+// calendarPeriod returns the calendar day, week (starting on weekStart), month or year containing t in the location,
+// moved by the given number of units.
+func calendarPeriod(t time.Time, unit Period, weekStart time.Weekday, offset int, loc *time.Location) Interval {
+	startsAt := startOfCalendarUnit(t.In(loc), unit, weekStart, loc)
+	startsAt = unit.Shift(startsAt, offset)
+	return Interval{Format: ISOFormatTimeAndDuration, StartsAt: startsAt, EndsAt: unit.AddTo(startsAt), Period: &unit}
+}
+
+func localIfNil(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.Local
+	}
+	return loc
+}
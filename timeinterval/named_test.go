@@ -0,0 +1,82 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedPeriods(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	now := time.Now()
+
+	today := Today(loc)
+	assert.True(t, today.In(now))
+	assert.Equal(t, 0, today.StartsAt.Hour())
+	assert.Equal(t, loc, today.StartsAt.Location())
+
+	week := ThisWeek(loc, time.Sunday)
+	assert.True(t, week.In(now))
+	assert.Equal(t, time.Sunday, week.StartsAt.Weekday())
+	assert.Equal(t, time.Wednesday, ThisWeek(loc, time.Wednesday).StartsAt.Weekday())
+
+	month := ThisMonth(loc)
+	assert.True(t, month.In(now))
+	assert.Equal(t, 1, month.StartsAt.Day())
+
+	last := LastNDays(7, loc)
+	assert.True(t, last.EndsAt.Sub(now) < time.Minute)
+	iso, err := last.ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "P7D/", iso[:4])
+	for _, n := range []int{0, -3} {
+		assert.Equal(t, Period{Days: 1}, *LastNDays(n, loc).Period, n)
+	}
+
+	in, err := ParseNamedPeriod("this-week", loc)
+	assert.Nil(t, err)
+	assert.True(t, in.In(now))
+	assert.Equal(t, time.Local, Today(nil).StartsAt.Location())
+}
+
+func TestParseNamedPeriod(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	assert.Nil(t, err)
+	// Sunday
+	ref := time.Date(2019, 3, 31, 14, 30, 0, 0, loc)
+	expectations := map[string]string{
+		"today":         "2019-03-31T00:00:00+01:00/P1D",
+		"Yesterday":     "2019-03-30T00:00:00+01:00/P1D",
+		"tomorrow":      "2019-04-01T00:00:00+02:00/P1D",
+		"this-week":     "2019-03-25T00:00:00+01:00/P7D",
+		"last_week":     "2019-03-18T00:00:00+01:00/P7D",
+		"next week":     "2019-04-01T00:00:00+02:00/P7D",
+		"this-month":    "2019-03-01T00:00:00+01:00/P1M",
+		"last-month":    "2019-02-01T00:00:00+01:00/P1M",
+		"next-year":     "2020-01-01T00:00:00+01:00/P1Y",
+		"last-24h":      "P1D/2019-03-31T14:30:00+02:00",
+		"month-to-date": "2019-03-01T00:00:00+01:00/2019-03-31T14:30:00+02:00",
+	}
+	for given, expected := range expectations {
+		in, err := namedPeriod(given, ref, loc)
+		if !assert.Nil(t, err, given) {
+			continue
+		}
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso, given)
+	}
+	// The day of the DST change is 23 hours long.
+	in, err := namedPeriod("today", ref, loc)
+	assert.Nil(t, err)
+	assert.Equal(t, 23*time.Hour, in.Duration())
+
+	for _, given := range []string{"", "this", "this-decade", "previous-fortnight", "someday"} {
+		_, err := namedPeriod(given, ref, loc)
+		assert.NotNil(t, err, given)
+	}
+	_, err = ParseNamedPeriod("today", nil)
+	assert.NotNil(t, err)
+}
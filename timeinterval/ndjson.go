@@ -0,0 +1,73 @@
+package timeinterval
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// ReadIntervalsNDJSON reads newline-delimited JSON from r, where each line holds an interval as a JSON encoded
+// ISO8601 "interval" string (see: Interval.MarshalJSON), and calls fn with each interval that parsed successfully
+// without holding the intervals in memory. Blank lines are skipped. Lines that fail to decode are returned as
+// LineErrors after the input was read. Reading stops at the first error returned by r or fn or when ctx is done,
+// which is returned instead.
+func ReadIntervalsNDJSON(ctx context.Context, r io.Reader, fn func(line int, in Interval) error) error {
+	scanner := bufio.NewScanner(r)
+	var errs LineErrors
+	for line := 1; scanner.Scan(); line++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		b := bytes.TrimSpace(scanner.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			errs = append(errs, LineError{Line: line, Input: string(b), Err: err})
+			continue
+		}
+		in, err := parseInterval(s, StrictParseOptions)
+		if err != nil {
+			offset, err := splitOffset(err)
+			errs = append(errs, LineError{Line: line, Input: string(b), Err: err, Offset: offset})
+			continue
+		}
+		if err := fn(line, *in); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// WriteIntervalsNDJSON writes each interval received from ins to w as a line of newline-delimited JSON
+// (see: ReadIntervalsNDJSON) until ins is closed. Writing stops at the first error or when ctx is done, which is
+// returned. Intervals that cannot be formatted are returned as a LineError holding their 1-based position in ins.
+func WriteIntervalsNDJSON(ctx context.Context, w io.Writer, ins <-chan Interval) error {
+	bw := bufio.NewWriter(w)
+	for line := 1; ; line++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case in, ok := <-ins:
+			if !ok {
+				return bw.Flush()
+			}
+			b, err := in.MarshalJSON()
+			if err != nil {
+				return LineError{Line: line, Input: in.String(), Err: err}
+			}
+			if _, err := bw.Write(append(b, '\n')); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package timeinterval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadIntervalsNDJSON(t *testing.T) {
+	input := "\"2019-01-02T21:00:00Z/P1D\"\n\n  \"2019-01-03T21:00:00Z/PT1H\"  \n2019-01-03T21:00:00Z/PT1H\n\"invalid\"\n"
+	var lines []int
+	var intervals []Interval
+	err := ReadIntervalsNDJSON(context.Background(), strings.NewReader(input), func(line int, in Interval) error {
+		lines = append(lines, line)
+		intervals = append(intervals, in)
+		return nil
+	})
+	assert.Equal(t, []int{1, 3}, lines)
+	assert.Equal(t, *MustParseIntervalISO8601("2019-01-03T21:00:00Z/PT1H"), intervals[1])
+	errs, ok := err.(LineErrors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, 4, errs[0].Line)
+	assert.Equal(t, 5, errs[1].Line)
+	assert.Equal(t, `"invalid"`, errs[1].Input)
+
+	stop := errors.New("stop")
+	err = ReadIntervalsNDJSON(context.Background(), strings.NewReader(input), func(line int, in Interval) error {
+		return stop
+	})
+	assert.Equal(t, stop, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ReadIntervalsNDJSON(ctx, strings.NewReader(input), func(line int, in Interval) error {
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestWriteIntervalsNDJSON(t *testing.T) {
+	ins := make(chan Interval, 3)
+	ins <- *MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1D")
+	ins <- *MustParseIntervalISO8601("2019-01-03T21:00:00Z/..")
+	close(ins)
+	var buf bytes.Buffer
+	assert.Nil(t, WriteIntervalsNDJSON(context.Background(), &buf, ins))
+	assert.Equal(t, "\"2019-01-02T21:00:00Z/P1D\"\n\"2019-01-03T21:00:00Z/..\"\n", buf.String())
+
+	var result []Interval
+	assert.Nil(t, ReadIntervalsNDJSON(context.Background(), &buf, func(line int, in Interval) error {
+		result = append(result, in)
+		return nil
+	}))
+	assert.Len(t, result, 2)
+
+	ins = make(chan Interval, 2)
+	ins <- *MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1D")
+	ins <- Interval{Format: ISOFormatTimeAndDuration, Period: &Period{Days: -1}}
+	close(ins)
+	err := WriteIntervalsNDJSON(context.Background(), &buf, ins)
+	lineErr, ok := err.(LineError)
+	assert.True(t, ok)
+	assert.Equal(t, 2, lineErr.Line)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, WriteIntervalsNDJSON(ctx, &buf, make(chan Interval)))
+}
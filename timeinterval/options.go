@@ -0,0 +1,171 @@
+package timeinterval
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var regexSpaceSeparator = regexp.MustCompile("([0-9]{4}-(?:1[0-2]|0[1-9])-(?:3[01]|0[1-9]|[12][0-9])) +([0-9])")
+
+var regexEpoch = regexp.MustCompile("^-?[0-9]+$")
+
+var regexGoDuration = regexp.MustCompile("^(?:[0-9]+(?:\\.[0-9]*)?(?:ns|us|µs|ms|s|m|h))+$")
+
+type epochUnit uint8
+
+// EpochNone rejects Unix epoch timestamps as interval parts.
+const EpochNone epochUnit = 0
+
+// EpochSeconds accepts Unix epoch timestamps in seconds as interval parts, e.g. "1546462800/1641243600".
+const EpochSeconds epochUnit = 1
+
+// EpochMilliseconds accepts Unix epoch timestamps in milliseconds as interval parts, e.g. "1546462800000/PT1H".
+const EpochMilliseconds epochUnit = 2
+
+// ParseOptions controls which deviations from the ISO8601 specification are tolerated when parsing.
+// The zero value is strict and is what ParseIntervalISO8601 and ParseRepeatingIntervalISO8601 use.
+type ParseOptions struct {
+	// AllowLowercase accepts lowercase designators, e.g. "r5/2019-01-02t21:00:00z/p1w".
+	AllowLowercase bool
+	// AllowSpaceSeparator accepts a space instead of "T" between date and time, e.g. "2019-01-02 21:00:00Z".
+	AllowSpaceSeparator bool
+	// TrimWhitespace ignores whitespace around the input and around each "/" separated part.
+	TrimWhitespace bool
+	// AllowGoDuration accepts Go duration strings as durations, e.g. "2019-01-02T21:00:00Z/1h30m".
+	// See: time.ParseDuration
+	AllowGoDuration bool
+	// Epoch determines whether integer interval parts are accepted as Unix epoch timestamps and in which unit.
+	// The resulting times are in UTC.
+	Epoch epochUnit
+	// DefaultLocation is used for times without a time zone designator (e.g. "2019-01-02T21:00:00").
+	// Such times are rejected when it is nil.
+	DefaultLocation *time.Location
+	// ZeroLengthAsSingleOccurrence accepts repeating intervals with a zero-length interval, e.g.
+	// "R5/2019-01-02T21:00:00Z/PT0S", as a single occurrence at the start. They are rejected with
+	// ErrZeroLengthRepeating otherwise.
+	ZeroLengthAsSingleOccurrence bool
+}
+
+// StrictParseOptions only accepts input following the ISO8601 specification. Useful for validating configuration.
+var StrictParseOptions = ParseOptions{}
+
+// LenientParseOptions tolerates common deviations from the ISO8601 specification found in third-party data.
+// Times without a time zone designator are interpreted as UTC.
+var LenientParseOptions = ParseOptions{
+	AllowLowercase:      true,
+	AllowSpaceSeparator: true,
+	TrimWhitespace:      true,
+	DefaultLocation:     time.UTC,
+}
+
+// ParseIntervalISO8601WithOptions is like ParseIntervalISO8601 but tolerates the deviations enabled in opts.
+func ParseIntervalISO8601WithOptions(s string, opts ParseOptions) (*Interval, error) {
+	return parseIntervalISO8601(s, opts)
+}
+
+// ParseRepeatingIntervalISO8601WithOptions is like ParseRepeatingIntervalISO8601 but tolerates the deviations
+// enabled in opts.
+func ParseRepeatingIntervalISO8601WithOptions(s string, opts ParseOptions) (*Repeating, error) {
+	return parseRepeatingIntervalISO8601(s, opts)
+}
+
+// ParseIntervalISO8601InLocation is like ParseIntervalISO8601 but interprets times without a time zone designator
+// (e.g. "2019-01-02T21:00:00/P1D") in the given location.
+func ParseIntervalISO8601InLocation(s string, loc *time.Location) (*Interval, error) {
+	return parseIntervalISO8601(s, ParseOptions{DefaultLocation: loc})
+}
+
+// ParseRepeatingIntervalISO8601InLocation is like ParseRepeatingIntervalISO8601 but interprets times without a time
+// zone designator (e.g. "R5/2019-01-02T21:00:00/P1D") in the given location.
+func ParseRepeatingIntervalISO8601InLocation(s string, loc *time.Location) (*Repeating, error) {
+	return parseRepeatingIntervalISO8601(s, ParseOptions{DefaultLocation: loc})
+}
+
+// normalize rewrites the tolerated deviations of s into their ISO8601 form.
+func (o ParseOptions) normalize(s string) string {
+	if o.TrimWhitespace {
+		parts := splitParts(strings.TrimSpace(s))
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		s = strings.Join(parts, "/")
+	}
+	if o.AllowGoDuration || o.Epoch != EpochNone {
+		parts := splitParts(s)
+		for i := range parts {
+			if o.AllowGoDuration {
+				parts[i] = goDurationToISO8601(parts[i])
+			}
+			if o.Epoch != EpochNone {
+				parts[i] = epochToISO8601(parts[i], o.Epoch)
+			}
+		}
+		s = strings.Join(parts, "/")
+	}
+	if o.AllowLowercase {
+		s = upperDesignators(s)
+	}
+	if o.AllowSpaceSeparator {
+		s = regexSpaceSeparator.ReplaceAllString(s, "${1}T${2}")
+	}
+	return s
+}
+
+// upperDesignators returns s with the ISO8601 designators in upper case. Bracketed suffixes such as
+// "[Europe/Copenhagen]" are case sensitive and left unchanged.
+func upperDesignators(s string) string {
+	var b strings.Builder
+	depth := 0
+	for _, c := range s {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			c = unicode.ToUpper(c)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// goDurationToISO8601 returns s as an ISO8601 duration if it is a Go duration string and otherwise s unchanged.
+func goDurationToISO8601(s string) string {
+	if !regexGoDuration.MatchString(s) {
+		return s
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return s
+	}
+	iso, err := FormatDurationISO8601(d)
+	if err != nil {
+		return s
+	}
+	return iso
+}
+
+// epochToISO8601 returns s as an ISO8601 time in UTC if it is a Unix epoch timestamp in the given unit and otherwise
+// s unchanged.
+func epochToISO8601(s string, unit epochUnit) string {
+	if !regexEpoch.MatchString(s) {
+		return s
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return s
+	}
+	var t time.Time
+	switch unit {
+	case EpochMilliseconds:
+		t = time.Unix(n/1000, n%1000*int64(time.Millisecond))
+	default:
+		t = time.Unix(n, 0)
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
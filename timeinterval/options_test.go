@@ -0,0 +1,126 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIntervalISO8601WithOptions(t *testing.T) {
+	expected, err := ParseIntervalISO8601("2019-01-02T21:00:00Z/P1W")
+	assert.Nil(t, err)
+	inputs := []string{
+		"2019-01-02t21:00:00z/p1w",
+		"2019-01-02 21:00:00Z/P1W",
+		"  2019-01-02T21:00:00Z / P1W \n",
+		"2019-01-02T21:00:00/P1W",
+	}
+	for _, given := range inputs {
+		_, err := ParseIntervalISO8601WithOptions(given, StrictParseOptions)
+		assert.NotNil(t, err, given)
+		_, err = ParseIntervalISO8601(given)
+		assert.NotNil(t, err, given)
+		result, err := ParseIntervalISO8601WithOptions(given, LenientParseOptions)
+		assert.Nil(t, err, given)
+		assert.Equal(t, expected, result, given)
+	}
+
+	loc := time.FixedZone("CET", 3600)
+	result, err := ParseIntervalISO8601WithOptions("2019-01-02T21:00/P1D", ParseOptions{DefaultLocation: loc})
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00+01:00", result.StartsAt.Format(time.RFC3339))
+	assert.Equal(t, loc, result.StartsAt.Location())
+}
+
+func TestParseRepeatingIntervalISO8601WithOptions(t *testing.T) {
+	expected, err := ParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT15M")
+	assert.Nil(t, err)
+	given := " r5 / 2019-01-02 21:00:00 / pt15m "
+	_, err = ParseRepeatingIntervalISO8601WithOptions(given, StrictParseOptions)
+	assert.NotNil(t, err)
+	result, err := ParseRepeatingIntervalISO8601WithOptions(given, LenientParseOptions)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestParseIntervalISO8601WithOptions_GoDuration(t *testing.T) {
+	opts := ParseOptions{AllowGoDuration: true}
+	expectations := map[string]string{
+		"2019-01-02T21:00:00Z/1h30m":      "2019-01-02T21:00:00Z/PT1H30M",
+		"36h/2019-01-02T21:00:00Z":        "P1DT12H/2019-01-02T21:00:00Z",
+		"2019-01-02T21:00:00Z/1.5s":       "2019-01-02T21:00:00Z/PT1.5S",
+		"2019-01-02T21:00:00Z/PT1H":       "2019-01-02T21:00:00Z/PT1H",
+		"2019-01-02T21:00:00Z/250ms100us": "2019-01-02T21:00:00Z/PT0.2501S",
+	}
+	for given, expected := range expectations {
+		_, err := ParseIntervalISO8601(given)
+		if given != "2019-01-02T21:00:00Z/PT1H" {
+			assert.NotNil(t, err, given)
+		}
+		result, err := ParseIntervalISO8601WithOptions(given, opts)
+		assert.Nil(t, err, given)
+		iso, err := result.ISO8601()
+		assert.Nil(t, err, given)
+		assert.Equal(t, expected, iso, given)
+	}
+
+	_, err := ParseIntervalISO8601WithOptions("2019-01-02T21:00:00Z/-1h", opts)
+	assert.NotNil(t, err)
+
+	opts.AllowLowercase = true
+	r, err := ParseRepeatingIntervalISO8601WithOptions("r3/2019-01-02t21:00:00z/15m", opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 15*time.Minute, r.RepeatEvery())
+
+	// Time zone suffixes keep their case.
+	in, err := ParseIntervalISO8601WithOptions("2019-01-02t21:00:00+01:00[Europe/Copenhagen]/pt1h", opts)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "Europe/Copenhagen", in.StartsAt.Location().String())
+	}
+}
+
+func TestParseIntervalISO8601WithOptions_Epoch(t *testing.T) {
+	expected := MustParseIntervalISO8601("2019-01-02T21:00:00Z/2022-01-03T09:00:00Z")
+	_, err := ParseIntervalISO8601("1546462800/1641200400")
+	assert.NotNil(t, err)
+
+	result, err := ParseIntervalISO8601WithOptions("1546462800/1641200400", ParseOptions{Epoch: EpochSeconds})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+
+	result, err = ParseIntervalISO8601WithOptions("1546462800000/1641200400000", ParseOptions{Epoch: EpochMilliseconds})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+
+	result, err = ParseIntervalISO8601WithOptions("1546462800250/PT1H", ParseOptions{Epoch: EpochMilliseconds})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 1, 2, 21, 0, 0, 250000000, time.UTC), result.StartsAt)
+	assert.Equal(t, ISOFormatTimeAndDuration, result.Format)
+
+	r, err := ParseRepeatingIntervalISO8601WithOptions("R5/1546462800/PT15M", ParseOptions{Epoch: EpochSeconds})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(5), *r.Repetitions)
+	assert.Equal(t, expected.StartsAt, r.Interval.StartsAt)
+}
+
+func TestParseIntervalISO8601InLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	in, err := ParseIntervalISO8601InLocation("2019-01-02T21:00:00/P1D", loc)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 1, 3, 2, 0, 0, 0, time.UTC), in.StartsAt.UTC())
+	assert.Equal(t, loc, in.StartsAt.Location())
+
+	// Times with a time zone designator are not affected by the location.
+	in, err = ParseIntervalISO8601InLocation("2019-01-02T21:00:00Z/P1D", loc)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC), in.StartsAt)
+
+	_, err = ParseIntervalISO8601InLocation("2019-01-02T21:00:00/P1D", nil)
+	assert.NotNil(t, err)
+
+	r, err := ParseRepeatingIntervalISO8601InLocation("R5/2019-01-02T21:00:00/PT1H", loc)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 1, 3, 2, 0, 0, 0, time.UTC), r.StartsAt().UTC())
+}
@@ -0,0 +1,83 @@
+package timeinterval
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// OverlapViolation describes two intervals overlapping by more than the allowed slack. See: ValidateNoOverlap.
+type OverlapViolation struct {
+	// First and Second are the 0-based positions of the overlapping intervals in the input, First < Second.
+	First, Second int
+	// Overlap is the time both intervals cover.
+	Overlap Interval
+}
+
+// Error describes the positions of the intervals and the duration of the overlap.
+func (v OverlapViolation) Error() string {
+	return fmt.Sprintf("intervals %d and %d overlap by %v", v.First, v.Second, v.Overlap.Duration())
+}
+
+// OverlapViolations aggregates the violations found by ValidateNoOverlap, ordered by First and Second.
+type OverlapViolations []OverlapViolation
+
+// Error returns the number of violations and the first violation.
+func (v OverlapViolations) Error() string {
+	if len(v) == 1 {
+		return v[0].Error()
+	}
+	return fmt.Sprintf("%d overlaps exceed the allowed duration, first %v", len(v), v[0])
+}
+
+// ValidateNoOverlap returns OverlapViolations describing every pair of intervals overlapping by more than allowed,
+// e.g. to validate uploaded shift plans while tolerating short handovers. Intervals that merely touch do not overlap.
+// It returns nil if there are no violations.
+func ValidateNoOverlap(ins []Interval, allowed time.Duration) error {
+	order := make([]int, len(ins))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return ins[order[i]].StartsAt.Before(ins[order[j]].StartsAt)
+	})
+	var violations OverlapViolations
+	// active holds the positions of the intervals that have not ended at the start of the current interval.
+	var active []int
+	for _, j := range order {
+		in := ins[j]
+		kept := active[:0]
+		for _, i := range active {
+			if !in.StartsAt.Before(ins[i].EndsAt) {
+				continue
+			}
+			kept = append(kept, i)
+			end := ins[i].EndsAt
+			if in.EndsAt.Before(end) {
+				end = in.EndsAt
+			}
+			if overlap := end.Sub(in.StartsAt); overlap > allowed {
+				first, second := i, j
+				if second < first {
+					first, second = second, first
+				}
+				violations = append(violations, OverlapViolation{
+					First:   first,
+					Second:  second,
+					Overlap: Interval{StartsAt: in.StartsAt, EndsAt: end, Format: ISOFormatTimeAndTime},
+				})
+			}
+		}
+		active = append(kept, j)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].First != violations[j].First {
+			return violations[i].First < violations[j].First
+		}
+		return violations[i].Second < violations[j].Second
+	})
+	return violations
+}
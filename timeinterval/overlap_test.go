@@ -0,0 +1,43 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNoOverlap(t *testing.T) {
+	shifts := mustIntervals(t,
+		"2019-01-02T16:00:00Z/2019-01-03T00:05:00Z",
+		"2019-01-02T08:00:00Z/2019-01-02T16:10:00Z",
+		"2019-01-03T00:00:00Z/2019-01-03T08:00:00Z",
+		"2019-01-02T12:00:00Z/2019-01-02T14:00:00Z",
+	)
+	err := ValidateNoOverlap(shifts[:3], 15*time.Minute)
+	assert.Nil(t, err)
+
+	err = ValidateNoOverlap(shifts, 15*time.Minute)
+	violations, ok := err.(OverlapViolations)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, 1, len(violations))
+	assert.Equal(t, 1, violations[0].First)
+	assert.Equal(t, 3, violations[0].Second)
+	assert.Equal(t, 2*time.Hour, violations[0].Overlap.Duration())
+	assert.Equal(t, "intervals 1 and 3 overlap by 2h0m0s", err.Error())
+
+	err = ValidateNoOverlap(shifts, 0)
+	violations = err.(OverlapViolations)
+	assert.Equal(t, 3, len(violations))
+	assert.Equal(t, [2]int{0, 1}, [2]int{violations[0].First, violations[0].Second})
+	assert.Equal(t, 10*time.Minute, violations[0].Overlap.Duration())
+	assert.Equal(t, [2]int{0, 2}, [2]int{violations[1].First, violations[1].Second})
+	assert.Equal(t, [2]int{1, 3}, [2]int{violations[2].First, violations[2].Second})
+	assert.Equal(t, "3 overlaps exceed the allowed duration, first intervals 0 and 1 overlap by 10m0s", err.Error())
+
+	// Touching intervals do not overlap.
+	assert.Nil(t, ValidateNoOverlap(mustIntervals(t, "2019-01-02T08:00:00Z/PT8H", "2019-01-02T16:00:00Z/PT8H"), 0))
+	assert.Nil(t, ValidateNoOverlap(nil, 0))
+}
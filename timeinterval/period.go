@@ -0,0 +1,149 @@
+package timeinterval
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Period describes a calendar based duration such as P1M or P1Y2M3DT4H.
+// Unlike time.Duration, the length of a Period depends on the time it is applied to:
+// months and years follow the calendar and days follow the wall clock of the time's location.
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+	// Time holds the hour, minute and second components of the period.
+	Time time.Duration
+}
+
+// ParsePeriodISO8601 parses an ISO8601 duration string (e.g. P1Y2M3DT4H5M6S) into a Period.
+// Weeks are converted to days.
+func ParsePeriodISO8601(s string) (Period, error) {
+	d, err := parseISODuration(s)
+	if err != nil {
+		return Period{}, newParseError(s, err, func(c string) error {
+			_, err := parseISODuration(c)
+			return err
+		})
+	}
+	return d.period(), nil
+}
+
+// IsZero returns a boolean indicating if the period has no length.
+func (p Period) IsZero() bool {
+	return p.Years == 0 && p.Months == 0 && p.Days == 0 && p.Time == 0
+}
+
+// isPositive returns a boolean indicating if the period has a length and no negative components, so that adding it
+// always moves a time forward.
+func (p Period) isPositive() bool {
+	return !p.IsZero() && p.Years >= 0 && p.Months >= 0 && p.Days >= 0 && p.Time >= 0
+}
+
+// AddTo returns t moved forward by the period.
+// Years and months are added first. If the resulting month is shorter than the day of t, the day is clamped
+// to the last day of that month (Jan 31 + P1M -> Feb 28/29). Days and time are added afterwards.
+func (p Period) AddTo(t time.Time) time.Time {
+	return p.Shift(t, 1)
+}
+
+// Shift returns t moved by n periods, e.g. Shift(t, 2) adds the period twice and Shift(t, -1) subtracts it.
+// The period is multiplied before it is applied, so repeated shifts from the same anchor do not accumulate
+// clamping (Jan 31 shifted by 2 x P1M is Mar 31, not Mar 28). Like AddTo, years and months are applied first with
+// the day clamped, then days and time, in either direction (Mar 31 shifted by -1 x P1M1D is Feb 27).
+func (p Period) Shift(t time.Time, n int) time.Time {
+	t = addMonthsClamped(t, n*(p.Years*12+p.Months))
+	return t.AddDate(0, 0, n*p.Days).Add(time.Duration(n) * p.Time)
+}
+
+// ISO8601 returns the period formatted as an ISO8601 duration string. Like FormatDurationISO8601, a zero period is
+// formatted as PT0S.
+func (p Period) ISO8601() (string, error) {
+	if p.Years < 0 || p.Months < 0 || p.Days < 0 || p.Time < 0 {
+		return "", errors.New("negative periods cannot be represented")
+	}
+	if p.IsZero() {
+		return "PT0S", nil
+	}
+	iso := "P"
+	if p.Years != 0 {
+		iso += fmt.Sprintf("%dY", p.Years)
+	}
+	if p.Months != 0 {
+		iso += fmt.Sprintf("%dM", p.Months)
+	}
+	if p.Days != 0 {
+		iso += fmt.Sprintf("%dD", p.Days)
+	}
+	if p.Time != 0 {
+		iso += "T" + timeComponentsISO8601(p.Time)
+	}
+	return iso, nil
+}
+
+// addMonthsClamped adds the given number of months to t, clamping the day to the last day of the resulting month.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	if months == 0 {
+		return t
+	}
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	first := time.Date(year, month+time.Month(months), 1, hour, min, sec, t.Nanosecond(), t.Location())
+	if last := daysIn(first.Year(), first.Month()); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// daysIn returns the number of days in the given month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// NewPeriodInterval returns an Interval bounded by the given startsAt or endsAt and a calendar Period.
+// Exactly one of startsAt and endsAt must be set. The Period is kept on the interval so that the ISO8601
+// output and repetitions (See: Repeating) preserve the calendar semantics.
+func NewPeriodInterval(startsAt, endsAt *time.Time, p Period) (*Interval, error) {
+	if (startsAt == nil) == (endsAt == nil) {
+		return nil, errors.New("invalid interval")
+	}
+	in := Interval{Period: &p}
+	if startsAt != nil {
+		in.StartsAt = *startsAt
+		in.EndsAt = p.AddTo(*startsAt)
+		in.Format = ISOFormatTimeAndDuration
+	} else {
+		in.EndsAt = *endsAt
+		in.StartsAt = p.Shift(*endsAt, -1)
+		in.Format = ISOFormatDurationAndTime
+	}
+	return &in, in.Validate()
+}
+
+// CalendarOccurrence is an occurrence of a calendar recurrence. See: PreviewCalendarRecurrence.
+type CalendarOccurrence struct {
+	Time time.Time
+	// Clamped indicates that the day of the anchor does not exist in the month of the occurrence,
+	// so the last day of that month was used instead (e.g. Jan 31 + P1M -> Feb 28).
+	Clamped bool
+}
+
+// PreviewCalendarRecurrence returns the first n occurrences (starting with the anchor) of a recurrence stepping
+// by the given Period, as used by repeating intervals with a calendar Period. Occurrences where the day of month
+// had to be clamped are marked, so that a policy such as "monthly on the 31st" can be verified before it is used.
+// It returns nil if n is not positive.
+func PreviewCalendarRecurrence(rule Period, anchor time.Time, n int) []CalendarOccurrence {
+	if n <= 0 {
+		return nil
+	}
+	result := make([]CalendarOccurrence, 0, n)
+	months := rule.Years*12 + rule.Months
+	for k := 0; k < n; k++ {
+		result = append(result, CalendarOccurrence{
+			Time:    rule.Shift(anchor, k),
+			Clamped: addMonthsClamped(anchor, k*months).Day() != anchor.Day(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,144 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePeriodISO8601(t *testing.T) {
+	expectations := map[string]Period{
+		"P1M":            {Months: 1},
+		"P1Y2M3DT4H5M6S": {Years: 1, Months: 2, Days: 3, Time: 4*time.Hour + 5*time.Minute + 6*time.Second},
+		"P2W":            {Days: 14},
+		"PT1.5H":         {Time: 90 * time.Minute},
+	}
+	for given, expected := range expectations {
+		result, err := ParsePeriodISO8601(given)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+	}
+	_, err := ParsePeriodISO8601("P1.5M")
+	assert.NotNil(t, err)
+}
+
+func TestPeriod_AddTo(t *testing.T) {
+	expectations := []struct {
+		period   Period
+		given    string
+		expected string
+	}{
+		{Period{Months: 1}, "2019-01-31T10:00:00Z", "2019-02-28T10:00:00Z"},
+		{Period{Months: 1}, "2020-01-31T10:00:00Z", "2020-02-29T10:00:00Z"},
+		{Period{Years: 1}, "2020-02-29T10:00:00Z", "2021-02-28T10:00:00Z"},
+		{Period{Months: 13, Days: 1}, "2019-01-31T10:00:00Z", "2020-03-01T10:00:00Z"},
+		{Period{Months: 1, Time: time.Hour}, "2019-03-15T10:00:00Z", "2019-04-15T11:00:00Z"},
+	}
+	for _, e := range expectations {
+		assert.Equal(t, e.expected, e.period.AddTo(mustTime(t, e.given)).Format(time.RFC3339))
+	}
+	jan31 := mustTime(t, "2019-01-31T00:00:00Z")
+	month := Period{Months: 1}
+	assert.Equal(t, "2019-03-31T00:00:00Z", month.Shift(jan31, 2).Format(time.RFC3339))
+	assert.Equal(t, "2018-12-31T00:00:00Z", month.Shift(jan31, -1).Format(time.RFC3339))
+	assert.Equal(t, "2019-02-28T00:00:00Z", month.Shift(mustTime(t, "2019-03-31T00:00:00Z"), -1).Format(time.RFC3339))
+	// Negative shifts apply the components in the same order as positive ones.
+	mixed := Period{Months: 1, Days: 1}
+	assert.Equal(t, "2019-02-27T00:00:00Z", mixed.Shift(mustTime(t, "2019-03-31T00:00:00Z"), -1).Format(time.RFC3339))
+	assert.Equal(t, "2019-03-01T00:00:00Z", mixed.Shift(mustTime(t, "2019-01-31T00:00:00Z"), 1).Format(time.RFC3339))
+}
+
+func TestPeriod_ISO8601(t *testing.T) {
+	expectations := map[string]Period{
+		"P1M":            {Months: 1},
+		"P1Y2M3DT4H5M6S": {Years: 1, Months: 2, Days: 3, Time: 4*time.Hour + 5*time.Minute + 6*time.Second},
+		"PT36H":          {Time: 36 * time.Hour},
+		"PT0S":           {},
+	}
+	for expected, given := range expectations {
+		result, err := given.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+	}
+	_, err := Period{Months: -1}.ISO8601()
+	assert.NotNil(t, err)
+}
+
+func TestNewPeriodInterval(t *testing.T) {
+	startsAt, err := time.Parse(time.RFC3339, "2019-01-31T21:00:00Z")
+	assert.Nil(t, err)
+	in, err := NewPeriodInterval(&startsAt, nil, Period{Months: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-02-28T21:00:00Z", in.EndsAt.Format(time.RFC3339))
+	iso, err := in.ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-31T21:00:00Z/P1M", iso)
+
+	_, err = NewPeriodInterval(nil, nil, Period{Months: 1})
+	assert.NotNil(t, err)
+	_, err = NewPeriodInterval(&startsAt, &startsAt, Period{Months: 1})
+	assert.NotNil(t, err)
+
+	for _, given := range []string{"2019-01-31T21:00:00Z/P1M", "P1Y/2020-02-29T00:00:00Z", "2019-01-31T21:00:00Z/P1Y2M3DT4H"} {
+		in, err := ParseIntervalISO8601(given)
+		assert.Nil(t, err)
+		assert.NotNil(t, in.Period)
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, given, iso)
+	}
+}
+
+func TestRepeating_CalendarPeriod(t *testing.T) {
+	r, err := ParseRepeatingIntervalISO8601("R3/2019-01-31T10:00:00Z/P1M")
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-04-30T10:00:00Z", r.EndsAt().Format(time.RFC3339))
+	iso, err := r.ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "R3/2019-01-31T10:00:00Z/P1M", iso)
+
+	expectations := map[string]string{
+		"2019-01-01T00:00:00Z": "2019-01-31T10:00:00Z",
+		"2019-01-31T10:00:00Z": "2019-02-28T10:00:00Z",
+		"2019-02-28T10:00:00Z": "2019-03-31T10:00:00Z",
+		"2019-03-01T00:00:00Z": "2019-03-31T10:00:00Z",
+		"2019-04-01T00:00:00Z": "2019-04-30T10:00:00Z",
+	}
+	for given, expected := range expectations {
+		tm, err := time.Parse(time.RFC3339, given)
+		assert.Nil(t, err)
+		nxt := r.Next(tm)
+		assert.NotNil(t, nxt)
+		assert.Equal(t, expected, nxt.Format(time.RFC3339), given)
+	}
+	assert.Nil(t, r.Next(r.EndsAt().Add(time.Second)))
+
+	r.Repetitions = nil
+	tm, err := time.Parse(time.RFC3339, "2018-11-15T00:00:00Z")
+	assert.Nil(t, err)
+	assert.Equal(t, "2018-11-30T10:00:00Z", r.Next(tm).Format(time.RFC3339))
+}
+
+func TestPreviewCalendarRecurrence(t *testing.T) {
+	anchor, err := time.Parse(time.RFC3339, "2019-12-31T09:00:00Z")
+	assert.Nil(t, err)
+	result := PreviewCalendarRecurrence(Period{Months: 1}, anchor, 5)
+	expected := []struct {
+		time    string
+		clamped bool
+	}{
+		{"2019-12-31T09:00:00Z", false},
+		{"2020-01-31T09:00:00Z", false},
+		{"2020-02-29T09:00:00Z", true},
+		{"2020-03-31T09:00:00Z", false},
+		{"2020-04-30T09:00:00Z", true},
+	}
+	assert.Len(t, result, len(expected))
+	for i, e := range expected {
+		assert.Equal(t, e.time, result[i].Time.Format(time.RFC3339))
+		assert.Equal(t, e.clamped, result[i].Clamped)
+	}
+	assert.Nil(t, PreviewCalendarRecurrence(Period{Years: 1}, anchor, 0))
+	assert.Nil(t, PreviewCalendarRecurrence(Period{Years: 1}, anchor, -1))
+}
@@ -0,0 +1,161 @@
+package timeinterval
+
+import (
+	"sort"
+	"time"
+)
+
+// Pipeline is a chain of transformations of a set of intervals, e.g.:
+//
+//	NewPipeline(ins).Clip(window).Merge(0).SplitBy(Period{Days: 1}).Filter(pred).Collect()
+//
+// The transformations are applied by Collect. Consecutive Clip, SplitBy and Filter steps are fused into a single
+// pass over the intervals without intermediate slices. Merge needs all intervals and ends a pass.
+type Pipeline struct {
+	input  []Interval
+	stages []pipelineStage
+}
+
+// pipelineStage is a step of a Pipeline. It either transforms each interval independently by calling emit for
+// each resulting interval or, if merge is set, merges all intervals.
+type pipelineStage struct {
+	each  func(in Interval, emit func(Interval))
+	merge bool
+	// tolerance is the largest gap between intervals that are merged.
+	tolerance time.Duration
+}
+
+// NewPipeline returns a Pipeline over the given intervals.
+func NewPipeline(ins []Interval) *Pipeline {
+	return &Pipeline{input: ins}
+}
+
+// Clip limits the intervals to the window and drops those that do not overlap it.
+func (p *Pipeline) Clip(window Interval) *Pipeline {
+	return p.then(func(in Interval, emit func(Interval)) {
+		if !overlaps(in, window) {
+			return
+		}
+		if in.StartsAt.Before(window.StartsAt) || in.EndsAt.After(window.EndsAt) {
+			in = Interval{StartsAt: in.StartsAt, EndsAt: in.EndsAt, Meta: in.Meta}
+			if in.StartsAt.Before(window.StartsAt) {
+				in.StartsAt = window.StartsAt
+			}
+			if in.EndsAt.After(window.EndsAt) {
+				in.EndsAt = window.EndsAt
+			}
+			in.Format = boundsFormat(in.StartsAt, in.EndsAt)
+		}
+		emit(in)
+	})
+}
+
+// Merge sorts the intervals by their start and merges those that overlap or are at most tolerance apart.
+// The metadata of merged intervals is merged.
+func (p *Pipeline) Merge(tolerance time.Duration) *Pipeline {
+	p.stages = append(p.stages, pipelineStage{merge: true, tolerance: tolerance})
+	return p
+}
+
+// SplitBy splits the intervals at the boundaries of the given unit, e.g. at midnight for Period{Days: 1}.
+// The boundaries of a calendar day, week (starting Monday), month or year are the starts of those units in the
+// location of each interval. The boundaries of other periods are multiples of the period from the start of the day.
+// Open intervals are not split and zero or negative periods, which have no boundaries to split at, leave the
+// intervals unchanged.
+func (p *Pipeline) SplitBy(unit Period) *Pipeline {
+	return p.then(func(in Interval, emit func(Interval)) {
+		if !unit.isPositive() || in.OpenStart() || in.OpenEnd() {
+			emit(in)
+			return
+		}
+		loc := in.StartsAt.Location()
+		anchor := startOfCalendarUnit(in.StartsAt, unit, time.Monday, loc)
+		k := 1
+		boundary := unit.Shift(anchor, k)
+		for !boundary.After(in.StartsAt) {
+			k++
+			boundary = unit.Shift(anchor, k)
+		}
+		if !boundary.Before(in.EndsAt) {
+			emit(in)
+			return
+		}
+		for startsAt := in.StartsAt; startsAt.Before(in.EndsAt); k++ {
+			endsAt := unit.Shift(anchor, k)
+			if endsAt.After(in.EndsAt) {
+				endsAt = in.EndsAt
+			}
+			emit(Interval{StartsAt: startsAt, EndsAt: endsAt, Format: ISOFormatTimeAndTime, Meta: in.Meta})
+			startsAt = endsAt
+		}
+	})
+}
+
+// Filter keeps the intervals for which keep returns true.
+func (p *Pipeline) Filter(keep func(Interval) bool) *Pipeline {
+	return p.then(func(in Interval, emit func(Interval)) {
+		if keep(in) {
+			emit(in)
+		}
+	})
+}
+
+// Collect applies the transformations and returns the resulting intervals. The pipeline can be collected again.
+func (p *Pipeline) Collect() []Interval {
+	ins := p.input
+	for i := 0; i < len(p.stages); {
+		if p.stages[i].merge {
+			ins = mergeWithin(ins, p.stages[i].tolerance)
+			i++
+			continue
+		}
+		// Fuse the consecutive per-interval stages into one pass.
+		j := i
+		for j < len(p.stages) && !p.stages[j].merge {
+			j++
+		}
+		var result []Interval
+		emit := func(in Interval) {
+			result = append(result, in)
+		}
+		for k := j - 1; k >= i; k-- {
+			each, next := p.stages[k].each, emit
+			emit = func(in Interval) {
+				each(in, next)
+			}
+		}
+		for _, in := range ins {
+			emit(in)
+		}
+		ins = result
+		i = j
+	}
+	if len(p.stages) == 0 {
+		ins = append([]Interval{}, ins...)
+	}
+	return ins
+}
+
+func (p *Pipeline) then(each func(in Interval, emit func(Interval))) *Pipeline {
+	p.stages = append(p.stages, pipelineStage{each: each})
+	return p
+}
+
+// mergeWithin returns the intervals sorted by their start with those that overlap or are at most tolerance apart
+// merged. Unlike normalize, zero length intervals are kept.
+func mergeWithin(ins []Interval, tolerance time.Duration) []Interval {
+	sorted := append([]Interval{}, ins...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartsAt.Before(sorted[j].StartsAt)
+	})
+	var result []Interval
+	for _, in := range sorted {
+		last := len(result) - 1
+		if last >= 0 && !in.StartsAt.After(result[last].EndsAt.Add(tolerance)) {
+			result[last] = span(result[last], in)
+			continue
+		}
+		result = append(result, in)
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	ins := mustIntervals(t,
+		"2019-01-01T20:00:00Z/PT2H",
+		"2019-01-02T21:00:00Z/PT2H",
+		"2019-01-02T23:10:00Z/PT1H",
+		"2019-01-03T12:00:00Z/PT30M",
+		"2019-01-05T12:00:00Z/PT1H",
+	)
+	window := *MustParseIntervalISO8601("2019-01-02T00:00:00Z/2019-01-04T00:00:00Z")
+	pipeline := NewPipeline(ins).
+		Clip(window).
+		Merge(15 * time.Minute).
+		SplitBy(Period{Days: 1}).
+		Filter(func(in Interval) bool {
+			return in.Duration() >= time.Hour
+		})
+	result := pipeline.Collect()
+	assert.Equal(t, mustIntervals(t, "2019-01-02T21:00:00Z/2019-01-03T00:00:00Z"), result)
+	// Collecting again yields the same result.
+	assert.Equal(t, result, pipeline.Collect())
+
+	result = NewPipeline(ins).Clip(window).Merge(15 * time.Minute).SplitBy(Period{Days: 1}).Collect()
+	assert.Equal(t, mustIntervals(t,
+		"2019-01-02T21:00:00Z/2019-01-03T00:00:00Z",
+		"2019-01-03T00:00:00Z/2019-01-03T00:10:00Z",
+		"2019-01-03T12:00:00Z/PT30M",
+	), result)
+
+	// Without a tolerance the touching but not overlapping intervals are kept apart.
+	result = NewPipeline(ins).Merge(0).Collect()
+	assert.Len(t, result, 5)
+	assert.Len(t, NewPipeline(ins).Collect(), 5)
+	assert.Empty(t, NewPipeline(nil).Clip(window).Merge(0).Collect())
+
+	// Clipping to an open window keeps the open bounds of the intervals.
+	open := mustIntervals(t, "2019-01-02T12:00:00Z/..", "../2019-01-02T12:00:00Z")
+	result = NewPipeline(open).Clip(*NewOpenEndInterval(window.StartsAt)).Collect()
+	assert.Equal(t, []string{"2019-01-02T12:00:00Z/..", "2019-01-02T00:00:00Z/2019-01-02T12:00:00Z"}, isos(t, result))
+	result = NewPipeline(mustIntervals(t, "2019-01-02T12:00:00Z/..", "2019-01-02T10:00:00Z/PT4H")).Merge(0).Collect()
+	assert.Equal(t, []string{"2019-01-02T10:00:00Z/.."}, isos(t, result))
+}
+
+func isos(t *testing.T, ins []Interval) []string {
+	var result []string
+	for _, in := range ins {
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		result = append(result, iso)
+	}
+	return result
+}
+
+func TestPipeline_SplitBy(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	assert.Nil(t, err)
+	startsAt := time.Date(2019, 1, 30, 18, 0, 0, 0, loc)
+	endsAt := time.Date(2019, 2, 2, 6, 0, 0, 0, loc)
+	in, err := NewInterval(&startsAt, &endsAt, nil)
+	assert.Nil(t, err)
+
+	days := NewPipeline([]Interval{*in}).SplitBy(Period{Days: 1}).Collect()
+	assert.Len(t, days, 4)
+	assert.Equal(t, time.Date(2019, 1, 31, 0, 0, 0, 0, loc), days[0].EndsAt)
+	assert.Equal(t, endsAt, days[3].EndsAt)
+
+	months := NewPipeline([]Interval{*in}).SplitBy(Period{Months: 1}).Collect()
+	assert.Len(t, months, 2)
+	assert.Equal(t, time.Date(2019, 2, 1, 0, 0, 0, 0, loc), months[0].EndsAt)
+
+	quarters := NewPipeline([]Interval{*in}).SplitBy(Period{Time: 6 * time.Hour}).Collect()
+	assert.Len(t, quarters, 10)
+	assert.Equal(t, time.Date(2019, 1, 31, 0, 0, 0, 0, loc), quarters[0].EndsAt)
+
+	// Intervals within a unit are kept as they are.
+	short := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT1H")
+	assert.Equal(t, []Interval{*short}, NewPipeline([]Interval{*short}).SplitBy(Period{Days: 1}).Collect())
+	open := NewOpenEndInterval(startsAt)
+	assert.Equal(t, []Interval{*open}, NewPipeline([]Interval{*open}).SplitBy(Period{Days: 1}).Collect())
+
+	// Zero and negative periods leave the intervals unchanged.
+	for _, unit := range []Period{{}, {Days: -1}, {Time: -time.Hour}, {Months: 1, Days: -1}} {
+		assert.Equal(t, []Interval{*in}, NewPipeline([]Interval{*in}).SplitBy(unit).Collect(), unit)
+	}
+}
+
+func BenchmarkPipeline(b *testing.B) {
+	startsAt := time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)
+	ins := make([]Interval, 1000)
+	for i := range ins {
+		ins[i] = Interval{Format: ISOFormatTimeAndTime, StartsAt: startsAt.Add(time.Duration(i) * time.Hour), EndsAt: startsAt.Add(time.Duration(i)*time.Hour + 90*time.Minute)}
+	}
+	window := Interval{Format: ISOFormatTimeAndTime, StartsAt: startsAt.Add(100 * time.Hour), EndsAt: startsAt.Add(900 * time.Hour)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewPipeline(ins).Clip(window).SplitBy(Period{Days: 1}).Filter(func(in Interval) bool {
+			return in.Duration() > time.Hour
+		}).Collect()
+	}
+}
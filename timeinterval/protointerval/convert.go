@@ -0,0 +1,132 @@
+package protointerval
+
+import (
+	"errors"
+	"time"
+
+	"github.com/corthmann/go-time-intervals/timeinterval"
+	"google.golang.org/genproto/googleapis/type/interval"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProto returns the interval as a google.type.Interval. An open start or end is left unset.
+// The calendar Period, Format and Meta of the interval are not converted.
+func ToProto(in timeinterval.Interval) (*interval.Interval, error) {
+	p := &interval.Interval{}
+	if !in.OpenStart() {
+		ts, err := timestamp(in.StartsAt)
+		if err != nil {
+			return nil, err
+		}
+		p.StartTime = ts
+	}
+	if !in.OpenEnd() {
+		ts, err := timestamp(in.EndsAt)
+		if err != nil {
+			return nil, err
+		}
+		p.EndTime = ts
+	}
+	return p, nil
+}
+
+// FromProto returns the google.type.Interval as an Interval. An unset start or end time results in an open
+// interval, and an error is returned if both are unset since an interval cannot be open at both ends.
+func FromProto(p *interval.Interval) (*timeinterval.Interval, error) {
+	if p == nil {
+		return nil, errors.New("interval is nil")
+	}
+	var startsAt, endsAt *time.Time
+	if p.StartTime != nil {
+		t, err := asTime(p.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		startsAt = &t
+	}
+	if p.EndTime != nil {
+		t, err := asTime(p.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		endsAt = &t
+	}
+	switch {
+	case startsAt == nil && endsAt == nil:
+		return nil, errors.New("interval cannot be open at both ends")
+	case startsAt == nil:
+		return timeinterval.NewOpenStartInterval(*endsAt), nil
+	case endsAt == nil:
+		return timeinterval.NewOpenEndInterval(*startsAt), nil
+	}
+	return timeinterval.NewInterval(startsAt, endsAt, nil)
+}
+
+// RepeatingToProto returns the repeating interval as a Repeating message. The repeat format and occurrence
+// reference of the repeating interval are not converted.
+func RepeatingToProto(r timeinterval.Repeating) (*Repeating, error) {
+	if r.Interval.OpenStart() || r.Interval.OpenEnd() {
+		return nil, timeinterval.ErrOpenInterval
+	}
+	in, err := ToProto(r.Interval)
+	if err != nil {
+		return nil, err
+	}
+	p := &Repeating{Interval: in}
+	if r.Interval.Period != nil {
+		if p.Period, err = r.Interval.Period.ISO8601(); err != nil {
+			return nil, err
+		}
+	}
+	if r.Repetitions != nil {
+		reps := *r.Repetitions
+		p.Repetitions = &reps
+	}
+	return p, nil
+}
+
+// RepeatingFromProto returns the Repeating message as a repeating interval. An error is returned if the first
+// repetition is open or has zero length.
+func RepeatingFromProto(p *Repeating) (*timeinterval.Repeating, error) {
+	if p == nil {
+		return nil, errors.New("repeating interval is nil")
+	}
+	in, err := FromProto(p.Interval)
+	if err != nil {
+		return nil, err
+	}
+	if in.OpenStart() || in.OpenEnd() {
+		return nil, timeinterval.ErrOpenInterval
+	}
+	if in.Duration() == 0 {
+		return nil, timeinterval.ErrZeroLengthRepeating
+	}
+	if p.Period != "" {
+		period, err := timeinterval.ParsePeriodISO8601(p.Period)
+		if err != nil {
+			return nil, err
+		}
+		in.Period = &period
+		in.Format = timeinterval.ISOFormatTimeAndDuration
+	}
+	r := &timeinterval.Repeating{Interval: *in}
+	if p.Repetitions != nil {
+		reps := *p.Repetitions
+		r.Repetitions = &reps
+	}
+	return r, nil
+}
+
+// timestamp returns the time as a Timestamp or an error if it is outside the range of Timestamp.
+func timestamp(t time.Time) (*timestamppb.Timestamp, error) {
+	ts := timestamppb.New(t)
+	return ts, ts.CheckValid()
+}
+
+// asTime returns the Timestamp as a time in UTC or an error if the Timestamp is invalid.
+func asTime(ts *timestamppb.Timestamp) (time.Time, error) {
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, err
+	}
+	return ts.AsTime(), nil
+}
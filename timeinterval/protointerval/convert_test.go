@@ -0,0 +1,112 @@
+package protointerval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/corthmann/go-time-intervals/timeinterval"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/type/interval"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestToProto(t *testing.T) {
+	expectations := []string{
+		"2019-01-02T21:00:00Z/2019-01-03T21:00:00Z",
+		"2019-01-02T21:00:00Z/..",
+		"../2019-01-03T21:00:00Z",
+	}
+	for _, expected := range expectations {
+		p, err := ToProto(*timeinterval.MustParseIntervalISO8601(expected))
+		assert.Nil(t, err)
+		// The message survives the wire format.
+		b, err := proto.Marshal(p)
+		assert.Nil(t, err)
+		decoded := &interval.Interval{}
+		assert.Nil(t, proto.Unmarshal(b, decoded))
+		in, err := FromProto(decoded)
+		assert.Nil(t, err)
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso)
+	}
+
+	p, err := ToProto(*timeinterval.MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT1H"))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC), p.StartTime.AsTime())
+	assert.Equal(t, time.Date(2019, 1, 2, 22, 0, 0, 0, time.UTC), p.EndTime.AsTime())
+	p, err = ToProto(*timeinterval.NewOpenEndInterval(time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC)))
+	assert.Nil(t, err)
+	assert.Nil(t, p.EndTime)
+
+	_, err = ToProto(*timeinterval.NewOpenStartInterval(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.NotNil(t, err)
+}
+
+func TestFromProto(t *testing.T) {
+	_, err := FromProto(nil)
+	assert.NotNil(t, err)
+	_, err = FromProto(&interval.Interval{})
+	assert.NotNil(t, err)
+	startsAt := timestamppb.New(time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC))
+	endsAt := timestamppb.New(time.Date(2019, 1, 3, 21, 0, 0, 0, time.UTC))
+	_, err = FromProto(&interval.Interval{StartTime: endsAt, EndTime: startsAt})
+	assert.NotNil(t, err)
+	_, err = FromProto(&interval.Interval{StartTime: startsAt, EndTime: &timestamppb.Timestamp{Nanos: -1}})
+	assert.NotNil(t, err)
+
+	in, err := FromProto(&interval.Interval{StartTime: startsAt, EndTime: startsAt})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), in.Duration())
+}
+
+func TestRepeatingToProto(t *testing.T) {
+	expectations := []string{
+		"R5/2019-01-02T21:00:00Z/2019-01-02T22:00:00Z",
+		"R/2019-01-31T00:00:00Z/P1M",
+		"R0/2019-01-02T21:00:00Z/2019-01-03T21:00:00Z",
+	}
+	for _, expected := range expectations {
+		p, err := RepeatingToProto(*timeinterval.MustParseRepeatingIntervalISO8601(expected))
+		assert.Nil(t, err)
+		b, err := proto.Marshal(p)
+		assert.Nil(t, err)
+		decoded := &Repeating{}
+		assert.Nil(t, proto.Unmarshal(b, decoded))
+		r, err := RepeatingFromProto(decoded)
+		assert.Nil(t, err)
+		iso, err := r.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso)
+	}
+
+	p, err := RepeatingToProto(*timeinterval.MustParseRepeatingIntervalISO8601("R/2019-01-31T00:00:00Z/P1M"))
+	assert.Nil(t, err)
+	assert.Equal(t, "P1M", p.Period)
+	assert.Nil(t, p.Repetitions)
+
+	_, err = RepeatingToProto(timeinterval.Repeating{Interval: *timeinterval.NewOpenEndInterval(time.Now())})
+	assert.Equal(t, timeinterval.ErrOpenInterval, err)
+}
+
+func TestRepeatingFromProto(t *testing.T) {
+	startsAt := timestamppb.New(time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC))
+	endsAt := timestamppb.New(time.Date(2019, 1, 3, 21, 0, 0, 0, time.UTC))
+	_, err := RepeatingFromProto(nil)
+	assert.NotNil(t, err)
+	_, err = RepeatingFromProto(&Repeating{})
+	assert.NotNil(t, err)
+	_, err = RepeatingFromProto(&Repeating{Interval: &interval.Interval{StartTime: startsAt}})
+	assert.Equal(t, timeinterval.ErrOpenInterval, err)
+	_, err = RepeatingFromProto(&Repeating{Interval: &interval.Interval{StartTime: startsAt, EndTime: startsAt}})
+	assert.Equal(t, timeinterval.ErrZeroLengthRepeating, err)
+	_, err = RepeatingFromProto(&Repeating{Interval: &interval.Interval{StartTime: startsAt, EndTime: endsAt}, Period: "1D"})
+	assert.NotNil(t, err)
+
+	r, err := RepeatingFromProto(&Repeating{Interval: &interval.Interval{StartTime: startsAt, EndTime: endsAt}, Period: "P1D"})
+	assert.Nil(t, err)
+	assert.Nil(t, r.Repetitions)
+	assert.Equal(t, timeinterval.Period{Days: 1}, *r.Interval.Period)
+	assert.Equal(t, 24*time.Hour, r.RepeatEvery())
+}
@@ -0,0 +1,12 @@
+/*
+Package protointerval converts the timeinterval types to and from protocol buffer messages, so that gRPC services
+can exchange them without copying fields by hand.
+
+Intervals are converted to the well-known google.type.Interval, where an open start or end is an unset time.
+Repeating intervals are converted to the Repeating message defined in repeating.proto, which embeds the first
+repetition as a google.type.Interval. Times are read as UTC.
+
+The package depends on google.golang.org/protobuf and google.golang.org/genproto, which the timeinterval package
+itself does not. repeating.pb.go is generated with protoc-gen-go from repeating.proto.
+*/
+package protointerval
@@ -0,0 +1,151 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: timeinterval/protointerval/repeating.proto
+
+package protointerval
+
+import (
+	interval "google.golang.org/genproto/googleapis/type/interval"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Repeating is a repeating interval with recurring events distributed evenly by the duration of its first
+// repetition. See: timeinterval.Repeating
+type Repeating struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The first repetition. It must have both a start and an end time.
+	Interval *interval.Interval `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	// The calendar period of the first repetition as an ISO8601 duration (e.g. "P1M"). It is empty for repetitions
+	// of a fixed duration.
+	Period string `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	// The number of repetitions. It is unset for unbounded repetitions.
+	Repetitions   *uint32 `protobuf:"varint,3,opt,name=repetitions,proto3,oneof" json:"repetitions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Repeating) Reset() {
+	*x = Repeating{}
+	mi := &file_timeinterval_protointerval_repeating_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Repeating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Repeating) ProtoMessage() {}
+
+func (x *Repeating) ProtoReflect() protoreflect.Message {
+	mi := &file_timeinterval_protointerval_repeating_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Repeating.ProtoReflect.Descriptor instead.
+func (*Repeating) Descriptor() ([]byte, []int) {
+	return file_timeinterval_protointerval_repeating_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Repeating) GetInterval() *interval.Interval {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Repeating) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *Repeating) GetRepetitions() uint32 {
+	if x != nil && x.Repetitions != nil {
+		return *x.Repetitions
+	}
+	return 0
+}
+
+var File_timeinterval_protointerval_repeating_proto protoreflect.FileDescriptor
+
+const file_timeinterval_protointerval_repeating_proto_rawDesc = "" +
+	"\n" +
+	"*timeinterval/protointerval/repeating.proto\x12\ftimeinterval\x1a\x1agoogle/type/interval.proto\"\x8d\x01\n" +
+	"\tRepeating\x121\n" +
+	"\binterval\x18\x01 \x01(\v2\x15.google.type.IntervalR\binterval\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x12%\n" +
+	"\vrepetitions\x18\x03 \x01(\rH\x00R\vrepetitions\x88\x01\x01B\x0e\n" +
+	"\f_repetitionsBCZAgithub.com/corthmann/go-time-intervals/timeinterval/protointervalb\x06proto3"
+
+var (
+	file_timeinterval_protointerval_repeating_proto_rawDescOnce sync.Once
+	file_timeinterval_protointerval_repeating_proto_rawDescData []byte
+)
+
+func file_timeinterval_protointerval_repeating_proto_rawDescGZIP() []byte {
+	file_timeinterval_protointerval_repeating_proto_rawDescOnce.Do(func() {
+		file_timeinterval_protointerval_repeating_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_timeinterval_protointerval_repeating_proto_rawDesc), len(file_timeinterval_protointerval_repeating_proto_rawDesc)))
+	})
+	return file_timeinterval_protointerval_repeating_proto_rawDescData
+}
+
+var file_timeinterval_protointerval_repeating_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_timeinterval_protointerval_repeating_proto_goTypes = []any{
+	(*Repeating)(nil),         // 0: timeinterval.Repeating
+	(*interval.Interval)(nil), // 1: google.type.Interval
+}
+var file_timeinterval_protointerval_repeating_proto_depIdxs = []int32{
+	1, // 0: timeinterval.Repeating.interval:type_name -> google.type.Interval
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_timeinterval_protointerval_repeating_proto_init() }
+func file_timeinterval_protointerval_repeating_proto_init() {
+	if File_timeinterval_protointerval_repeating_proto != nil {
+		return
+	}
+	file_timeinterval_protointerval_repeating_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_timeinterval_protointerval_repeating_proto_rawDesc), len(file_timeinterval_protointerval_repeating_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_timeinterval_protointerval_repeating_proto_goTypes,
+		DependencyIndexes: file_timeinterval_protointerval_repeating_proto_depIdxs,
+		MessageInfos:      file_timeinterval_protointerval_repeating_proto_msgTypes,
+	}.Build()
+	File_timeinterval_protointerval_repeating_proto = out.File
+	file_timeinterval_protointerval_repeating_proto_goTypes = nil
+	file_timeinterval_protointerval_repeating_proto_depIdxs = nil
+}
@@ -0,0 +1,77 @@
+package timeinterval
+
+import (
+	"errors"
+	"hash/fnv"
+	"time"
+)
+
+// DailyWindow is a window of wall-clock time in a location that recurs every day, e.g. 02:00 to 05:00.
+type DailyWindow struct {
+	From     time.Duration
+	To       time.Duration
+	Location *time.Location
+}
+
+// NewDailyWindow returns the DailyWindow between the wall-clock times from and to ("02:00", "04:30:00") in the given
+// location. The window cannot span midnight.
+func NewDailyWindow(from, to string, loc *time.Location) (DailyWindow, error) {
+	if loc == nil {
+		return DailyWindow{}, errors.New("location cannot be nil")
+	}
+	f, err := parseClock(from)
+	if err != nil {
+		return DailyWindow{}, err
+	}
+	t, err := parseClock(to)
+	if err != nil {
+		return DailyWindow{}, err
+	}
+	if t <= f {
+		return DailyWindow{}, errors.New("end of window must be after its start")
+	}
+	return DailyWindow{From: f, To: t, Location: loc}, nil
+}
+
+// RandomizedSchedule is a Schedule with one pseudo-random occurrence per day inside a DailyWindow.
+// The occurrence on a day only depends on the date and the seed key, so it is stable across restarts and processes.
+type RandomizedSchedule struct {
+	window  DailyWindow
+	seedKey string
+}
+
+// RandomizedDaily returns a RandomizedSchedule with one occurrence per day inside the window. Different seed keys
+// (e.g. host or certificate names) spread their occurrences across the window, which is useful to spread load.
+// A window without location is evaluated in UTC.
+func RandomizedDaily(window DailyWindow, seedKey string) *RandomizedSchedule {
+	if window.Location == nil {
+		window.Location = time.UTC
+	}
+	return &RandomizedSchedule{window: window, seedKey: seedKey}
+}
+
+// Next returns the time of the first occurrence after the given time.
+func (s RandomizedSchedule) Next(t time.Time) *time.Time {
+	year, month, day := t.In(s.window.Location).Date()
+	for d := day - 1; d <= day+2; d++ {
+		occurrence := s.occurrence(year, month, d)
+		if occurrence.After(t) {
+			return &occurrence
+		}
+	}
+	return nil
+}
+
+// occurrence returns the occurrence on the given date. The offset into the window is truncated to whole seconds.
+func (s RandomizedSchedule) occurrence(year int, month time.Month, day int) time.Time {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	h := fnv.New64a()
+	h.Write([]byte(date.Format("2006-01-02") + "/" + s.seedKey))
+	width := uint64((s.window.To - s.window.From) / time.Second)
+	offset := time.Duration(0)
+	if width > 0 {
+		offset = time.Duration(h.Sum64()%width) * time.Second
+	}
+	occurrence, _ := wallClock(date.Year(), date.Month(), date.Day(), s.window.From+offset, s.window.Location, DSTShift)
+	return occurrence
+}
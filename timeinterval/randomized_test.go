@@ -0,0 +1,68 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDailyWindow(t *testing.T) {
+	w, err := NewDailyWindow("02:00", "05:30", time.UTC)
+	assert.Nil(t, err)
+	assert.Equal(t, 2*time.Hour, w.From)
+	assert.Equal(t, 5*time.Hour+30*time.Minute, w.To)
+
+	_, err = NewDailyWindow("05:00", "02:00", time.UTC)
+	assert.EqualError(t, err, "end of window must be after its start")
+	_, err = NewDailyWindow("02:00", "5pm", time.UTC)
+	assert.EqualError(t, err, "invalid time of day format")
+	_, err = NewDailyWindow("02:00", "05:00", nil)
+	assert.EqualError(t, err, "location cannot be nil")
+}
+
+func TestRandomizedDaily(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	assert.Nil(t, err)
+	w, err := NewDailyWindow("02:00", "05:00", loc)
+	assert.Nil(t, err)
+	s := RandomizedDaily(w, "example.com")
+
+	from := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	cur := from
+	days := map[string]bool{}
+	for i := 0; i < 60; i++ {
+		nxt := s.Next(cur)
+		assert.NotNil(t, nxt)
+		assert.True(t, nxt.After(cur))
+		local := nxt.In(loc)
+		clock := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+		assert.True(t, clock >= 2*time.Hour && clock < 6*time.Hour, local.String())
+		days[local.Format("2006-01-02")] = true
+		cur = *nxt
+	}
+	assert.Len(t, days, 60)
+
+	// Stable across instances and independent of the reference time within the day.
+	a := RandomizedDaily(w, "example.com").Next(from)
+	b := RandomizedDaily(w, "example.com").Next(from.Add(-6 * time.Hour))
+	assert.Equal(t, *a, *b)
+}
+
+func TestRandomizedDaily_SeedKeys(t *testing.T) {
+	w, err := NewDailyWindow("00:00", "23:59:59", time.UTC)
+	assert.Nil(t, err)
+	from := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	seen := map[time.Time]bool{}
+	for _, key := range []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com"} {
+		seen[*RandomizedDaily(w, key).Next(from)] = true
+	}
+	assert.True(t, len(seen) > 1)
+}
+
+func TestRandomizedDaily_DefaultLocation(t *testing.T) {
+	s := RandomizedDaily(DailyWindow{From: time.Hour, To: 2 * time.Hour}, "x")
+	nxt := s.Next(time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.NotNil(t, nxt)
+	assert.Equal(t, 1, nxt.Hour())
+}
@@ -0,0 +1,654 @@
+package timeinterval
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type frequency uint8
+
+// FrequencySecondly means a Recurrence repeats every Interval seconds.
+const FrequencySecondly frequency = 1
+
+// FrequencyMinutely means a Recurrence repeats every Interval minutes.
+const FrequencyMinutely frequency = 2
+
+// FrequencyHourly means a Recurrence repeats every Interval hours.
+const FrequencyHourly frequency = 3
+
+// FrequencyDaily means a Recurrence repeats every Interval days.
+const FrequencyDaily frequency = 4
+
+// FrequencyWeekly means a Recurrence repeats every Interval weeks.
+const FrequencyWeekly frequency = 5
+
+// FrequencyMonthly means a Recurrence repeats every Interval months.
+const FrequencyMonthly frequency = 6
+
+// FrequencyYearly means a Recurrence repeats every Interval years.
+const FrequencyYearly frequency = 7
+
+// frequencyNames holds the FREQ values of RFC 5545 recurrence rules by frequency.
+var frequencyNames = map[frequency]string{
+	FrequencySecondly: "SECONDLY",
+	FrequencyMinutely: "MINUTELY",
+	FrequencyHourly:   "HOURLY",
+	FrequencyDaily:    "DAILY",
+	FrequencyWeekly:   "WEEKLY",
+	FrequencyMonthly:  "MONTHLY",
+	FrequencyYearly:   "YEARLY",
+}
+
+// recurrenceWeekdays holds the two letter weekdays of RFC 5545 recurrence rules.
+var recurrenceWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// recurrenceMaxYears is the number of years without occurrences after which a Recurrence is considered to have
+// none. The Gregorian calendar repeats every 400 years, so rules without occurrences in that time never match.
+const recurrenceMaxYears = 400
+
+// Recurrence describes an RFC 5545 recurrence rule (e.g. FREQ=WEEKLY;BYDAY=MO,WE) starting at the time Start.
+// Unlike Repeating, whose repetitions are spread evenly, a Recurrence can express calendar rules such as
+// "Mondays and Wednesdays" or "the last day of each month". See: RFC 5545 section 3.3.10.
+// Only the BYMONTH, BYMONTHDAY and BYDAY (without ordinals) rule parts are supported.
+type Recurrence struct {
+	// Start is the first possible occurrence (DTSTART). Occurrences are at the wall clock time of Start.
+	Start     time.Time
+	Frequency frequency
+	// Interval is the number of frequency units between repetitions. Zero means 1.
+	Interval int
+	// Count limits the number of occurrences if it is positive.
+	Count int
+	// Until is the last possible occurrence. Count and Until cannot both be set.
+	Until      *time.Time
+	ByMonth    []time.Month
+	ByMonthDay []int
+	ByDay      []time.Weekday
+	// WeekStart is the first day of the week (WKST). RFC 5545 defaults to Monday, which ParseRecurrence uses.
+	WeekStart time.Weekday
+}
+
+// ParseRecurrence parses an RFC 5545 recurrence rule (e.g. "FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20190131T000000Z")
+// into a Recurrence starting at the given time. A leading "RRULE:" is ignored. UNTIL values without a time zone
+// designator are interpreted in the location of start and dates include the whole day.
+func ParseRecurrence(rule string, start time.Time) (*Recurrence, error) {
+	r := Recurrence{Start: start, WeekStart: time.Monday}
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	for _, part := range strings.Split(rule, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid recurrence rule part: %v", part)
+		}
+		name, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+		var err error
+		switch name {
+		case "FREQ":
+			r.Frequency, err = parseFrequency(value)
+		case "INTERVAL":
+			r.Interval, err = parsePositive(value)
+		case "COUNT":
+			r.Count, err = parsePositive(value)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseRecurrenceUntil(value, start.Location())
+			r.Until = &until
+		case "BYMONTH":
+			err = parseRecurrenceList(value, func(v string) error {
+				month, err := strconv.Atoi(v)
+				if err != nil || month < 1 || month > 12 {
+					return errors.New("invalid BYMONTH value")
+				}
+				r.ByMonth = append(r.ByMonth, time.Month(month))
+				return nil
+			})
+		case "BYMONTHDAY":
+			err = parseRecurrenceList(value, func(v string) error {
+				day, err := strconv.Atoi(v)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return errors.New("invalid BYMONTHDAY value")
+				}
+				r.ByMonthDay = append(r.ByMonthDay, day)
+				return nil
+			})
+		case "BYDAY":
+			err = parseRecurrenceList(value, func(v string) error {
+				weekday, err := parseRecurrenceWeekday(v)
+				r.ByDay = append(r.ByDay, weekday)
+				return err
+			})
+		case "WKST":
+			r.WeekStart, err = parseRecurrenceWeekday(value)
+		default:
+			err = fmt.Errorf("unsupported recurrence rule part: %v", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func parseFrequency(s string) (frequency, error) {
+	for f, name := range frequencyNames {
+		if name == s {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid recurrence frequency: %v", s)
+}
+
+func parsePositive(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid positive integer: %v", s)
+	}
+	return n, nil
+}
+
+func parseRecurrenceUntil(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(icsTimeLayout, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", s, loc); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("20060102", s, loc)
+	if err != nil {
+		return t, errors.New("invalid UNTIL value")
+	}
+	return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+}
+
+func parseRecurrenceList(s string, fn func(string) error) error {
+	for _, v := range strings.Split(s, ",") {
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseRecurrenceWeekday(s string) (time.Weekday, error) {
+	for i, name := range recurrenceWeekdays {
+		if name == s {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported weekday: %v", s)
+}
+
+// Validate returns an error if the frequency is unset or both Count and Until are set.
+func (r Recurrence) Validate() error {
+	if _, ok := frequencyNames[r.Frequency]; !ok {
+		return errors.New("recurrence must have a frequency")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return errors.New("recurrence cannot have both COUNT and UNTIL")
+	}
+	return nil
+}
+
+// RRule returns the recurrence formatted as an RFC 5545 recurrence rule, e.g. "FREQ=WEEKLY;BYDAY=MO,WE".
+// UNTIL is formatted in UTC.
+func (r Recurrence) RRule() string {
+	parts := []string{"FREQ=" + frequencyNames[r.Frequency]}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(icsTimeLayout))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, month := range r.ByMonth {
+			months[i] = strconv.Itoa(int(month))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = recurrenceWeekdays[day]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+recurrenceWeekdays[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence after the given time or nil if there is none.
+func (r Recurrence) Next(t time.Time) *time.Time {
+	var next *time.Time
+	r.each(t, func(o time.Time) bool {
+		if o.After(t) {
+			next = &o
+			return false
+		}
+		return true
+	})
+	return next
+}
+
+// each calls fn with the occurrences in order until fn returns false or there are no more occurrences.
+// Unless the occurrences must be counted from Start, it starts shortly before the given time.
+func (r Recurrence) each(from time.Time, fn func(time.Time) bool) {
+	k := 0
+	if r.Count == 0 || r.everyPeriod() {
+		// Start a period early since the estimate does not account for DST transitions.
+		if k = r.periodIndex(from) - 1; k < 0 {
+			k = 0
+		}
+	}
+	limit := r.periodStart(k).AddDate(recurrenceMaxYears, 0, 0)
+	// Every period before k has exactly one occurrence if the occurrences are counted from k > 0.
+	n := k
+	for ; ; k++ {
+		start := r.periodStart(k)
+		if start.After(limit) {
+			return
+		}
+		if r.Frequency.subDaily() && !r.dayMatches(start.Date()) {
+			// Skip to the next matching day instead of checking each period of the days in between.
+			day, ok := r.nextMatchingDay(start, limit)
+			if !ok {
+				return
+			}
+			k = r.periodAtOrAfter(day) - 1
+			continue
+		}
+		for _, o := range r.candidates(k) {
+			if o.Before(r.Start) {
+				continue
+			}
+			if r.Until != nil && o.After(*r.Until) {
+				return
+			}
+			n++
+			if r.Count > 0 && n > r.Count {
+				return
+			}
+			if !fn(o) {
+				return
+			}
+		}
+	}
+}
+
+// everyPeriod returns a boolean indicating if each period has exactly one occurrence, which is the case without BY
+// rules unless monthly or yearly occurrences skip months without the day of Start.
+func (r Recurrence) everyPeriod() bool {
+	if len(r.ByMonth) > 0 || len(r.ByMonthDay) > 0 || len(r.ByDay) > 0 {
+		return false
+	}
+	return r.Frequency < FrequencyMonthly || r.Start.Day() <= 28
+}
+
+// nextMatchingDay returns midnight of the first day after t that is matched by the BY rules or false if there is
+// none until the limit.
+func (r Recurrence) nextMatchingDay(t, limit time.Time) (time.Time, bool) {
+	year, month, day := t.Date()
+	for d := calendarDate(year, month, day+1); ; d = d.AddDate(0, 0, 1) {
+		if len(r.ByMonth) > 0 && !containsMonth(r.ByMonth, d.Month()) {
+			d = calendarDate(d.Year(), d.Month()+1, 0)
+			continue
+		}
+		midnight := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, t.Location())
+		if midnight.After(limit) {
+			return time.Time{}, false
+		}
+		if r.dayMatches(d.Date()) {
+			return midnight, true
+		}
+	}
+}
+
+// periodAtOrAfter returns the index of the first sub-daily period starting at or after t.
+func (r Recurrence) periodAtOrAfter(t time.Time) int {
+	k := r.periodIndex(t)
+	if r.periodStart(k).Before(t) {
+		k++
+	}
+	return k
+}
+
+func (r Recurrence) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
+
+// subDaily returns a boolean indicating if the frequency is secondly, minutely or hourly.
+func (f frequency) subDaily() bool {
+	return f < FrequencyDaily
+}
+
+// unit returns the length of the sub-daily frequencies.
+func (f frequency) unit() time.Duration {
+	switch f {
+	case FrequencySecondly:
+		return time.Second
+	case FrequencyMinutely:
+		return time.Minute
+	}
+	return time.Hour
+}
+
+// periodIndex returns an estimate of the index of the period containing t.
+func (r Recurrence) periodIndex(t time.Time) int {
+	t = t.In(r.Start.Location())
+	switch r.Frequency {
+	case FrequencyDaily:
+		return int(secondsBetween(r.Start, t)/86400) / r.interval()
+	case FrequencyWeekly:
+		return int(secondsBetween(r.Start, t)/(7*86400)) / r.interval()
+	case FrequencyMonthly:
+		return ((t.Year()-r.Start.Year())*12 + int(t.Month()-r.Start.Month())) / r.interval()
+	case FrequencyYearly:
+		return (t.Year() - r.Start.Year()) / r.interval()
+	}
+	return int(secondsBetween(r.Start, t)/int64(r.Frequency.unit()/time.Second)) / r.interval()
+}
+
+// secondsBetween returns the number of seconds from a to b, ignoring fractions of seconds. Unlike Time.Sub, it does not saturate for times
+// more than 292 years apart.
+func secondsBetween(a, b time.Time) int64 {
+	return b.Unix() - a.Unix()
+}
+
+// periodStart returns the start of the k-th period.
+func (r Recurrence) periodStart(k int) time.Time {
+	n := k * r.interval()
+	year, month, day := r.Start.Date()
+	switch r.Frequency {
+	case FrequencyDaily:
+		return r.at(year, month, day+n)
+	case FrequencyWeekly:
+		return r.at(year, month, day+7*n)
+	case FrequencyMonthly:
+		return r.at(year, month+time.Month(n), 1)
+	case FrequencyYearly:
+		return r.at(year+n, time.January, 1)
+	}
+	// Add the seconds in steps since time.Duration overflows after 292 years.
+	t, seconds := r.Start, int64(n)*int64(r.Frequency.unit()/time.Second)
+	const step = int64(math.MaxInt64 / time.Second)
+	for ; seconds > step; seconds -= step {
+		t = t.Add(time.Duration(step) * time.Second)
+	}
+	return t.Add(time.Duration(seconds) * time.Second)
+}
+
+// candidates returns the occurrences of the k-th period in order, including those before Start.
+func (r Recurrence) candidates(k int) []time.Time {
+	n := k * r.interval()
+	switch r.Frequency {
+	case FrequencySecondly, FrequencyMinutely, FrequencyHourly:
+		t := r.periodStart(k)
+		if !r.dayMatches(t.Date()) {
+			return nil
+		}
+		return []time.Time{t}
+	}
+	year, month, day := r.Start.Date()
+	var days []time.Time
+	switch r.Frequency {
+	case FrequencyDaily:
+		days = []time.Time{calendarDate(year, month, day+n)}
+	case FrequencyWeekly:
+		offset := (int(r.Start.Weekday()) - int(r.WeekStart) + 7) % 7
+		first := calendarDate(year, month, day+7*n-offset)
+		for i := 0; i < 7; i++ {
+			d := first.AddDate(0, 0, i)
+			if len(r.ByDay) > 0 || i == offset {
+				days = append(days, d)
+			}
+		}
+	case FrequencyMonthly:
+		days = r.monthDays(year, month+time.Month(n))
+	case FrequencyYearly:
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{month}
+			if len(r.ByMonthDay) > 0 || len(r.ByDay) > 0 {
+				months = []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+			}
+		}
+		for _, m := range months {
+			days = append(days, r.monthDays(year+n, m)...)
+		}
+	}
+	var result []time.Time
+	for _, d := range days {
+		if r.dayMatches(d.Date()) {
+			result = append(result, r.at(d.Date()))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result
+}
+
+// monthDays returns the days of the month given by BYMONTHDAY, all days if only BYDAY is set or otherwise the day
+// of Start if the month has it.
+func (r Recurrence) monthDays(year int, month time.Month) []time.Time {
+	first := calendarDate(year, month, 1)
+	year, month = first.Year(), first.Month()
+	last := daysIn(year, month)
+	var days []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, day := range r.ByMonthDay {
+			if day < 0 {
+				day += last + 1
+			}
+			if day >= 1 && day <= last {
+				days = append(days, calendarDate(year, month, day))
+			}
+		}
+	case len(r.ByDay) > 0:
+		for day := 1; day <= last; day++ {
+			days = append(days, calendarDate(year, month, day))
+		}
+	default:
+		if day := r.Start.Day(); day <= last {
+			days = append(days, calendarDate(year, month, day))
+		}
+	}
+	return days
+}
+
+// dayMatches returns a boolean indicating if the day is matched by BYMONTH, BYMONTHDAY and BYDAY.
+func (r Recurrence) dayMatches(year int, month time.Month, day int) bool {
+	if len(r.ByMonth) > 0 && !containsMonth(r.ByMonth, month) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 {
+		matches := false
+		last := daysIn(year, month)
+		for _, d := range r.ByMonthDay {
+			matches = matches || d == day || d == day-last-1
+		}
+		if !matches {
+			return false
+		}
+	}
+	if len(r.ByDay) > 0 {
+		weekday := calendarDate(year, month, day).Weekday()
+		matches := false
+		for _, d := range r.ByDay {
+			matches = matches || d == weekday
+		}
+		return matches
+	}
+	return true
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
+
+// calendarDate returns the day as a time at midnight UTC, normalizing days outside of the month.
+func calendarDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// at returns the day at the wall clock time of Start.
+func (r Recurrence) at(year int, month time.Month, day int) time.Time {
+	hour, min, sec := r.Start.Clock()
+	return time.Date(year, month, day, hour, min, sec, r.Start.Nanosecond(), r.Start.Location())
+}
+
+// ToRepeating returns the recurrence as a Repeating whose repetitions start at the occurrences.
+// An error is returned if the recurrence has BY rules or monthly or yearly occurrences that skip months
+// without the day of Start, since a Repeating cannot express them.
+func (r Recurrence) ToRepeating() (*Repeating, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if len(r.ByMonth) > 0 || len(r.ByMonthDay) > 0 || len(r.ByDay) > 0 {
+		return nil, errors.New("recurrence with BY rules cannot be represented as a repeating interval")
+	}
+	n := r.interval()
+	var in *Interval
+	var err error
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+		p := map[frequency]Period{
+			FrequencyDaily:   {Days: n},
+			FrequencyWeekly:  {Days: 7 * n},
+			FrequencyMonthly: {Months: n},
+			FrequencyYearly:  {Years: n},
+		}[r.Frequency]
+		if (p.Months != 0 || p.Years != 0) && r.Start.Day() > 28 {
+			return nil, errors.New("recurrence skipping short months cannot be represented as a repeating interval")
+		}
+		in, err = NewPeriodInterval(&r.Start, nil, p)
+	default:
+		d := time.Duration(n) * r.Frequency.unit()
+		in, err = NewInterval(&r.Start, nil, &d)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := Repeating{Interval: *in}
+	count := r.Count
+	if r.Until != nil {
+		count = r.untilCount()
+	}
+	if r.Count > 0 || r.Until != nil {
+		if int64(count) > math.MaxUint32 {
+			return nil, errors.New("recurrence has too many occurrences to be represented as a repeating interval")
+		}
+		reps := uint32(count)
+		result.Repetitions = &reps
+	}
+	return &result, nil
+}
+
+// untilCount returns the number of occurrences until Until of a recurrence with an occurrence in every period.
+// It is computed from an estimate instead of iterating the occurrences, which takes long for sub-daily frequencies.
+func (r Recurrence) untilCount() int {
+	k := r.periodIndex(*r.Until)
+	if k < -1 {
+		k = -1
+	}
+	for k >= 0 && r.occurrence(k).After(*r.Until) {
+		k--
+	}
+	for !r.occurrence(k + 1).After(*r.Until) {
+		k++
+	}
+	return k + 1
+}
+
+// occurrence returns the occurrence of the k-th period of a recurrence with an occurrence in every period.
+func (r Recurrence) occurrence(k int) time.Time {
+	n := k * r.interval()
+	year, month, day := r.Start.Date()
+	switch r.Frequency {
+	case FrequencyMonthly:
+		return r.at(year, month+time.Month(n), day)
+	case FrequencyYearly:
+		return r.at(year+n, month, day)
+	}
+	return r.periodStart(k)
+}
+
+// RecurrenceFromRepeating returns a Recurrence with occurrences at the starts of the repetitions of the repeating
+// interval. Fixed durations are expressed in seconds, minutes or hours, or in days and weeks for intervals in UTC.
+// An error is returned for open intervals and calendar Periods mixing units, which a Recurrence cannot express.
+func RecurrenceFromRepeating(rep Repeating) (*Recurrence, error) {
+	in := rep.Interval
+	if in.OpenStart() || in.OpenEnd() {
+		return nil, errors.New("open intervals cannot be represented as a recurrence")
+	}
+	r := Recurrence{Start: in.StartsAt, WeekStart: time.Monday}
+	if in.Period != nil {
+		p := *in.Period
+		switch {
+		case p.Time == 0 && p.Days == 0 && (p.Years != 0 || p.Months != 0) && p.Months%12 == 0:
+			r.Frequency, r.Interval = FrequencyYearly, p.Years+p.Months/12
+		case p.Time == 0 && p.Days == 0 && (p.Years != 0 || p.Months != 0):
+			r.Frequency, r.Interval = FrequencyMonthly, p.Years*12+p.Months
+		case p.Time == 0 && p.Years == 0 && p.Months == 0 && p.Days%7 == 0 && p.Days > 0:
+			r.Frequency, r.Interval = FrequencyWeekly, p.Days/7
+		case p.Time == 0 && p.Years == 0 && p.Months == 0 && p.Days > 0:
+			r.Frequency, r.Interval = FrequencyDaily, p.Days
+		default:
+			return nil, errors.New("period cannot be represented as a recurrence")
+		}
+		if r.Frequency >= FrequencyMonthly && r.Start.Day() > 28 {
+			return nil, errors.New("clamped repetitions cannot be represented as a recurrence")
+		}
+	} else {
+		d := rep.RepeatEvery()
+		utc := r.Start.Location() == time.UTC
+		switch {
+		case d <= 0:
+			return nil, errors.New("repeating interval must have a positive duration")
+		case utc && d%durationWeek == 0:
+			r.Frequency, r.Interval = FrequencyWeekly, int(d/durationWeek)
+		case utc && d%durationDay == 0:
+			r.Frequency, r.Interval = FrequencyDaily, int(d/durationDay)
+		case d%time.Hour == 0:
+			r.Frequency, r.Interval = FrequencyHourly, int(d/time.Hour)
+		case d%time.Minute == 0:
+			r.Frequency, r.Interval = FrequencyMinutely, int(d/time.Minute)
+		case d%time.Second == 0:
+			r.Frequency, r.Interval = FrequencySecondly, int(d/time.Second)
+		default:
+			return nil, errors.New("fractional seconds cannot be represented as a recurrence")
+		}
+	}
+	if rep.Repetitions != nil {
+		if *rep.Repetitions == 0 {
+			return nil, errors.New("repeating interval without repetitions cannot be represented as a recurrence")
+		}
+		r.Count = int(*rep.Repetitions)
+	}
+	return &r, nil
+}
@@ -0,0 +1,144 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recurrenceOccurrences(r *Recurrence, from time.Time, n int) []string {
+	var result []string
+	for at := from; len(result) < n; {
+		next := r.Next(at)
+		if next == nil {
+			break
+		}
+		result = append(result, next.Format(time.RFC3339))
+		at = *next
+	}
+	return result
+}
+
+func TestParseRecurrence(t *testing.T) {
+	// 2019-01-02 is a Wednesday.
+	start := time.Date(2019, 1, 2, 9, 0, 0, 0, time.UTC)
+	expectations := map[string][]string{
+		"FREQ=WEEKLY;BYDAY=MO,WE":                                   {"2019-01-02T09:00:00Z", "2019-01-07T09:00:00Z", "2019-01-09T09:00:00Z", "2019-01-14T09:00:00Z"},
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;UNTIL=20190118T090000Z": {"2019-01-04T09:00:00Z", "2019-01-14T09:00:00Z", "2019-01-18T09:00:00Z"},
+		"RRULE:FREQ=DAILY;COUNT=3":                                  {"2019-01-02T09:00:00Z", "2019-01-03T09:00:00Z", "2019-01-04T09:00:00Z"},
+		"FREQ=MONTHLY;BYMONTHDAY=-1":                                {"2019-01-31T09:00:00Z", "2019-02-28T09:00:00Z", "2019-03-31T09:00:00Z"},
+		"FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR":                       {"2019-09-13T09:00:00Z", "2019-12-13T09:00:00Z"},
+		"FREQ=YEARLY;BYMONTH=1,7":                                   {"2019-01-02T09:00:00Z", "2019-07-02T09:00:00Z", "2020-01-02T09:00:00Z"},
+		"FREQ=HOURLY;INTERVAL=6;BYDAY=SA":                           {"2019-01-05T03:00:00Z", "2019-01-05T09:00:00Z"},
+		"FREQ=DAILY;UNTIL=20190103":                                 {"2019-01-02T09:00:00Z", "2019-01-03T09:00:00Z"},
+		"freq=weekly;wkst=su;byday=tu":                              {"2019-01-08T09:00:00Z"},
+	}
+	for given, expected := range expectations {
+		r, err := ParseRecurrence(given, start)
+		assert.Nil(t, err, given)
+		assert.Equal(t, expected, recurrenceOccurrences(r, start.Add(-time.Second), len(expected)), given)
+	}
+
+	// Monthly occurrences skip months without the day of the start.
+	r, err := ParseRecurrence("FREQ=MONTHLY", time.Date(2019, 1, 31, 9, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2019-03-31T09:00:00Z", "2019-05-31T09:00:00Z"}, recurrenceOccurrences(r, time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC), 2))
+	// Occurrences far after the start are found without counting from the start.
+	r, err = ParseRecurrence("FREQ=MINUTELY;INTERVAL=15", start)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2119-01-02T09:15:00Z"}, recurrenceOccurrences(r, time.Date(2119, 1, 2, 9, 0, 0, 0, time.UTC), 1))
+	// Impossible rules have no occurrences.
+	r, err = ParseRecurrence("FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", start)
+	assert.Nil(t, err)
+	assert.Nil(t, r.Next(start))
+	// Sub-daily occurrences skip days not matching the BY rules.
+	r, err = ParseRecurrence("FREQ=MINUTELY;BYMONTH=2;BYMONTHDAY=30", start)
+	assert.Nil(t, err)
+	assert.Nil(t, r.Next(start))
+	r, err = ParseRecurrence("FREQ=SECONDLY;INTERVAL=7;BYMONTH=2;BYMONTHDAY=29", start)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2020-02-29T00:00:00Z", "2020-02-29T00:00:07Z"}, recurrenceOccurrences(r, start, 2))
+	r, err = ParseRecurrence("FREQ=SECONDLY;COUNT=4000000000", start)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2119-01-02T09:00:01Z"}, recurrenceOccurrences(r, time.Date(2119, 1, 2, 9, 0, 0, 0, time.UTC), 1))
+
+	for _, given := range []string{"", "BYDAY=MO", "FREQ=FORTNIGHTLY", "FREQ=DAILY;COUNT=0", "FREQ=DAILY;COUNT=2;UNTIL=20190103",
+		"FREQ=WEEKLY;BYDAY=1MO", "FREQ=DAILY;BYMONTH=13", "FREQ=DAILY;BYMONTHDAY=0", "FREQ=DAILY;BYSETPOS=1", "FREQ=DAILY;UNTIL=tomorrow"} {
+		r, err := ParseRecurrence(given, start)
+		assert.NotNil(t, err, given)
+		assert.Nil(t, r, given)
+	}
+}
+
+func TestRecurrence_RRule(t *testing.T) {
+	start := time.Date(2019, 1, 2, 9, 0, 0, 0, time.UTC)
+	for _, given := range []string{
+		"FREQ=WEEKLY;BYDAY=MO,WE",
+		"FREQ=MONTHLY;INTERVAL=2;COUNT=5;BYMONTHDAY=1,-1",
+		"FREQ=YEARLY;UNTIL=20250101T000000Z;BYMONTH=3;WKST=SU",
+	} {
+		r, err := ParseRecurrence(given, start)
+		assert.Nil(t, err)
+		assert.Equal(t, given, r.RRule())
+	}
+}
+
+func TestRecurrence_ToRepeating(t *testing.T) {
+	start := time.Date(2019, 1, 2, 9, 0, 0, 0, time.UTC)
+	expectations := map[string]string{
+		"FREQ=DAILY;COUNT=5":                   "R5/2019-01-02T09:00:00Z/P1D",
+		"FREQ=WEEKLY;INTERVAL=2":               "R/2019-01-02T09:00:00Z/P14D",
+		"FREQ=MONTHLY;UNTIL=20190601T000000Z":  "R5/2019-01-02T09:00:00Z/P1M",
+		"FREQ=YEARLY":                          "R/2019-01-02T09:00:00Z/P1Y",
+		"FREQ=MINUTELY;INTERVAL=90":            "R/2019-01-02T09:00:00Z/PT1H30M",
+		"FREQ=SECONDLY;INTERVAL=30;COUNT=2":    "R2/2019-01-02T09:00:00Z/PT30S",
+		"FREQ=SECONDLY;UNTIL=20300101T000000Z": "R347036401/2019-01-02T09:00:00Z/PT1S",
+		"FREQ=DAILY;UNTIL=20190104T085959Z":    "R2/2019-01-02T09:00:00Z/P1D",
+	}
+	for given, expected := range expectations {
+		r, err := ParseRecurrence(given, start)
+		assert.Nil(t, err)
+		rep, err := r.ToRepeating()
+		assert.Nil(t, err, given)
+		iso, err := rep.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso)
+
+		// The conversion round-trips.
+		back, err := RecurrenceFromRepeating(*rep)
+		assert.Nil(t, err, given)
+		assert.Equal(t, recurrenceOccurrences(r, start.Add(-time.Second), 10), recurrenceOccurrences(back, start.Add(-time.Second), 10), given)
+	}
+
+	// BY rules and more repetitions than a uint32 holds cannot be represented.
+	for _, given := range []string{"FREQ=WEEKLY;BYDAY=MO,WE", "FREQ=DAILY;BYMONTH=1", "FREQ=SECONDLY;COUNT=4294967296", "FREQ=SECONDLY;UNTIL=22000101T000000Z"} {
+		r, err := ParseRecurrence(given, start)
+		assert.Nil(t, err)
+		_, err = r.ToRepeating()
+		assert.NotNil(t, err, given)
+	}
+	r, err := ParseRecurrence("FREQ=MONTHLY", time.Date(2019, 1, 31, 9, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	_, err = r.ToRepeating()
+	assert.NotNil(t, err)
+}
+
+func TestRecurrenceFromRepeating(t *testing.T) {
+	expectations := map[string]string{
+		"R5/2019-01-02T09:00:00Z/PT1H":    "FREQ=HOURLY;COUNT=5",
+		"R/2019-01-02T09:00:00Z/P1W":      "FREQ=WEEKLY",
+		"R/2019-01-02T09:00:00Z/PT48H":    "FREQ=DAILY;INTERVAL=2",
+		"R/2019-01-02T09:00:00Z/P1Y6M":    "FREQ=MONTHLY;INTERVAL=18",
+		"R/2019-01-02T09:00:00+01:00/P1D": "FREQ=HOURLY;INTERVAL=24",
+	}
+	for given, expected := range expectations {
+		r, err := RecurrenceFromRepeating(*MustParseRepeatingIntervalISO8601(given))
+		assert.Nil(t, err, given)
+		assert.Equal(t, expected, r.RRule(), given)
+	}
+	for _, given := range []string{"R/2019-01-02T09:00:00Z/P1M1D", "R/2019-01-02T09:00:00Z/PT0.5S", "R0/2019-01-02T09:00:00Z/PT1H", "R/2019-01-31T09:00:00Z/P1M"} {
+		_, err := RecurrenceFromRepeating(*MustParseRepeatingIntervalISO8601(given))
+		assert.NotNil(t, err, given)
+	}
+}
@@ -0,0 +1,277 @@
+package timeinterval
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// weekdays maps the names of weekdays to time.Weekday.
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// ParseRelative parses a human-typed description of an interval relative to ref in the given location, e.g.
+// "next monday 09:00 for 2h", "tomorrow", "today at 22:00 until 23:30", "in 30m for 1 hour" or
+// "2019-01-02 9pm for 90 minutes". The description consists of:
+//
+//  1. a start: "now", "in <duration>" or a day ("today", "tomorrow", "yesterday", "[next|last] <weekday>" or
+//     "YYYY-MM-DD") optionally followed by "[at] <time of day>" ("09:00", "9am", "9:30pm")
+//  2. an optional end: "for <duration>" or "until <time of day>" (on the following day if it is not after the start)
+//
+// Durations are Go durations ("1h30m") or numbers with a unit ("2 hours", "1 day"). Days and weeks follow the
+// calendar of the location. A day without time of day and end covers the whole day. The input is case insensitive.
+func ParseRelative(s string, ref time.Time, loc *time.Location) (*Interval, error) {
+	if loc == nil {
+		return nil, errors.New("location cannot be nil")
+	}
+	tokens := strings.Fields(strings.ToLower(s))
+	end := len(tokens)
+	for i, token := range tokens {
+		if token == "for" || token == "until" {
+			end = i
+			break
+		}
+	}
+	startsAt, wholeDay, err := parseRelativeStart(tokens[:end], ref.In(loc), loc)
+	if err != nil {
+		return nil, err
+	}
+	if end == len(tokens) {
+		if !wholeDay {
+			return nil, errors.New("missing duration (\"for\") or end (\"until\")")
+		}
+		return NewPeriodInterval(&startsAt, nil, Period{Days: 1})
+	}
+	if tokens[end] == "until" {
+		if len(tokens) != end+2 {
+			return nil, errors.New("invalid relative end format")
+		}
+		clock, err := parseRelativeClock(tokens[end+1])
+		if err != nil {
+			return nil, err
+		}
+		year, month, day := startsAt.Date()
+		endsAt, _ := wallClock(year, month, day, clock, loc, DSTShift)
+		if !endsAt.After(startsAt) {
+			endsAt, _ = wallClock(year, month, day+1, clock, loc, DSTShift)
+		}
+		return NewInterval(&startsAt, &endsAt, nil)
+	}
+	p, err := parseRelativeDuration(tokens[end+1:])
+	if err != nil {
+		return nil, err
+	}
+	if p.Days == 0 {
+		return NewInterval(&startsAt, nil, &p.Time)
+	}
+	return NewPeriodInterval(&startsAt, nil, p)
+}
+
+// parseRelativeStart parses the start of a relative description. It returns true if the start is a day without
+// time of day.
+func parseRelativeStart(tokens []string, ref time.Time, loc *time.Location) (time.Time, bool, error) {
+	if len(tokens) == 0 {
+		return time.Time{}, false, errors.New("missing relative start")
+	}
+	switch tokens[0] {
+	case "now":
+		if len(tokens) != 1 {
+			return time.Time{}, false, errors.New("invalid relative start format")
+		}
+		return ref, false, nil
+	case "in":
+		p, err := parseRelativeDuration(tokens[1:])
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		return p.AddTo(ref), false, nil
+	}
+
+	year, month, day := ref.Date()
+	n := 1
+	switch tokens[0] {
+	case "today":
+	case "tomorrow":
+		day++
+	case "yesterday":
+		day--
+	case "next", "last":
+		if len(tokens) < 2 {
+			return time.Time{}, false, errors.New("missing weekday")
+		}
+		weekday, ok := weekdays[tokens[1]]
+		if !ok {
+			return time.Time{}, false, errors.New("invalid weekday")
+		}
+		diff := int(weekday - ref.Weekday())
+		if tokens[0] == "next" && diff <= 0 {
+			diff += 7
+		}
+		if tokens[0] == "last" && diff >= 0 {
+			diff -= 7
+		}
+		day += diff
+		n = 2
+	default:
+		if weekday, ok := weekdays[tokens[0]]; ok {
+			day += (int(weekday-ref.Weekday()) + 7) % 7
+			break
+		}
+		date, err := time.Parse("2006-01-02", tokens[0])
+		if err != nil {
+			return time.Time{}, false, errors.New("invalid relative start format")
+		}
+		year, month, day = date.Date()
+	}
+
+	tokens = tokens[n:]
+	if len(tokens) > 0 && tokens[0] == "at" {
+		tokens = tokens[1:]
+	}
+	switch len(tokens) {
+	case 0:
+		t, _ := wallClock(year, month, day, 0, loc, DSTShift)
+		return t, true, nil
+	case 1:
+		clock, err := parseRelativeClock(tokens[0])
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		t, _ := wallClock(year, month, day, clock, loc, DSTShift)
+		return t, false, nil
+	}
+	return time.Time{}, false, errors.New("invalid relative start format")
+}
+
+// parseRelativeClock parses a time of day such as "09:00", "9am" or "9:30pm".
+func parseRelativeClock(s string) (time.Duration, error) {
+	if !strings.HasSuffix(s, "am") && !strings.HasSuffix(s, "pm") {
+		return parseClock(s)
+	}
+	clock := s[:len(s)-2]
+	if !strings.Contains(clock, ":") {
+		clock += ":00"
+	}
+	d, err := parseClock(clock)
+	if err != nil || d < time.Hour || d >= 13*time.Hour {
+		return 0, errors.New("invalid time of day format")
+	}
+	if d >= 12*time.Hour {
+		d -= 12 * time.Hour
+	}
+	if strings.HasSuffix(s, "pm") {
+		d += 12 * time.Hour
+	}
+	return d, nil
+}
+
+// relativeUnits maps the units of relative durations to their length. Days are handled by the calendar.
+var relativeUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": durationDay, "day": durationDay, "days": durationDay,
+	"w": 7 * durationDay, "week": 7 * durationDay, "weeks": 7 * durationDay,
+}
+
+// parseRelativeDuration parses a sequence of Go durations ("1h30m") and numbers with a unit ("2 hours", "1 day")
+// into a Period. Days and weeks are added as calendar days.
+func parseRelativeDuration(tokens []string) (Period, error) {
+	if len(tokens) == 0 {
+		return Period{}, errors.New("missing duration")
+	}
+	p := Period{}
+	for i := 0; i < len(tokens); i++ {
+		if d, err := time.ParseDuration(tokens[i]); err == nil && d > 0 {
+			p.Time += d
+			continue
+		}
+		n, err := strconv.Atoi(tokens[i])
+		if err != nil || n <= 0 || i+1 >= len(tokens) {
+			return Period{}, errors.New("invalid duration format")
+		}
+		unit, ok := relativeUnits[tokens[i+1]]
+		if !ok {
+			return Period{}, errors.New("invalid duration unit")
+		}
+		if unit%durationDay == 0 {
+			p.Days += n * int(unit/durationDay)
+		} else {
+			p.Time += time.Duration(n) * unit
+		}
+		i++
+	}
+	return p, nil
+}
+
+// calendarUnits maps the calendar units of ResolveRelative to a Period of one unit.
+var calendarUnits = map[string]Period{
+	"day":   {Days: 1},
+	"week":  {Days: 7},
+	"month": {Months: 1},
+	"year":  {Years: 1},
+}
+
+// ResolveRelative resolves the name of a dashboard time range preset into an interval relative to ref, with
+// calendar units following the calendar of the given location. The names are case insensitive and may contain
+// spaces, underscores or hyphens ("last 24h", "previous_month", "monthToDate"):
+//
+//   - last<N><unit>: the N units (m, h, d or w) up to ref, e.g. "last15m" or "last7d"
+//   - previous<unit>: the previous calendar day, week (starting Monday), month or year, e.g. "previousMonth"
+//   - <unit>ToDate: the current calendar day, week, month or year up to ref, e.g. "monthToDate" or "yearToDate"
+func ResolveRelative(name string, ref time.Time, loc *time.Location) (*Interval, error) {
+	if loc == nil {
+		return nil, errors.New("location cannot be nil")
+	}
+	ref = ref.In(loc)
+	key := strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(name))
+	switch {
+	case strings.HasPrefix(key, "last") && len(key) > len("last1"):
+		n, err := strconv.Atoi(key[len("last") : len(key)-1])
+		if err != nil || n <= 0 {
+			return nil, errors.New("invalid relative range name")
+		}
+		p, err := parseRelativeDuration([]string{strconv.Itoa(n), key[len(key)-1:]})
+		if err != nil {
+			return nil, err
+		}
+		if p.Days == 0 {
+			return NewInterval(nil, &ref, &p.Time)
+		}
+		return NewPeriodInterval(nil, &ref, p)
+	case strings.HasPrefix(key, "previous"):
+		unit, ok := calendarUnits[key[len("previous"):]]
+		if !ok {
+			return nil, errors.New("invalid relative range name")
+		}
+		in := calendarPeriod(ref, unit, time.Monday, -1, loc)
+		return &in, nil
+	case strings.HasSuffix(key, "todate"):
+		unit, ok := calendarUnits[strings.TrimSuffix(key, "todate")]
+		if !ok {
+			return nil, errors.New("invalid relative range name")
+		}
+		startsAt := startOfCalendarUnit(ref, unit, time.Monday, loc)
+		return NewInterval(&startsAt, &ref, nil)
+	}
+	return nil, errors.New("invalid relative range name")
+}
+
+// startOfCalendarUnit returns the start of the calendar day, week (starting on weekStart), month or year
+// containing t.
+func startOfCalendarUnit(t time.Time, unit Period, weekStart time.Weekday, loc *time.Location) time.Time {
+	year, month, day := t.Date()
+	switch {
+	case unit.Years > 0:
+		month, day = time.January, 1
+	case unit.Months > 0:
+		day = 1
+	case unit.Days == 7:
+		day -= (int(t.Weekday()-weekStart) + 7) % 7
+	}
+	startsAt, _ := wallClock(year, month, day, 0, loc, DSTShift)
+	return startsAt
+}
@@ -0,0 +1,108 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRelative(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	assert.Nil(t, err)
+	// Wednesday
+	ref := time.Date(2019, 3, 27, 14, 30, 0, 0, loc)
+	expectations := map[string]string{
+		"next monday 09:00 for 2h":          "2019-04-01T09:00:00+02:00/PT2H",
+		"Next Monday at 9am for 2 hours":    "2019-04-01T09:00:00+02:00/PT2H",
+		"monday 09:00 for 30m":              "2019-04-01T09:00:00+02:00/PT30M",
+		"wednesday 09:00 for 30m":           "2019-03-27T09:00:00+01:00/PT30M",
+		"next wednesday 09:00 for 30m":      "2019-04-03T09:00:00+02:00/PT30M",
+		"last friday 9:30pm for 1h30m":      "2019-03-22T21:30:00+01:00/PT1H30M",
+		"tomorrow":                          "2019-03-28T00:00:00+01:00/P1D",
+		"yesterday for 2 days":              "2019-03-26T00:00:00+01:00/P2D",
+		"today at 22:00 until 23:30":        "2019-03-27T22:00:00+01:00/2019-03-27T23:30:00+01:00",
+		"today 22:00 until 02:00":           "2019-03-27T22:00:00+01:00/2019-03-28T02:00:00+01:00",
+		"now for 15 minutes":                "2019-03-27T14:30:00+01:00/PT15M",
+		"in 30m for 1 hour":                 "2019-03-27T15:00:00+01:00/PT1H",
+		"in 4 days for 1 day 2 hours":       "2019-03-31T14:30:00+02:00/P1DT2H",
+		"2019-01-02 12am for 1 week":        "2019-01-02T00:00:00+01:00/P7D",
+		"2019-01-02 12pm for 90 minutes":    "2019-01-02T12:00:00+01:00/PT1H30M",
+		"  saturday   at 02:00  for  1 h  ": "2019-03-30T02:00:00+01:00/PT1H",
+	}
+	for given, expected := range expectations {
+		in, err := ParseRelative(given, ref, loc)
+		if !assert.Nil(t, err, given) {
+			continue
+		}
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso, given)
+	}
+
+	// The DST gap on Sunday is shifted forward.
+	in, err := ParseRelative("sunday 02:30 for 1h", ref, loc)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 3, 31, 3, 30, 0, 0, loc), in.StartsAt)
+
+	for _, given := range []string{
+		"", "now", "today 09:00", "next", "next funday 09:00 for 1h", "someday for 1h", "today 25:00 for 1h",
+		"today 13pm for 1h", "now for", "now for 2 fortnights", "now for -1h", "now for 2", "today until",
+		"today until 9 10", "now foo for 1h", "today at 09:00 10:00 for 1h",
+	} {
+		_, err := ParseRelative(given, ref, loc)
+		assert.NotNil(t, err, given)
+	}
+	_, err = ParseRelative("now for 1h", ref, nil)
+	assert.NotNil(t, err)
+}
+
+func TestResolveRelative(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	assert.Nil(t, err)
+	// Wednesday
+	ref := time.Date(2019, 3, 27, 14, 30, 0, 0, loc)
+	expectations := map[string]string{
+		"last24h":        "P1D/2019-03-27T14:30:00+01:00",
+		"last 15m":       "PT15M/2019-03-27T14:30:00+01:00",
+		"Last_7d":        "P7D/2019-03-27T14:30:00+01:00",
+		"last2w":         "P14D/2019-03-27T14:30:00+01:00",
+		"previousDay":    "2019-03-26T00:00:00+01:00/P1D",
+		"previous week":  "2019-03-18T00:00:00+01:00/P7D",
+		"previous_month": "2019-02-01T00:00:00+01:00/P1M",
+		"previous-year":  "2018-01-01T00:00:00+01:00/P1Y",
+		"dayToDate":      "2019-03-27T00:00:00+01:00/2019-03-27T14:30:00+01:00",
+		"weekToDate":     "2019-03-25T00:00:00+01:00/2019-03-27T14:30:00+01:00",
+		"monthToDate":    "2019-03-01T00:00:00+01:00/2019-03-27T14:30:00+01:00",
+		"year to date":   "2019-01-01T00:00:00+01:00/2019-03-27T14:30:00+01:00",
+	}
+	for given, expected := range expectations {
+		in, err := ResolveRelative(given, ref, loc)
+		if !assert.Nil(t, err, given) {
+			continue
+		}
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso, given)
+	}
+
+	// The previous week across the DST change is 167 hours long.
+	in, err := ResolveRelative("previousWeek", time.Date(2019, 4, 2, 12, 0, 0, 0, loc), loc)
+	assert.Nil(t, err)
+	assert.Equal(t, 167*time.Hour, in.Duration())
+	// On Sundays the week started on Monday.
+	in, err = ResolveRelative("weekToDate", time.Date(2019, 3, 31, 12, 0, 0, 0, loc), loc)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 3, 25, 0, 0, 0, 0, loc), in.StartsAt)
+	// The reference is evaluated in the location.
+	in, err = ResolveRelative("dayToDate", time.Date(2019, 3, 27, 23, 30, 0, 0, time.UTC), loc)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 3, 28, 0, 0, 0, 0, loc), in.StartsAt)
+
+	for _, given := range []string{"", "last", "last0h", "lastxh", "last5y", "last5", "previousDecade", "quarterToDate", "tomorrow"} {
+		_, err := ResolveRelative(given, ref, loc)
+		assert.NotNil(t, err, given)
+	}
+	_, err = ResolveRelative("last24h", ref, nil)
+	assert.NotNil(t, err)
+}
@@ -2,16 +2,42 @@ package timeinterval
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
 
+type repeatFormat uint8
+
+// RepeatFormatOmitted means the repeating.ISO8601() output of unbounded repeating intervals will have the format R/Interval.
+const RepeatFormatOmitted repeatFormat = 0
+
+// RepeatFormatMinusOne means the repeating.ISO8601() output of unbounded repeating intervals will have the
+// ISO8601-2 format R-1/Interval.
+const RepeatFormatMinusOne repeatFormat = 1
+
+type occurrenceReference uint8
+
+// OccurrenceStart means the occurrences returned by Repeating.Next() refer to the start of each repetition.
+const OccurrenceStart occurrenceReference = 0
+
+// OccurrenceMiddle means the occurrences returned by Repeating.Next() refer to the midpoint of each repetition.
+const OccurrenceMiddle occurrenceReference = 1
+
+// OccurrenceEnd means the occurrences returned by Repeating.Next() refer to the end of each repetition.
+// This is useful when a repetition is a bucket that is reported when it is complete.
+const OccurrenceEnd occurrenceReference = 2
+
 // Repeating describes an interval with recurring events distributed evenly by the duration of the interval.
 // The number of Repetitions determine the bounds of the repeating interval (from StartsAt).
 // When Repetitions is unset, then the repeating interval will be unbounded and recur infinitely long into the future.
+// Format determines how unbounded repetitions are written by ISO8601().
+// Reference determines which point of each repetition Next() refers to. It is not part of the ISO8601 format.
 type Repeating struct {
 	Interval    Interval
 	Repetitions *uint32
+	Format      repeatFormat
+	Reference   occurrenceReference
 }
 
 // String returns a string that describes the repeating interval.
@@ -23,25 +49,71 @@ func (r Repeating) String() string {
 }
 
 // RepeatEvery returns duration of each repetition. This is identical to the duration of the interval.
+// When the interval has a calendar Period, the repetitions vary in length and this is the duration of the first one.
 func (r Repeating) RepeatEvery() time.Duration {
 	return r.Interval.Duration()
 }
 
+// validateRepeating returns an error if the interval cannot be repeated because it is open or, unless opts accept
+// it as a single occurrence, has zero length. All parsers and decoders of repeating intervals use it.
+func validateRepeating(in Interval, opts ParseOptions) error {
+	if in.OpenStart() || in.OpenEnd() {
+		return errors.New("repeating interval cannot be open")
+	}
+	if in.Duration() == 0 && !opts.ZeroLengthAsSingleOccurrence {
+		return ErrZeroLengthRepeating
+	}
+	return nil
+}
+
 // UnmarshalJSON unmarshal Repeating from an ISO8601 "repeating interval" string.
+// The JSON object representation of RepeatingObject is accepted as well.
 func (in *Repeating) UnmarshalJSON(data []byte) error {
+	if isJSONObject(data) {
+		i, reps, err := unmarshalJSONObject(data)
+		if err != nil {
+			return err
+		}
+		if err := validateRepeating(i, ParseOptions{}); err != nil {
+			return err
+		}
+		r := Repeating{Interval: in.Interval, Repetitions: reps, Reference: in.Reference}
+		r.Interval.assign(i)
+		*in = r
+		return nil
+	}
 	var s string
 	err := json.Unmarshal(data, &s)
 	if err != nil {
 		return err
 	}
-	ri, err := ParseRepeatingIntervalISO8601(s)
+	return in.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText unmarshal Repeating from an ISO8601 "repeating interval" string. See: encoding.TextUnmarshaler.
+func (in *Repeating) UnmarshalText(text []byte) error {
+	ri, err := ParseRepeatingIntervalISO8601(string(text))
 	if err != nil {
 		return err
 	}
+	ri.Reference = in.Reference
+	in.Interval.assign(ri.Interval)
+	ri.Interval = in.Interval
 	*in = *ri
 	return nil
 }
 
+// MarshalText marshals Repeating into an ISO8601 "repeating interval" string. See: encoding.TextMarshaler.
+// It allows repeating intervals as keys of JSON objects. Since Repetitions is held by pointer, decoded keys of bounded
+// repeating intervals do not equal the originals, so look them up by their ISO8601 string.
+func (in Repeating) MarshalText() ([]byte, error) {
+	iso, err := in.ISO8601()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(iso), nil
+}
+
 // MarshalJSON marshal Repeating into an ISO8601 "repeating interval" string.
 func (in Repeating) MarshalJSON() ([]byte, error) {
 	iso, err := in.ISO8601()
@@ -68,7 +140,7 @@ func (in Repeating) EndsAt() *time.Time {
 	if in.Repetitions == nil {
 		return nil
 	}
-	endsAt := in.Interval.StartsAt.Add(time.Duration(*in.Repetitions) * in.RepeatEvery())
+	endsAt := in.occurrence(int(*in.Repetitions))
 	return &endsAt
 }
 
@@ -110,30 +182,137 @@ func (in Repeating) In(t time.Time) bool {
 
 // Next returns the time of the next interval-occurrence relative to the given time.
 // It returns the startsAt time if the interval have not started yet and nil if the interval has ended.
+// When Reference is OccurrenceMiddle or OccurrenceEnd, the next midpoint or end of a repetition is returned instead.
+// A zero-length interval occurs once at its start, as all of its repetitions coincide.
 func (in Repeating) Next(t time.Time) *time.Time {
+	if in.RepeatEvery() == 0 {
+		if !t.Before(in.Interval.StartsAt) {
+			return nil
+		}
+		startsAt := in.Interval.StartsAt
+		return &startsAt
+	}
+	if in.Reference != OccurrenceStart {
+		return in.nextReference(t)
+	}
 	if !in.Started(t) {
 		return in.StartsAt()
 	}
-	if in.Ended(t) || in.RepeatEvery() == 0 {
+	if in.Ended(t) {
 		return nil
 	}
-	diff := t.Sub(in.Interval.StartsAt)
-	mod := diff % in.RepeatEvery()
-	nxt := t.Add(in.RepeatEvery() - mod)
+	var nxt time.Time
+	if in.Interval.Period != nil {
+		nxt = in.occurrence(in.occurrenceIndex(t) + 1)
+	} else {
+		mod := t.Sub(in.Interval.StartsAt) % in.RepeatEvery()
+		// The remainder is negative before the start of unbounded repetitions, which would move t backwards.
+		if mod < 0 {
+			mod += in.RepeatEvery()
+		}
+		nxt = t.Add(in.RepeatEvery() - mod)
+	}
 	if in.Ended(nxt) {
 		return nil
 	}
 	return &nxt
 }
 
+// previous returns the start of the latest repetition at or before t or nil if there is none. Like in Next, the
+// repetitions of unbounded repeating intervals extend before their first interval.
+func (in Repeating) previous(t time.Time) *time.Time {
+	if !in.Started(t) {
+		return nil
+	}
+	if in.RepeatEvery() == 0 {
+		if t.Before(in.Interval.StartsAt) {
+			return nil
+		}
+		startsAt := in.Interval.StartsAt
+		return &startsAt
+	}
+	k := in.occurrenceIndex(t)
+	if in.Repetitions != nil && k > int(*in.Repetitions) {
+		k = int(*in.Repetitions)
+	}
+	prev := in.occurrence(k)
+	return &prev
+}
+
+// nextReference returns the first midpoint or end (See: Reference) of a repetition after t or nil if there is none.
+func (in Repeating) nextReference(t time.Time) *time.Time {
+	k := 0
+	if in.Started(t) {
+		k = in.occurrenceIndex(t)
+		if !in.reference(k).After(t) {
+			k++
+		}
+	}
+	if in.Repetitions != nil && k >= int(*in.Repetitions) {
+		return nil
+	}
+	nxt := in.reference(k)
+	return &nxt
+}
+
+// reference returns the point of the k-th repetition determined by Reference.
+func (in Repeating) reference(k int) time.Time {
+	startsAt := in.occurrence(k)
+	switch in.Reference {
+	case OccurrenceMiddle:
+		return startsAt.Add(in.occurrence(k+1).Sub(startsAt) / 2)
+	case OccurrenceEnd:
+		return in.occurrence(k + 1)
+	}
+	return startsAt
+}
+
+// occurrence returns the start of the k-th repetition relative to the start of the interval.
+// The repetitions follow the calendar when the interval has a Period.
+func (in Repeating) occurrence(k int) time.Time {
+	if in.Interval.Period != nil {
+		return in.Interval.Period.Shift(in.Interval.StartsAt, k)
+	}
+	return in.Interval.StartsAt.Add(time.Duration(k) * in.RepeatEvery())
+}
+
+// occurrenceIndex returns the index of the last repetition starting at or before t.
+// The repeating interval must have a positive RepeatEvery().
+func (in Repeating) occurrenceIndex(t time.Time) int {
+	diff := t.Sub(in.Interval.StartsAt)
+	k := int(diff / in.RepeatEvery())
+	if in.Interval.Period == nil {
+		if diff < 0 && diff%in.RepeatEvery() != 0 {
+			k--
+		}
+		return k
+	}
+	// Calendar repetitions vary in length, so the estimate is corrected by stepping.
+	for in.occurrence(k).After(t) {
+		k--
+	}
+	for !in.occurrence(k + 1).After(t) {
+		k++
+	}
+	return k
+}
+
 // ISO8691 returns the repeating interval formatted as an ISO8601 repeating interval string.
 func (in Repeating) ISO8601() (string, error) {
 	iso, err := in.Interval.ISO8601()
 	if err != nil {
 		return "", err
 	}
+	return in.prefix() + iso, nil
+}
+
+// prefix returns the repetitions of the repeating interval formatted as the prefix of an ISO8601 string, e.g. "R5/".
+func (in Repeating) prefix() string {
 	if in.Repetitions != nil {
-		return fmt.Sprintf("R%d/%s", *in.Repetitions, iso), nil
+		return fmt.Sprintf("R%d/", *in.Repetitions)
+	}
+	if in.Format == RepeatFormatMinusOne {
+		return "R-1/"
 	}
-	return fmt.Sprintf("R/%s", iso), nil
+	return "R/"
 }
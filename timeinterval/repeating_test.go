@@ -80,6 +80,45 @@ func TestRepeating_NextUnbounded(t *testing.T) {
 	assert.Equal(t, endsAt.Add(duration), *in.Next(endsAt))
 }
 
+func TestRepeating_NextBeforeStart(t *testing.T) {
+	// Unbounded repetitions extend before the start of the interval. Next returns the first of them after t,
+	// also when t lies between them.
+	in := MustParseRepeatingIntervalISO8601("R/2019-01-02T21:00:00Z/PT1H")
+	startsAt := in.Interval.StartsAt
+	expectations := map[time.Duration]time.Duration{
+		-time.Nanosecond:  0,
+		-30 * time.Minute: 0,
+		-90 * time.Minute: -time.Hour,
+		-time.Hour:        0,
+	}
+	for given, expected := range expectations {
+		assert.Equal(t, startsAt.Add(expected), *in.Next(startsAt.Add(given)), given.String())
+	}
+}
+
+func TestRepeating_ZeroLength(t *testing.T) {
+	for _, given := range []string{"R5/2019-01-02T21:00:00Z/PT0S", "R/2019-01-02T21:00:00Z/2019-01-02T21:00:00Z", "R/P0D/2019-01-02T21:00:00Z"} {
+		_, err := ParseRepeatingIntervalISO8601(given)
+		if assert.NotNil(t, err, given) {
+			assert.Equal(t, ErrZeroLengthRepeating, err.(*ParseError).Err, given)
+		}
+	}
+
+	at := time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC)
+	opts := ParseOptions{ZeroLengthAsSingleOccurrence: true}
+	for _, given := range []string{"R5/2019-01-02T21:00:00Z/PT0S", "R/2019-01-02T21:00:00Z/PT0S"} {
+		r, err := ParseRepeatingIntervalISO8601WithOptions(given, opts)
+		assert.Nil(t, err, given)
+		assert.Equal(t, at, *r.Next(at.Add(-time.Hour)), given)
+		assert.Nil(t, r.Next(at), given)
+		assert.Nil(t, r.previous(at.Add(-time.Second)), given)
+		assert.Equal(t, at, *r.previous(at.Add(time.Hour)), given)
+		r.Reference = OccurrenceEnd
+		assert.Equal(t, at, *r.Next(at.Add(-time.Hour)), given)
+		assert.Nil(t, r.Next(at), given)
+	}
+}
+
 func TestRepeating_Started(t *testing.T) {
 	endsAt := time.Now().Add(-1 * time.Hour)
 
@@ -120,6 +159,7 @@ func TestRepeating_ISO8601(t *testing.T) {
 		"R/2019-01-02T21:00:00Z/P1W",
 		"R/P1W/2022-01-03T21:00:00Z",
 		"R10/P1W/2022-01-03T21:00:00Z",
+		"R-1/P1W/2022-01-03T21:00:00Z",
 	}
 	for _, expectation := range expectations {
 		in, err := ParseRepeatingIntervalISO8601(expectation)
@@ -170,3 +210,89 @@ func TestRepeating_UnmarshalJSON(t *testing.T) {
 		assert.Equal(t, expected, &result)
 	}
 }
+
+func TestRepeating_NextReference(t *testing.T) {
+	r := MustParseRepeatingIntervalISO8601("R3/2019-01-02T00:00:00Z/PT1H")
+	expectations := map[occurrenceReference]map[string]string{
+		OccurrenceStart: {
+			"2019-01-01T23:00:00Z": "2019-01-02T00:00:00Z",
+			"2019-01-02T00:10:00Z": "2019-01-02T01:00:00Z",
+		},
+		OccurrenceMiddle: {
+			"2019-01-01T23:00:00Z": "2019-01-02T00:30:00Z",
+			"2019-01-02T00:10:00Z": "2019-01-02T00:30:00Z",
+			"2019-01-02T00:30:00Z": "2019-01-02T01:30:00Z",
+			"2019-01-02T02:40:00Z": "",
+		},
+		OccurrenceEnd: {
+			"2019-01-01T23:00:00Z": "2019-01-02T01:00:00Z",
+			"2019-01-02T00:10:00Z": "2019-01-02T01:00:00Z",
+			"2019-01-02T01:00:00Z": "2019-01-02T02:00:00Z",
+			"2019-01-02T02:10:00Z": "2019-01-02T03:00:00Z",
+			"2019-01-02T03:00:00Z": "",
+		},
+	}
+	for reference, cases := range expectations {
+		r.Reference = reference
+		for given, expected := range cases {
+			nxt := r.Next(mustTime(t, given))
+			if expected == "" {
+				assert.Nil(t, nxt, given)
+				continue
+			}
+			assert.NotNil(t, nxt, given)
+			assert.Equal(t, mustTime(t, expected), *nxt, given)
+		}
+	}
+}
+
+func TestRepeating_NextReferencePeriod(t *testing.T) {
+	r := MustParseRepeatingIntervalISO8601("R/2019-01-01T00:00:00Z/P1M")
+	r.Reference = OccurrenceMiddle
+	nxt := r.Next(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2019, 2, 15, 0, 0, 0, 0, time.UTC), *nxt)
+	r.Reference = OccurrenceEnd
+	nxt = r.Next(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC), *nxt)
+}
+
+func TestRepeating_UnmarshalJSONKeepsReference(t *testing.T) {
+	r := Repeating{Reference: OccurrenceEnd}
+	err := json.Unmarshal([]byte(`"R3/2019-01-02T00:00:00Z/PT1H"`), &r)
+	assert.Nil(t, err)
+	assert.Equal(t, OccurrenceEnd, r.Reference)
+}
+
+func TestRepeating_MarshalText(t *testing.T) {
+	r := MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT15M")
+	text, err := r.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "R5/2019-01-02T21:00:00Z/PT15M", string(text))
+
+	result := Repeating{Reference: OccurrenceEnd}
+	assert.Nil(t, result.UnmarshalText(text))
+	assert.Equal(t, OccurrenceEnd, result.Reference)
+	assert.Equal(t, r.Interval, result.Interval)
+	assert.NotNil(t, result.UnmarshalText([]byte("5/P1D")))
+}
+
+func TestRepeating_MapKey(t *testing.T) {
+	jobs := map[Repeating]string{
+		*MustParseRepeatingIntervalISO8601("R/2019-01-02T21:00:00Z/P1D"):   "backup",
+		*MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H"): "report",
+	}
+	b, err := json.Marshal(jobs)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"R/2019-01-02T21:00:00Z/P1D":"backup","R5/2019-01-02T21:00:00Z/PT1H":"report"}`, string(b))
+
+	var result map[Repeating]string
+	assert.Nil(t, json.Unmarshal(b, &result))
+	assert.Equal(t, "backup", result[*MustParseRepeatingIntervalISO8601("R/2019-01-02T21:00:00Z/P1D")])
+	byISO := map[string]string{}
+	for r, job := range result {
+		iso, err := r.ISO8601()
+		assert.Nil(t, err)
+		byISO[iso] = job
+	}
+	assert.Equal(t, map[string]string{"R/2019-01-02T21:00:00Z/P1D": "backup", "R5/2019-01-02T21:00:00Z/PT1H": "report"}, byISO)
+}
@@ -0,0 +1,343 @@
+package timeinterval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ErrScheduleNotFound is returned by a ScheduleRepository when no schedule with the given name exists.
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// ScheduleRepository persists named repeating intervals, so that long-lived schedules survive restarts.
+// Implementations backed by e.g. SQL or Redis can be used in place of MemoryRepository and FileRepository.
+type ScheduleRepository interface {
+	// Save stores the schedule under the given name, replacing any schedule with the same name.
+	Save(ctx context.Context, name string, r Repeating) error
+	// Load returns the schedule with the given name or ErrScheduleNotFound.
+	Load(ctx context.Context, name string) (*Repeating, error)
+	// List returns the names of all schedules in ascending order.
+	List(ctx context.Context) ([]string, error)
+	// Delete removes the schedule with the given name or returns ErrScheduleNotFound.
+	Delete(ctx context.Context, name string) error
+}
+
+// PausableRepository is a ScheduleRepository that can pause schedules, e.g. during maintenance. Paused schedules
+// keep their occurrences, but should not be run. See: ScheduleStatus
+type PausableRepository interface {
+	ScheduleRepository
+	// SetPaused pauses or resumes the schedule with the given name or returns ErrScheduleNotFound.
+	SetPaused(ctx context.Context, name string, paused bool) error
+	// Paused returns a boolean indicating if the schedule with the given name is paused or ErrScheduleNotFound.
+	Paused(ctx context.Context, name string) (bool, error)
+}
+
+// MemoryRepository is a PausableRepository keeping the schedules in memory. It is safe for concurrent use.
+type MemoryRepository struct {
+	mu        sync.RWMutex
+	schedules map[string]Repeating
+	paused    map[string]bool
+}
+
+// NewMemoryRepository returns an empty MemoryRepository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{schedules: map[string]Repeating{}, paused: map[string]bool{}}
+}
+
+// Save stores the schedule under the given name.
+func (m *MemoryRepository) Save(ctx context.Context, name string, r Repeating) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schedules[name] = copyRepeating(r)
+	return nil
+}
+
+// Load returns the schedule with the given name.
+func (m *MemoryRepository) Load(ctx context.Context, name string) (*Repeating, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	r, ok := m.schedules[name]
+	if !ok {
+		return nil, ErrScheduleNotFound
+	}
+	r = copyRepeating(r)
+	return &r, nil
+}
+
+// List returns the names of all schedules.
+func (m *MemoryRepository) List(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return sortedNames(m.schedules), nil
+}
+
+// Delete removes the schedule with the given name.
+func (m *MemoryRepository) Delete(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.schedules[name]; !ok {
+		return ErrScheduleNotFound
+	}
+	delete(m.schedules, name)
+	delete(m.paused, name)
+	return nil
+}
+
+// SetPaused pauses or resumes the schedule with the given name. Saving the schedule again keeps it paused.
+func (m *MemoryRepository) SetPaused(ctx context.Context, name string, paused bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.schedules[name]; !ok {
+		return ErrScheduleNotFound
+	}
+	if paused {
+		m.paused[name] = true
+	} else {
+		delete(m.paused, name)
+	}
+	return nil
+}
+
+// Paused returns a boolean indicating if the schedule with the given name is paused.
+func (m *MemoryRepository) Paused(ctx context.Context, name string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if _, ok := m.schedules[name]; !ok {
+		return false, ErrScheduleNotFound
+	}
+	return m.paused[name], nil
+}
+
+// ReloadFrom replaces all schedules with the schedules in r, a JSON object mapping names to ISO8601 repeating interval
+// strings. All entries are validated first and the schedules are only replaced if every entry is valid. Otherwise the
+// schedules are kept and EntryErrors describing the invalid entries are returned.
+func (m *MemoryRepository) ReloadFrom(r io.Reader) error {
+	return m.ReloadWith(json.NewDecoder(r).Decode)
+}
+
+// ReloadWith is like ReloadFrom but reads the configuration with the given decode function, which must decode a
+// mapping of names to ISO8601 repeating interval strings into its argument (a *map[string]string). It supports
+// other formats without depending on them, e.g. YAML with ReloadWith(yaml.NewDecoder(r).Decode).
+func (m *MemoryRepository) ReloadWith(decode func(interface{}) error) error {
+	var config map[string]string
+	if err := decode(&config); err != nil {
+		return err
+	}
+	schedules := make(map[string]Repeating, len(config))
+	var errs EntryErrors
+	for _, name := range sortedKeys(config) {
+		ri, err := ParseRepeatingIntervalISO8601(config[name])
+		if err != nil {
+			errs = append(errs, EntryError{Name: name, Input: config[name], Err: err})
+			continue
+		}
+		schedules[name] = *ri
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	m.mu.Lock()
+	m.schedules = schedules
+	// Schedules that are still configured stay paused.
+	for name := range m.paused {
+		if _, ok := schedules[name]; !ok {
+			delete(m.paused, name)
+		}
+	}
+	m.mu.Unlock()
+	return nil
+}
+
+// EntryError describes an invalid entry of a schedule configuration. See: MemoryRepository.ReloadFrom.
+type EntryError struct {
+	// Name is the name of the schedule.
+	Name string
+	// Input is the schedule that failed to parse.
+	Input string
+	// Err is the parse error.
+	Err error
+}
+
+// Error returns the parse error prefixed with the name of the schedule.
+func (e EntryError) Error() string {
+	return fmt.Sprintf("schedule %q: %v", e.Name, e.Err)
+}
+
+// EntryErrors aggregates the errors of invalid entries of a schedule configuration, ordered by name.
+type EntryErrors []EntryError
+
+// Error returns the number of invalid entries and the first error.
+func (e EntryErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%d schedules are invalid, first %v", len(e), e[0])
+}
+
+// FileRepository is a ScheduleRepository keeping the schedules in a JSON file mapping names to ISO8601 repeating
+// interval strings. The file is replaced atomically on every change. It is safe for concurrent use within a process.
+// Fields that are not part of the ISO8601 format (e.g. Reference and Meta) are not persisted.
+type FileRepository struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileRepository returns a FileRepository storing the schedules in the file at the given path.
+// The file is created on the first Save.
+func NewFileRepository(path string) *FileRepository {
+	return &FileRepository{path: path}
+}
+
+// Save stores the schedule under the given name.
+func (f *FileRepository) Save(ctx context.Context, name string, r Repeating) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	schedules, err := f.read()
+	if err != nil {
+		return err
+	}
+	schedules[name] = r
+	return f.write(schedules)
+}
+
+// Load returns the schedule with the given name.
+func (f *FileRepository) Load(ctx context.Context, name string) (*Repeating, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	schedules, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	r, ok := schedules[name]
+	if !ok {
+		return nil, ErrScheduleNotFound
+	}
+	return &r, nil
+}
+
+// List returns the names of all schedules.
+func (f *FileRepository) List(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	schedules, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	return sortedNames(schedules), nil
+}
+
+// Delete removes the schedule with the given name.
+func (f *FileRepository) Delete(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	schedules, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := schedules[name]; !ok {
+		return ErrScheduleNotFound
+	}
+	delete(schedules, name)
+	return f.write(schedules)
+}
+
+// read returns the schedules in the file. A missing file holds no schedules.
+func (f *FileRepository) read() (map[string]Repeating, error) {
+	schedules := map[string]Repeating{}
+	data, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return schedules, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// write replaces the file with the given schedules by writing a temporary file and renaming it.
+func (f *FileRepository) write(schedules map[string]Repeating) error {
+	data, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(f.path), filepath.Base(f.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func copyRepeating(r Repeating) Repeating {
+	if r.Repetitions != nil {
+		reps := *r.Repetitions
+		r.Repetitions = &reps
+	}
+	return r
+}
+
+func sortedNames(schedules map[string]Repeating) []string {
+	names := make([]string, 0, len(schedules))
+	for name := range schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,167 @@
+package timeinterval
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testScheduleRepository(t *testing.T, repo ScheduleRepository) {
+	ctx := context.Background()
+	daily := MustParseRepeatingIntervalISO8601("R/2019-01-02T21:00:00Z/P1D")
+	hourly := MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H")
+
+	names, err := repo.List(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+
+	assert.Nil(t, repo.Save(ctx, "hourly", *hourly))
+	assert.Nil(t, repo.Save(ctx, "daily", *daily))
+	names, err = repo.List(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"daily", "hourly"}, names)
+
+	r, err := repo.Load(ctx, "hourly")
+	assert.Nil(t, err)
+	assert.Equal(t, hourly, r)
+
+	_, err = repo.Load(ctx, "weekly")
+	assert.Equal(t, ErrScheduleNotFound, err)
+
+	assert.Nil(t, repo.Delete(ctx, "hourly"))
+	assert.Equal(t, ErrScheduleNotFound, repo.Delete(ctx, "hourly"))
+	names, err = repo.List(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"daily"}, names)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Equal(t, context.Canceled, repo.Save(cancelled, "daily", *daily))
+	_, err = repo.Load(cancelled, "daily")
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestMemoryRepository(t *testing.T) {
+	testScheduleRepository(t, NewMemoryRepository())
+}
+
+func TestMemoryRepository_Paused(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	var _ PausableRepository = repo
+	assert.Nil(t, repo.Save(ctx, "hourly", *MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H")))
+	paused, err := repo.Paused(ctx, "hourly")
+	assert.Nil(t, err)
+	assert.False(t, paused)
+
+	assert.Nil(t, repo.SetPaused(ctx, "hourly", true))
+	paused, err = repo.Paused(ctx, "hourly")
+	assert.Nil(t, err)
+	assert.True(t, paused)
+	assert.Nil(t, repo.SetPaused(ctx, "hourly", false))
+	paused, err = repo.Paused(ctx, "hourly")
+	assert.Nil(t, err)
+	assert.False(t, paused)
+
+	assert.Equal(t, ErrScheduleNotFound, repo.SetPaused(ctx, "weekly", true))
+	_, err = repo.Paused(ctx, "weekly")
+	assert.Equal(t, ErrScheduleNotFound, err)
+
+	// Deleted schedules are no longer paused when saved again.
+	assert.Nil(t, repo.SetPaused(ctx, "hourly", true))
+	assert.Nil(t, repo.Delete(ctx, "hourly"))
+	assert.Nil(t, repo.Save(ctx, "hourly", *MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H")))
+	paused, err = repo.Paused(ctx, "hourly")
+	assert.Nil(t, err)
+	assert.False(t, paused)
+}
+
+func TestMemoryRepository_Copies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	r := MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H")
+	assert.Nil(t, repo.Save(ctx, "hourly", *r))
+	*r.Repetitions = 7
+	loaded, err := repo.Load(ctx, "hourly")
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(5), *loaded.Repetitions)
+}
+
+func TestFileRepository(t *testing.T) {
+	dir, err := ioutil.TempDir("", "timeinterval")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "schedules.json")
+	testScheduleRepository(t, NewFileRepository(path))
+
+	// The schedules are persisted.
+	r, err := NewFileRepository(path).Load(context.Background(), "daily")
+	assert.Nil(t, err)
+	iso, err := r.ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "R/2019-01-02T21:00:00Z/P1D", iso)
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = NewFileRepository(path).List(context.Background())
+	assert.NotNil(t, err)
+}
+
+func TestMemoryRepository_ReloadFrom(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	assert.Nil(t, repo.Save(ctx, "old", *MustParseRepeatingIntervalISO8601("R/2019-01-02T21:00:00Z/P1D")))
+
+	err := repo.ReloadFrom(strings.NewReader(`{"hourly": "R5/2019-01-02T21:00:00Z/PT1H", "daily": "R/2019-01-02T21:00:00Z/P1D"}`))
+	assert.Nil(t, err)
+	names, err := repo.List(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"daily", "hourly"}, names)
+
+	// Invalid configurations leave the schedules untouched.
+	err = repo.ReloadFrom(strings.NewReader(`{"weekly": "R/2019-01-02T21:00:00Z/P1W", "broken": "R/P1D", "bad": "R/x/P1D"}`))
+	errs, ok := err.(EntryErrors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "bad", errs[0].Name)
+	assert.Equal(t, "broken", errs[1].Name)
+	assert.EqualError(t, err, `2 schedules are invalid, first schedule "bad": invalid/unknown format`)
+	names, err = repo.List(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"daily", "hourly"}, names)
+
+	assert.NotNil(t, repo.ReloadFrom(strings.NewReader(`["R/2019-01-02T21:00:00Z/P1D"]`)))
+}
+
+func TestMemoryRepository_ReloadWith(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	// A decoder of "name: schedule" lines stands in for a YAML decoder.
+	lines := func(s string) func(interface{}) error {
+		return func(v interface{}) error {
+			config := map[string]string{}
+			for _, line := range strings.Split(s, "\n") {
+				kv := strings.SplitN(line, ": ", 2)
+				if len(kv) != 2 {
+					return errors.New("invalid line")
+				}
+				config[kv[0]] = kv[1]
+			}
+			*v.(*map[string]string) = config
+			return nil
+		}
+	}
+	assert.Nil(t, repo.ReloadWith(lines("hourly: R5/2019-01-02T21:00:00Z/PT1H\ndaily: R/2019-01-02T21:00:00Z/P1D")))
+	names, err := repo.List(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"daily", "hourly"}, names)
+
+	assert.NotNil(t, repo.ReloadWith(lines("broken")))
+	_, ok := repo.ReloadWith(lines("broken: R/P1D")).(EntryErrors)
+	assert.True(t, ok)
+}
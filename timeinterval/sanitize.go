@@ -0,0 +1,72 @@
+package timeinterval
+
+import "time"
+
+type adjustmentKind uint8
+
+// AdjustmentSwapped means the start and end were swapped because the interval ended before it started.
+const AdjustmentSwapped adjustmentKind = 0
+
+// AdjustmentStartClamped means the start was moved to the start of the interval it was clamped to.
+const AdjustmentStartClamped adjustmentKind = 1
+
+// AdjustmentEndClamped means the end was moved to the end of the interval it was clamped to.
+const AdjustmentEndClamped adjustmentKind = 2
+
+// AdjustmentShortened means the end was moved closer to the start to respect the maximum duration.
+const AdjustmentShortened adjustmentKind = 3
+
+// AdjustmentStartShortened means the open start was moved closer to the end to respect the maximum duration.
+const AdjustmentStartShortened adjustmentKind = 4
+
+// Adjustment describes a modification of an interval made by SanitizeForQuery.
+type Adjustment struct {
+	Kind adjustmentKind
+	// From and To are the bound before and after the adjustment. For AdjustmentSwapped they are the start.
+	From time.Time
+	To   time.Time
+}
+
+// SanitizeForQuery bounds a user-supplied interval for use in a query (e.g. of an API endpoint) and returns the
+// adjustments it made, so they can be reported back for transparency. It swaps the bounds of an interval ending
+// before it starts, clamps the interval to clampTo (use open bounds to leave a side unclamped) and shortens it to
+// the max duration (if positive) by moving its end, or its start if the start is still open. An interval outside
+// clampTo is clamped to zero length at the nearest bound of clampTo. Adjusted intervals lose their calendar Period
+// and are formatted as Time/Time unless a bound remains open.
+func SanitizeForQuery(in Interval, max time.Duration, clampTo Interval) (Interval, []Adjustment) {
+	var adjustments []Adjustment
+	startsAt, endsAt := in.StartsAt, in.EndsAt
+	if endsAt.Before(startsAt) {
+		adjustments = append(adjustments, Adjustment{Kind: AdjustmentSwapped, From: startsAt, To: endsAt})
+		startsAt, endsAt = endsAt, startsAt
+	}
+	if startsAt.Before(clampTo.StartsAt) {
+		adjustments = append(adjustments, Adjustment{Kind: AdjustmentStartClamped, From: startsAt, To: clampTo.StartsAt})
+		startsAt = clampTo.StartsAt
+	}
+	if startsAt.After(clampTo.EndsAt) {
+		adjustments = append(adjustments, Adjustment{Kind: AdjustmentStartClamped, From: startsAt, To: clampTo.EndsAt})
+		startsAt = clampTo.EndsAt
+	}
+	if endsAt.After(clampTo.EndsAt) {
+		adjustments = append(adjustments, Adjustment{Kind: AdjustmentEndClamped, From: endsAt, To: clampTo.EndsAt})
+		endsAt = clampTo.EndsAt
+	}
+	if endsAt.Before(startsAt) {
+		adjustments = append(adjustments, Adjustment{Kind: AdjustmentEndClamped, From: endsAt, To: startsAt})
+		endsAt = startsAt
+	}
+	if max > 0 && endsAt.Sub(startsAt) > max {
+		if startsAt.Equal(openStart) {
+			adjustments = append(adjustments, Adjustment{Kind: AdjustmentStartShortened, From: startsAt, To: endsAt.Add(-max)})
+			startsAt = endsAt.Add(-max)
+		} else {
+			adjustments = append(adjustments, Adjustment{Kind: AdjustmentShortened, From: endsAt, To: startsAt.Add(max)})
+			endsAt = startsAt.Add(max)
+		}
+	}
+	if len(adjustments) == 0 {
+		return in, nil
+	}
+	return Interval{Format: boundsFormat(startsAt, endsAt), StartsAt: startsAt, EndsAt: endsAt, Meta: in.Meta, Layout: in.Layout}, adjustments
+}
@@ -0,0 +1,62 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeForQuery(t *testing.T) {
+	clampTo := *MustParseIntervalISO8601("2019-01-01T00:00:00Z/2019-02-01T00:00:00Z")
+
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1D")
+	result, adjustments := SanitizeForQuery(*in, 7*durationDay, clampTo)
+	assert.Equal(t, *in, result)
+	assert.Empty(t, adjustments)
+
+	result, adjustments = SanitizeForQuery(*MustParseIntervalISO8601("2018-12-31T00:00:00Z/P1M"), 7*durationDay, clampTo)
+	assert.Equal(t, "2019-01-01T00:00:00Z/2019-01-08T00:00:00Z", mustISO8601(t, result))
+	assert.Equal(t, []Adjustment{
+		{Kind: AdjustmentStartClamped, From: mustTime(t, "2018-12-31T00:00:00Z"), To: mustTime(t, "2019-01-01T00:00:00Z")},
+		{Kind: AdjustmentShortened, From: mustTime(t, "2019-01-31T00:00:00Z"), To: mustTime(t, "2019-01-08T00:00:00Z")},
+	}, adjustments)
+
+	reversed := Interval{Format: ISOFormatTimeAndTime, StartsAt: mustTime(t, "2019-01-20T00:00:00Z"), EndsAt: mustTime(t, "2019-01-10T00:00:00Z")}
+	result, adjustments = SanitizeForQuery(reversed, 0, clampTo)
+	assert.Equal(t, "2019-01-10T00:00:00Z/2019-01-20T00:00:00Z", mustISO8601(t, result))
+	assert.Equal(t, []Adjustment{{Kind: AdjustmentSwapped, From: reversed.StartsAt, To: reversed.EndsAt}}, adjustments)
+
+	result, adjustments = SanitizeForQuery(*MustParseIntervalISO8601("2019-01-20T00:00:00Z/.."), 0, clampTo)
+	assert.Equal(t, "2019-01-20T00:00:00Z/2019-02-01T00:00:00Z", mustISO8601(t, result))
+	assert.Equal(t, AdjustmentEndClamped, adjustments[0].Kind)
+
+	result, adjustments = SanitizeForQuery(*MustParseIntervalISO8601("2019-03-01T00:00:00Z/P1D"), 0, clampTo)
+	assert.Equal(t, "2019-02-01T00:00:00Z/2019-02-01T00:00:00Z", mustISO8601(t, result))
+	assert.Len(t, adjustments, 2)
+
+	result, adjustments = SanitizeForQuery(*MustParseIntervalISO8601("2018-03-01T00:00:00Z/P1D"), 0, clampTo)
+	assert.Equal(t, "2019-01-01T00:00:00Z/2019-01-01T00:00:00Z", mustISO8601(t, result))
+	assert.Len(t, adjustments, 2)
+
+	// Open bounds leave the sides unclamped.
+	result, adjustments = SanitizeForQuery(*MustParseIntervalISO8601("../2019-01-20T00:00:00Z"), time.Hour, *NewOpenEndInterval(mustTime(t, "2000-01-01T00:00:00Z")))
+	assert.Equal(t, "2000-01-01T00:00:00Z/2000-01-01T01:00:00Z", mustISO8601(t, result))
+	assert.Len(t, adjustments, 2)
+
+	// Without a clamped start, an open start is moved back from the end.
+	open := *NewOpenStartInterval(mustTime(t, "2019-01-01T00:00:00Z"))
+	result, adjustments = SanitizeForQuery(open, 24*time.Hour, *NewOpenStartInterval(mustTime(t, "2100-01-01T00:00:00Z")))
+	assert.Equal(t, "2018-12-31T00:00:00Z/2019-01-01T00:00:00Z", mustISO8601(t, result))
+	assert.Equal(t, []Adjustment{{Kind: AdjustmentStartShortened, From: openStart, To: mustTime(t, "2018-12-31T00:00:00Z")}}, adjustments)
+	// Without a max duration, an open bound that is not clamped stays open.
+	result, adjustments = SanitizeForQuery(*MustParseIntervalISO8601("../2019-03-01T00:00:00Z"), 0, *NewOpenStartInterval(mustTime(t, "2019-02-01T00:00:00Z")))
+	assert.Equal(t, "../2019-02-01T00:00:00Z", mustISO8601(t, result))
+	assert.Len(t, adjustments, 1)
+}
+
+func mustISO8601(t *testing.T, in Interval) string {
+	iso, err := in.ISO8601()
+	assert.Nil(t, err)
+	return iso
+}
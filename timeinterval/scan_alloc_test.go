@@ -0,0 +1,20 @@
+//go:build !race
+// +build !race
+
+package timeinterval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The race detector instruments allocations, so the allocation count is only tested without it.
+
+func TestParseIntervalISO8601_Allocations(t *testing.T) {
+	// Only the returned interval is allocated. Other Go versions may allocate less, but not more.
+	allocs := testing.AllocsPerRun(100, func() {
+		ParseIntervalISO8601("2019-01-02T21:00:00Z/P1DT2H30M")
+	})
+	assert.True(t, allocs <= 1, "%v allocations", allocs)
+}
@@ -0,0 +1,54 @@
+package timeinterval
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// regexTimeStringISO is the regular expression isTimeStringISO replaces.
+var regexTimeStringISO = regexp.MustCompile("^(-?(?:[1-9][0-9]*)?[0-9]{4})-(1[0-2]|0[1-9])-(3[01]|0[1-9]|[12][0-9])T(2[0-3]|[01][0-9]):([0-5][0-9])(?::([0-5][0-9])(\\.[0-9]+)?)?(Z|[+-](?:2[0-3]|[01][0-9]):[0-5][0-9])?$")
+
+func TestIsTimeStringISO(t *testing.T) {
+	inputs := []string{
+		"2019-01-02T21:00:00Z",
+		"2019-01-02T21:00Z",
+		"2019-01-02T21:00",
+		"2019-01-02T21:00:00",
+		"2019-01-02T21:00:00.123456789+01:00",
+		"2019-12-31T23:59:59-23:59",
+		"-2019-01-02T21:00:00Z",
+		"12019-01-02T21:00:00Z",
+		"02019-01-02T21:00:00Z",
+		"0019-01-02T21:00:00Z",
+		"219-01-02T21:00:00Z",
+		"2019-13-02T21:00:00Z",
+		"2019-00-02T21:00:00Z",
+		"2019-01-32T21:00:00Z",
+		"2019-01-00T21:00:00Z",
+		"2019-01-02T24:00:00Z",
+		"2019-01-02T21:60:00Z",
+		"2019-01-02T21:00:60Z",
+		"2019-01-02T21:00:00.Z",
+		"2019-01-02T21:00:00+24:00",
+		"2019-01-02T21:00:00+01:60",
+		"2019-01-02T21:00:00+0100",
+		"2019-01-02T21:00:00ZZ",
+		"2019-01-02 21:00:00Z",
+		"2019-01-02T21",
+		"2019-01-02",
+		"2019-W01-3T21:00:00Z",
+		"P1D",
+		"",
+		"-",
+	}
+	for _, given := range inputs {
+		assert.Equal(t, regexTimeStringISO.MatchString(given), isTimeStringISO(given), given)
+	}
+}
+
+func TestParseRepeatingIntervalISO8601_MissingInterval(t *testing.T) {
+	_, err := ParseRepeatingIntervalISO8601("R5")
+	assert.EqualError(t, err, "invalid repeating interval format")
+}
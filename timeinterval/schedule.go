@@ -0,0 +1,284 @@
+package timeinterval
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Schedule is implemented by types producing occurrences over time, such as Repeating and AdaptiveSchedule.
+type Schedule interface {
+	// Next returns the time of the next occurrence relative to the given time or nil if there are no more occurrences.
+	Next(t time.Time) *time.Time
+}
+
+// completer is implemented by schedules that adapt to when executions complete (See: AdaptiveSchedule).
+type completer interface {
+	Complete(t time.Time)
+}
+
+// clock abstracts the passing of time for runners, so that tests can control it.
+type clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) clockTimer
+}
+
+// clockTimer is a timer created by a clock. See: time.Timer.
+type clockTimer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// systemClock is the clock of the system.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) clockTimer {
+	return systemTimer{timer: time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+type overrunPolicy uint8
+
+// OverrunLetFinish lets an invocation continue past its deadline. Occurrences while it runs are skipped as usual.
+const OverrunLetFinish overrunPolicy = 0
+
+// OverrunCancel cancels the context of an invocation once its deadline has passed. Only with this policy the
+// deadline is also reported by the Deadline method of the context.
+const OverrunCancel overrunPolicy = 1
+
+// OverrunSkipNext lets an invocation continue past its deadline and additionally skips the first occurrence after
+// it returned, giving an overloaded system a break.
+const OverrunSkipNext overrunPolicy = 2
+
+// RunOptions configures the invocations of RunSerializedWithOptions.
+type RunOptions struct {
+	// MaxDuration is the time an invocation may take from its occurrence. If zero, an invocation may take until the
+	// next occurrence and the invocation of the last occurrence has no deadline.
+	MaxDuration time.Duration
+	// Overrun determines what happens when an invocation exceeds its deadline.
+	Overrun overrunPolicy
+}
+
+// SerializedRunner reports the progress of RunSerialized.
+type SerializedRunner struct {
+	// runs, skips and overruns are accessed atomically and must stay 64-bit aligned.
+	runs     uint64
+	skips    uint64
+	overruns uint64
+	done     chan struct{}
+	clock    clock
+	opts     RunOptions
+}
+
+// occurrenceDeadlineKey is the context key of the deadline of an invocation. See: OccurrenceDeadline
+type occurrenceDeadlineKey struct{}
+
+// OccurrenceDeadline returns the deadline of the invocation of a SerializedRunner with the given context, or false
+// if it has none. Unlike the Deadline method of the context, it is reported regardless of the overrun policy.
+func OccurrenceDeadline(ctx context.Context) (time.Time, bool) {
+	deadline, ok := ctx.Value(occurrenceDeadlineKey{}).(time.Time)
+	return deadline, ok
+}
+
+// deadlineContext is the context of an invocation with OverrunCancel. Its parent is canceled at the deadline, after
+// expired is set (atomically).
+type deadlineContext struct {
+	context.Context
+	deadline time.Time
+	expired  int32
+}
+
+func (c *deadlineContext) Deadline() (time.Time, bool) {
+	if parent, ok := c.Context.Deadline(); ok && parent.Before(c.deadline) {
+		return parent, true
+	}
+	return c.deadline, true
+}
+
+func (c *deadlineContext) Err() error {
+	if atomic.LoadInt32(&c.expired) == 1 {
+		return context.DeadlineExceeded
+	}
+	return c.Context.Err()
+}
+
+// Runs returns the number of occurrences fn was invoked for.
+func (r *SerializedRunner) Runs() uint64 {
+	return atomic.LoadUint64(&r.runs)
+}
+
+// Skips returns the number of occurrences that were skipped because the previous invocation was still running
+// (or overran its deadline with OverrunSkipNext).
+func (r *SerializedRunner) Skips() uint64 {
+	return atomic.LoadUint64(&r.skips)
+}
+
+// Overruns returns the number of invocations that returned at or after their deadline.
+func (r *SerializedRunner) Overruns() uint64 {
+	return atomic.LoadUint64(&r.overruns)
+}
+
+// Done returns a channel that is closed once the context is canceled or the schedule has no more occurrences,
+// and the last invocation of fn has returned.
+func (r *SerializedRunner) Done() <-chan struct{} {
+	return r.done
+}
+
+// RunSerialized invokes fn in a separate goroutine at every occurrence of the schedule, starting from now.
+// At most one invocation is active at a time: an occurrence is skipped (and counted, See: SerializedRunner#Skips)
+// if the previous invocation is still running. fn receives the time of the occurrence and a context derived from the
+// given one. The deadline of an invocation is the next occurrence (See: OccurrenceDeadline), but invocations are not
+// canceled at it.
+//
+// If the schedule implements Complete(time.Time), like AdaptiveSchedule, it is called whenever an invocation returns.
+func RunSerialized(ctx context.Context, s Schedule, fn func(ctx context.Context, t time.Time)) *SerializedRunner {
+	return RunSerializedWithOptions(ctx, s, fn, RunOptions{})
+}
+
+// RunSerializedWithOptions is like RunSerialized but determines the deadline of each invocation and what happens
+// when it is exceeded by the given options.
+//
+// Schedules implementing Complete(time.Time) only know their next occurrence once an invocation returned, so their
+// invocations only have a deadline with RunOptions#MaxDuration.
+func RunSerializedWithOptions(ctx context.Context, s Schedule, fn func(ctx context.Context, t time.Time), opts RunOptions) *SerializedRunner {
+	return runSerialized(ctx, s, fn, opts, systemClock{})
+}
+
+func runSerialized(ctx context.Context, s Schedule, fn func(ctx context.Context, t time.Time), opts RunOptions, c clock) *SerializedRunner {
+	r := &SerializedRunner{done: make(chan struct{}), clock: c, opts: opts}
+	go r.run(ctx, s, fn)
+	return r
+}
+
+func (r *SerializedRunner) run(ctx context.Context, s Schedule, fn func(ctx context.Context, t time.Time)) {
+	defer close(r.done)
+	// finished receives whether the returned invocation overran its deadline.
+	finished := make(chan bool, 1)
+	running, skipNext := false, false
+	complete := func(overran bool) {
+		running = false
+		skipNext = overran && r.opts.Overrun == OverrunSkipNext
+	}
+	defer func() {
+		if running {
+			<-finished
+		}
+	}()
+	last := r.clock.Now()
+	for {
+		nxt := s.Next(last)
+		if nxt == nil {
+			return
+		}
+		// A schedule may report an overdue occurrence (at or before the previous one) until the running
+		// invocation completes, so wait for it instead of skipping the same occurrence repeatedly.
+		if running && !nxt.After(last) {
+			select {
+			case <-ctx.Done():
+				return
+			case overran := <-finished:
+				complete(overran)
+			}
+			continue
+		}
+		timer := r.clock.NewTimer(nxt.Sub(r.clock.Now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case overran := <-finished:
+			timer.Stop()
+			complete(overran)
+			continue
+		case <-timer.C():
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if nxt.After(last) {
+			last = *nxt
+		}
+		if running || skipNext {
+			skipNext = false
+			atomic.AddUint64(&r.skips, 1)
+			continue
+		}
+		atomic.AddUint64(&r.runs, 1)
+		running = true
+		r.invoke(ctx, s, fn, *nxt, finished)
+	}
+}
+
+// invoke calls fn for the occurrence t in a separate goroutine and reports on finished whether it overran its
+// deadline.
+func (r *SerializedRunner) invoke(ctx context.Context, s Schedule, fn func(ctx context.Context, t time.Time), t time.Time, finished chan<- bool) {
+	var deadline *time.Time
+	if r.opts.MaxDuration > 0 {
+		d := t.Add(r.opts.MaxDuration)
+		deadline = &d
+	} else if _, ok := s.(completer); !ok {
+		deadline = s.Next(t)
+	}
+	if deadline == nil {
+		go func() {
+			fn(ctx, t)
+			r.finish(s, false, finished)
+		}()
+		return
+	}
+
+	ctx = context.WithValue(ctx, occurrenceDeadlineKey{}, *deadline)
+	if r.opts.Overrun != OverrunCancel {
+		go func() {
+			fn(ctx, t)
+			r.finish(s, !r.clock.Now().Before(*deadline), finished)
+		}()
+		return
+	}
+
+	parent, cancel := context.WithCancel(ctx)
+	dctx := &deadlineContext{Context: parent, deadline: *deadline}
+	expiry := r.clock.NewTimer(deadline.Sub(r.clock.Now()))
+	go func() {
+		returned := make(chan struct{})
+		go func() {
+			select {
+			case <-expiry.C():
+				atomic.StoreInt32(&dctx.expired, 1)
+				cancel()
+			case <-returned:
+				expiry.Stop()
+			}
+		}()
+		fn(dctx, t)
+		close(returned)
+		cancel()
+		overran := atomic.LoadInt32(&dctx.expired) == 1 || !r.clock.Now().Before(*deadline)
+		r.finish(s, overran, finished)
+	}()
+}
+
+func (r *SerializedRunner) finish(s Schedule, overran bool, finished chan<- bool) {
+	if overran {
+		atomic.AddUint64(&r.overruns, 1)
+	}
+	if c, ok := s.(completer); ok {
+		c.Complete(r.clock.Now())
+	}
+	finished <- overran
+}
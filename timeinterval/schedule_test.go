@@ -0,0 +1,275 @@
+package timeinterval
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a clock for tests that only advances when one of its timers is fired.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	created chan *fakeTimer
+}
+
+type fakeTimer struct {
+	clock   *fakeClock
+	at      time.Time
+	c       chan time.Time
+	stopped bool
+	fired   bool
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, created: make(chan *fakeTimer, 64)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) clockTimer {
+	c.mu.Lock()
+	timer := &fakeTimer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.mu.Unlock()
+	c.created <- timer
+	return timer
+}
+
+// next waits for the runner to create its next timer.
+func (c *fakeClock) next(t *testing.T) *fakeTimer {
+	select {
+	case timer := <-c.created:
+		return timer
+	case <-time.After(time.Second):
+		t.Fatal("no timer was created")
+		return nil
+	}
+}
+
+// fire advances the clock to the time of the timer and fires it unless it was stopped.
+func (c *fakeClock) fire(timer *fakeTimer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if timer.at.After(c.now) {
+		c.now = timer.at
+	}
+	if !timer.stopped && !timer.fired {
+		timer.fired = true
+		timer.c <- c.now
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := !t.stopped && !t.fired
+	t.stopped = true
+	return active
+}
+
+func receive(t *testing.T, c <-chan time.Time) time.Time {
+	select {
+	case v := <-c:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("nothing was received")
+		return time.Time{}
+	}
+}
+
+func wait(t *testing.T, runner *SerializedRunner) {
+	select {
+	case <-runner.Done():
+	case <-time.After(time.Second):
+		t.Fatal("runner did not finish")
+	}
+}
+
+func TestRunSerialized(t *testing.T) {
+	clk := newFakeClock(time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC))
+	startsAt := clk.Now().Add(10 * time.Millisecond)
+	every := 10 * time.Millisecond
+	repetitions := uint32(5)
+	i, err := NewInterval(&startsAt, nil, &every)
+	assert.Nil(t, err)
+	r := Repeating{Interval: *i, Repetitions: &repetitions}
+	occurrence := func(n int) time.Time {
+		return startsAt.Add(time.Duration(n) * every)
+	}
+
+	started := make(chan time.Time)
+	release := make(chan struct{})
+	var active, overlapping int32
+	runner := runSerialized(context.Background(), r, func(ctx context.Context, t time.Time) {
+		if atomic.AddInt32(&active, 1) > 1 {
+			atomic.AddInt32(&overlapping, 1)
+		}
+		started <- t
+		<-release
+		atomic.AddInt32(&active, -1)
+	}, RunOptions{}, clk)
+	// finish lets the running invocation return once the runner waits for the next occurrence. The runner then
+	// replaces its timer, which is returned.
+	finish := func() *fakeTimer {
+		clk.next(t)
+		release <- struct{}{}
+		return clk.next(t)
+	}
+
+	clk.fire(clk.next(t))
+	assert.Equal(t, occurrence(0), receive(t, started))
+	// The next two occurrences are skipped while the first invocation is running.
+	clk.fire(clk.next(t))
+	clk.fire(clk.next(t))
+	clk.fire(finish())
+	assert.Equal(t, occurrence(3), receive(t, started))
+	clk.fire(finish())
+	assert.Equal(t, occurrence(4), receive(t, started))
+	clk.fire(finish())
+	assert.Equal(t, occurrence(5), receive(t, started))
+	// The schedule has ended, so the runner only waits for the last invocation.
+	release <- struct{}{}
+	wait(t, runner)
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&overlapping))
+	assert.Equal(t, uint64(4), runner.Runs())
+	assert.Equal(t, uint64(2), runner.Skips())
+}
+
+func TestRunSerialized_Cancel(t *testing.T) {
+	clk := newFakeClock(time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC))
+	startsAt := clk.Now().Add(5 * time.Millisecond)
+	every := 5 * time.Millisecond
+	i, err := NewInterval(&startsAt, nil, &every)
+	assert.Nil(t, err)
+	s := NewAdaptiveSchedule(Repeating{Interval: *i}, ScheduleFixedDelay)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan time.Time)
+	release := make(chan struct{})
+	var runs int32
+	runner := runSerialized(ctx, s, func(ctx context.Context, t time.Time) {
+		started <- t
+		<-release
+		if atomic.AddInt32(&runs, 1) == 3 {
+			cancel()
+		}
+	}, RunOptions{}, clk)
+
+	clk.fire(clk.next(t))
+	assert.Equal(t, startsAt, receive(t, started))
+	// Until the first completion is recorded the runner waits for the fixed rate occurrence.
+	clk.next(t)
+	release <- struct{}{}
+	clk.fire(clk.next(t))
+	assert.Equal(t, startsAt.Add(every), receive(t, started))
+	// Afterwards the next occurrence is overdue until the invocation completes, so no timer is created.
+	release <- struct{}{}
+	clk.fire(clk.next(t))
+	assert.Equal(t, startsAt.Add(2*every), receive(t, started))
+	release <- struct{}{}
+	wait(t, runner)
+
+	assert.Equal(t, uint64(3), runner.Runs())
+	assert.Equal(t, startsAt.Add(2*every), *s.LastCompleted())
+}
+
+func TestRunSerializedWithOptions_Cancel(t *testing.T) {
+	clk := newFakeClock(time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC))
+	startsAt := clk.Now().Add(10 * time.Millisecond)
+	every := 10 * time.Millisecond
+	i, err := NewInterval(&startsAt, nil, &every)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := make(chan time.Time)
+	var deadline, occurrenceDeadline time.Time
+	var errs []error
+	opts := RunOptions{MaxDuration: 3 * time.Millisecond, Overrun: OverrunCancel}
+	runner := runSerialized(ctx, Repeating{Interval: *i}, func(ctx context.Context, t time.Time) {
+		deadline, _ = ctx.Deadline()
+		occurrenceDeadline, _ = OccurrenceDeadline(ctx)
+		errs = append(errs, ctx.Err())
+		started <- t
+		<-ctx.Done()
+		errs = append(errs, ctx.Err())
+	}, opts, clk)
+
+	clk.fire(clk.next(t))
+	assert.Equal(t, startsAt, receive(t, started))
+	expiry := clk.next(t)
+	clk.next(t)
+	// The invocation is canceled at its deadline, before the next occurrence.
+	clk.fire(expiry)
+	clk.next(t)
+	cancel()
+	wait(t, runner)
+
+	assert.Equal(t, startsAt.Add(3*time.Millisecond), deadline)
+	assert.Equal(t, deadline, occurrenceDeadline)
+	assert.Equal(t, []error{nil, context.DeadlineExceeded}, errs)
+	assert.Equal(t, uint64(1), runner.Runs())
+	assert.Equal(t, uint64(1), runner.Overruns())
+}
+
+func TestRunSerializedWithOptions_SkipNext(t *testing.T) {
+	clk := newFakeClock(time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC))
+	startsAt := clk.Now().Add(10 * time.Millisecond)
+	every := 10 * time.Millisecond
+	repetitions := uint32(3)
+	i, err := NewInterval(&startsAt, nil, &every)
+	assert.Nil(t, err)
+	r := Repeating{Interval: *i, Repetitions: &repetitions}
+
+	started := make(chan time.Time)
+	release := make(chan struct{})
+	var deadlines []*time.Time
+	var contextDeadlines int32
+	runner := runSerialized(context.Background(), r, func(ctx context.Context, t time.Time) {
+		// The context is never canceled at the deadline, so it must not report it.
+		if _, ok := ctx.Deadline(); ok {
+			atomic.AddInt32(&contextDeadlines, 1)
+		}
+		if deadline, ok := OccurrenceDeadline(ctx); ok {
+			deadlines = append(deadlines, &deadline)
+		} else {
+			deadlines = append(deadlines, nil)
+		}
+		started <- t
+		<-release
+	}, RunOptions{Overrun: OverrunSkipNext}, clk)
+
+	clk.fire(clk.next(t))
+	assert.Equal(t, startsAt, receive(t, started))
+	// The invocation overruns its deadline, the next occurrence, which is skipped while it is running.
+	clk.fire(clk.next(t))
+	clk.next(t)
+	release <- struct{}{}
+	// The first occurrence after the overrun is skipped as well.
+	clk.fire(clk.next(t))
+	clk.fire(clk.next(t))
+	assert.Equal(t, startsAt.Add(3*every), receive(t, started))
+	release <- struct{}{}
+	wait(t, runner)
+
+	nextOccurrence := startsAt.Add(every)
+	// The last occurrence has no deadline.
+	assert.Equal(t, []*time.Time{&nextOccurrence, nil}, deadlines)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&contextDeadlines))
+	assert.Equal(t, uint64(2), runner.Runs())
+	assert.Equal(t, uint64(2), runner.Skips())
+	assert.Equal(t, uint64(1), runner.Overruns())
+}
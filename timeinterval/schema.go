@@ -0,0 +1,44 @@
+package timeinterval
+
+// The patterns describe the strings accepted by ParseIntervalISO8601 and ParseRepeatingIntervalISO8601. They use the
+// subset of regular expressions shared by ECMA 262 (used by JSON Schema) and Go.
+const (
+	schemaDatePattern     = "[0-9]{4}-(?:[0-9]{2}-[0-9]{2}|W[0-9]{2}-[1-7]|[0-9]{3})"
+	schemaClockPattern    = "[0-9]{2}:[0-9]{2}(?::[0-9]{2}(?:[.][0-9]+)?)?"
+	schemaZonePattern     = "(?:Z|[+-][0-9]{2}:[0-9]{2})"
+	schemaSuffixPattern   = "(?:\\[!?[^\\[\\]]+\\])*"
+	schemaTimePattern     = schemaDatePattern + "T" + schemaClockPattern + schemaZonePattern + schemaSuffixPattern
+	schemaConcisePattern  = "(?:(?:[0-9]{2}-)?[0-9]{2}T)?" + schemaClockPattern + schemaZonePattern + "?"
+	schemaNumberPattern   = "[0-9]+(?:[.,][0-9]+)?"
+	schemaDurationPattern = "P(?:" + schemaNumberPattern + "Y)?(?:" + schemaNumberPattern + "M)?(?:" + schemaNumberPattern + "W)?(?:" +
+		schemaNumberPattern + "D)?(?:T(?:" + schemaNumberPattern + "H)?(?:" + schemaNumberPattern + "M)?(?:" + schemaNumberPattern + "S)?)?"
+	schemaClosedPattern = "(?:" + schemaTimePattern + "/(?:" + schemaTimePattern + "|" + schemaDurationPattern + "|" + schemaConcisePattern + ")|" +
+		schemaDurationPattern + "/" + schemaTimePattern + ")"
+	schemaIntervalPattern  = "^(?:" + schemaClosedPattern + "|" + schemaTimePattern + "/\\.\\.|\\.\\./" + schemaTimePattern + ")$"
+	schemaRepeatingPattern = "^R(?:[0-9]+|-1)?/" + schemaClosedPattern + "$"
+)
+
+// IntervalJSONSchema returns a JSON Schema describing ISO8601 "interval" strings as accepted by
+// ParseIntervalISO8601, so that API servers can publish accurate OpenAPI schemas, e.g. as the schema of an Interval
+// field. The "pattern" does not check the ranges of the values (e.g. months), which parsing does.
+func IntervalJSONSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"format":      "iso8601-interval",
+		"pattern":     schemaIntervalPattern,
+		"description": "ISO8601 time interval: start/end, start/duration, duration/end or an open start/.. or ../end",
+		"examples":    []string{"2019-01-02T21:00:00Z/2019-01-03T21:00:00Z", "2019-01-02T21:00:00Z/P1W", "2019-01-02T21:00:00Z/.."},
+	}
+}
+
+// RepeatingJSONSchema returns a JSON Schema describing ISO8601 "repeating interval" strings as accepted by
+// ParseRepeatingIntervalISO8601. See: IntervalJSONSchema.
+func RepeatingJSONSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"format":      "iso8601-repeating-interval",
+		"pattern":     schemaRepeatingPattern,
+		"description": "ISO8601 repeating time interval: R[n]/interval, unbounded without n",
+		"examples":    []string{"R5/2019-01-02T21:00:00Z/PT1H", "R/2019-01-02T21:00:00Z/P1D"},
+	}
+}
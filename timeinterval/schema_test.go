@@ -0,0 +1,55 @@
+package timeinterval
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalJSONSchema(t *testing.T) {
+	schema := IntervalJSONSchema()
+	pattern := regexp.MustCompile(schema["pattern"].(string))
+	valid := []string{
+		"2019-01-02T21:00:00Z/2022-01-03T21:00:00Z",
+		"2019-01-02T21:00:00+01:00/P1W",
+		"P1DT6H/2022-01-03T21:00:00Z",
+		"2019-01-02T21:00:00Z/PT0.5S",
+		"2019-01-02T21:00:00Z/..",
+		"../2019-01-02T21:00:00Z",
+		"2007-11-13T09:00Z/15:30",
+		"2007-11-13T09:00:00Z/12-15T15:30:00",
+		"2019-W01-3T21:00:00Z/P1D",
+		"2019-002T21:00:00Z/P1D",
+		"2019-01-02T21:00:00+01:00[Europe/Copenhagen]/P1D",
+	}
+	for _, given := range valid {
+		_, err := ParseIntervalISO8601(given)
+		assert.Nil(t, err, given)
+		assert.True(t, pattern.MatchString(given), given)
+	}
+	invalid := []string{"", "P1D", "P1D/P1W", "../..", "2019-01-02T21:00:00/P1D", "2019-01-02 21:00:00Z/P1D", "R5/2019-01-02T21:00:00Z/P1D", "2019-01-02T21:00:00Z/1h"}
+	for _, given := range invalid {
+		_, err := ParseIntervalISO8601(given)
+		assert.NotNil(t, err, given)
+		assert.False(t, pattern.MatchString(given), given)
+	}
+	b, err := json.Marshal(schema)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `"format":"iso8601-interval"`)
+}
+
+func TestRepeatingJSONSchema(t *testing.T) {
+	pattern := regexp.MustCompile(RepeatingJSONSchema()["pattern"].(string))
+	for _, given := range []string{"R5/2019-01-02T21:00:00Z/PT1H", "R/2019-01-02T21:00:00Z/P1D", "R-1/P1D/2019-01-02T21:00:00Z", "R2/2019-01-02T21:00:00Z/2019-01-03T21:00:00Z"} {
+		_, err := ParseRepeatingIntervalISO8601(given)
+		assert.Nil(t, err, given)
+		assert.True(t, pattern.MatchString(given), given)
+	}
+	for _, given := range []string{"2019-01-02T21:00:00Z/P1D", "R/2019-01-02T21:00:00Z/..", "Rx/2019-01-02T21:00:00Z/P1D"} {
+		_, err := ParseRepeatingIntervalISO8601(given)
+		assert.NotNil(t, err, given)
+		assert.False(t, pattern.MatchString(given), given)
+	}
+}
@@ -0,0 +1,51 @@
+package timeinterval
+
+import "sort"
+
+// normalize returns the coverage of the given intervals as sorted, non-overlapping and non-touching intervals.
+// Zero length intervals do not contribute to the coverage and are dropped. Metadata of merged intervals is merged.
+func normalize(ins []Interval) []Interval {
+	sorted := make([]Interval, 0, len(ins))
+	for _, in := range ins {
+		if in.Duration() > 0 {
+			sorted = append(sorted, in)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartsAt.Before(sorted[j].StartsAt)
+	})
+	var result []Interval
+	for _, in := range sorted {
+		last := len(result) - 1
+		if last >= 0 && !in.StartsAt.After(result[last].EndsAt) {
+			result[last] = span(result[last], in)
+			continue
+		}
+		result = append(result, Interval{StartsAt: in.StartsAt, EndsAt: in.EndsAt, Format: boundsFormat(in.StartsAt, in.EndsAt), Meta: in.Meta})
+	}
+	return result
+}
+
+// intersectNormalized returns the intervals covered by both a and b. Both inputs must be normalized. Open bounds are
+// clipped to the bounds of the other set and only stay open where both are open.
+func intersectNormalized(a, b []Interval) []Interval {
+	var result []Interval
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		startsAt, endsAt := a[i].StartsAt, a[i].EndsAt
+		if b[j].StartsAt.After(startsAt) {
+			startsAt = b[j].StartsAt
+		}
+		if b[j].EndsAt.Before(endsAt) {
+			endsAt = b[j].EndsAt
+		}
+		if startsAt.Before(endsAt) {
+			result = append(result, Interval{StartsAt: startsAt, EndsAt: endsAt, Format: boundsFormat(startsAt, endsAt), Meta: mergeMeta(a[i].Meta, b[j].Meta)})
+		}
+		if a[i].EndsAt.Before(b[j].EndsAt) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
@@ -0,0 +1,29 @@
+package timeinterval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntersectNormalized_OpenBounds(t *testing.T) {
+	a := mustIntervals(t, "2019-01-01T00:00:00Z/..")
+	b := mustIntervals(t, "2019-02-01T00:00:00Z/2019-02-02T00:00:00Z", "2019-03-01T00:00:00Z/..")
+	common := intersectNormalized(a, b)
+	assert.Len(t, common, 2)
+	iso, err := common[0].ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-02-01T00:00:00Z/2019-02-02T00:00:00Z", iso)
+	// Bounds that are open in both sets stay open.
+	iso, err = common[1].ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-03-01T00:00:00Z/..", iso)
+}
+
+func TestNormalize_OpenBounds(t *testing.T) {
+	merged := normalize(mustIntervals(t, "../2019-01-02T12:00:00Z", "2019-01-02T10:00:00Z/2019-01-02T14:00:00Z"))
+	assert.Len(t, merged, 1)
+	iso, err := merged[0].ISO8601()
+	assert.Nil(t, err)
+	assert.Equal(t, "../2019-01-02T14:00:00Z", iso)
+}
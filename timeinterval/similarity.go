@@ -0,0 +1,70 @@
+package timeinterval
+
+import "time"
+
+// Overlap returns the total duration covered by both a and b.
+// Overlapping intervals within each set are only counted once. Open bounds are clipped to the earliest respectively
+// latest bound of either set, so open intervals count the time they share with the other set.
+func Overlap(a, b []Interval) time.Duration {
+	clipped := clipOpen(a, b)
+	return totalDuration(intersectNormalized(normalize(clipped[0]), normalize(clipped[1])))
+}
+
+// Jaccard returns the Jaccard similarity of the time covered by a and b.
+// This is the duration covered by both divided by the duration covered by either, ranging from 0 (disjoint) to 1 (identical).
+// Two sets that cover no time at all are considered identical. Like in Overlap, open bounds are clipped.
+func Jaccard(a, b []Interval) float64 {
+	clipped := clipOpen(a, b)
+	union := totalDuration(normalize(append(clipped[0], clipped[1]...)))
+	if union == 0 {
+		return 1
+	}
+	return float64(totalDuration(intersectNormalized(normalize(clipped[0]), normalize(clipped[1])))) / float64(union)
+}
+
+// clipOpen returns the given sets of intervals with their open bounds clipped to the earliest respectively latest
+// bound of all sets that is not open. See: ErrOpenInterval
+func clipOpen(sets ...[]Interval) [][]Interval {
+	var first, last time.Time
+	found := false
+	extend := func(t time.Time) {
+		if !found || t.Before(first) {
+			first = t
+		}
+		if !found || t.After(last) {
+			last = t
+		}
+		found = true
+	}
+	for _, set := range sets {
+		for _, in := range set {
+			if !in.OpenStart() {
+				extend(in.StartsAt)
+			}
+			if !in.OpenEnd() {
+				extend(in.EndsAt)
+			}
+		}
+	}
+	result := make([][]Interval, len(sets))
+	for i, set := range sets {
+		for _, in := range set {
+			switch {
+			case in.OpenStart():
+				in = Interval{StartsAt: first, EndsAt: in.EndsAt, Format: ISOFormatTimeAndTime, Meta: in.Meta}
+			case in.OpenEnd():
+				in = Interval{StartsAt: in.StartsAt, EndsAt: last, Format: ISOFormatTimeAndTime, Meta: in.Meta}
+			}
+			result[i] = append(result[i], in)
+		}
+	}
+	return result
+}
+
+func totalDuration(ins []Interval) time.Duration {
+	total := time.Duration(0)
+	for _, in := range ins {
+		total += in.Duration()
+	}
+	return total
+}
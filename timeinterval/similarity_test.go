@@ -0,0 +1,52 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustIntervals(t *testing.T, isos ...string) []Interval {
+	var result []Interval
+	for _, iso := range isos {
+		in, err := ParseIntervalISO8601(iso)
+		assert.Nil(t, err)
+		result = append(result, *in)
+	}
+	return result
+}
+
+func TestOverlap(t *testing.T) {
+	planned := mustIntervals(t,
+		"2019-01-02T08:00:00Z/2019-01-02T12:00:00Z",
+		"2019-01-02T10:00:00Z/2019-01-02T14:00:00Z",
+		"2019-01-03T08:00:00Z/PT2H",
+	)
+	actual := mustIntervals(t,
+		"2019-01-02T09:00:00Z/2019-01-02T15:00:00Z",
+		"2019-01-03T09:00:00Z/PT2H",
+	)
+	assert.Equal(t, 6*time.Hour, Overlap(planned, actual))
+	assert.Equal(t, 6*time.Hour, Overlap(actual, planned))
+	assert.Equal(t, time.Duration(0), Overlap(planned, nil))
+
+	// Open bounds are clipped to the other bounds instead of counting as the time up to year 1 or 9999.
+	open := mustIntervals(t, "2019-01-02T10:00:00Z/..", "../2019-01-02T10:00:00Z")
+	assert.Equal(t, 8*time.Hour, Overlap(append(planned, open...), actual))
+	assert.Equal(t, time.Duration(0), Overlap(open, open))
+	assert.Equal(t, 24*time.Hour, Overlap(mustIntervals(t, "2019-01-01T00:00:00Z/.."), mustIntervals(t, "2019-02-01T00:00:00Z/2019-02-02T00:00:00Z")))
+}
+
+func TestJaccard(t *testing.T) {
+	a := mustIntervals(t, "2019-01-02T08:00:00Z/2019-01-02T12:00:00Z")
+	b := mustIntervals(t, "2019-01-02T10:00:00Z/2019-01-02T14:00:00Z")
+	c := mustIntervals(t, "2019-01-02T12:00:00Z/2019-01-02T14:00:00Z")
+	assert.InDelta(t, 1.0/3.0, Jaccard(a, b), 1e-9)
+	assert.Equal(t, 1.0, Jaccard(a, a))
+	assert.Equal(t, 0.0, Jaccard(a, c))
+	assert.Equal(t, 1.0, Jaccard(nil, nil))
+	assert.Equal(t, 0.0, Jaccard(a, nil))
+	assert.InDelta(t, 2.0/3.0, Jaccard(append(a, mustIntervals(t, "2019-01-02T10:00:00Z/..")...), b), 1e-9)
+	assert.InDelta(t, 1.0/32.0, Jaccard(mustIntervals(t, "2019-01-01T00:00:00Z/.."), mustIntervals(t, "2019-02-01T00:00:00Z/2019-02-02T00:00:00Z")), 1e-9)
+}
@@ -0,0 +1,95 @@
+package timeinterval
+
+import "time"
+
+type spreadEndpoints uint8
+
+// SpreadIncludeStart places the first occurrence at the start of the interval and none at the end,
+// i.e. every occurrence starts an equal share of the interval.
+const SpreadIncludeStart spreadEndpoints = 0
+
+// SpreadIncludeEnd places the last occurrence at the end of the interval and none at the start,
+// i.e. every occurrence ends an equal share of the interval.
+const SpreadIncludeEnd spreadEndpoints = 1
+
+// SpreadIncludeBoth places the first occurrence at the start and the last occurrence at the end of the interval.
+const SpreadIncludeBoth spreadEndpoints = 2
+
+// SpreadExclude places the occurrences in the middle of equal shares of the interval, keeping away from both ends.
+const SpreadExclude spreadEndpoints = 3
+
+// SpreadN returns n times evenly distributed across the interval, e.g. to spread n tasks across a maintenance window.
+// The endpoints determine whether the start and end of the interval are used. Nil is returned if n is not positive
+// or the interval is open.
+func SpreadN(in Interval, n int, endpoints spreadEndpoints) []time.Time {
+	if n <= 0 || in.Format == ISOFormatOpenStart || in.Format == ISOFormatOpenEnd {
+		return nil
+	}
+	d := in.Duration()
+	result := make([]time.Time, n)
+	for i := 0; i < n; i++ {
+		var offset time.Duration
+		switch endpoints {
+		case SpreadIncludeEnd:
+			offset = scaleDuration(d, int64(i+1), int64(n))
+		case SpreadIncludeBoth:
+			if n > 1 {
+				offset = scaleDuration(d, int64(i), int64(n-1))
+			}
+		case SpreadExclude:
+			offset = scaleDuration(d, int64(2*i+1), int64(2*n))
+		default:
+			offset = scaleDuration(d, int64(i), int64(n))
+		}
+		result[i] = in.StartsAt.Add(offset)
+	}
+	return result
+}
+
+// SpreadWeighted returns one time per weight distributed across the interval, where every occurrence is given a share
+// of the interval proportional to its weight. The endpoints determine where in its share an occurrence is placed
+// (See: SpreadN). With SpreadIncludeBoth the shares are scaled so the last occurrence is at the end of the interval.
+// Nil is returned if there are no weights, a weight is negative, the weights sum to zero or the interval is open.
+func SpreadWeighted(in Interval, weights []float64, endpoints spreadEndpoints) []time.Time {
+	if len(weights) == 0 || in.Format == ISOFormatOpenStart || in.Format == ISOFormatOpenEnd {
+		return nil
+	}
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 {
+			return nil
+		}
+		total += w
+	}
+	if total == 0 {
+		return nil
+	}
+	d := float64(in.Duration())
+	result := make([]time.Time, len(weights))
+	cumulative := 0.0
+	for i, w := range weights {
+		var position float64
+		switch endpoints {
+		case SpreadIncludeEnd:
+			position = (cumulative + w) / total
+		case SpreadIncludeBoth:
+			if last := total - weights[len(weights)-1]; last > 0 {
+				position = cumulative / last
+			}
+		case SpreadExclude:
+			position = (cumulative + w/2) / total
+		default:
+			position = cumulative / total
+		}
+		result[i] = in.StartsAt.Add(time.Duration(d * position))
+		cumulative += w
+	}
+	return result
+}
+
+// scaleDuration returns d*num/den without overflowing for large durations.
+func scaleDuration(d time.Duration, num, den int64) time.Duration {
+	q := int64(d) / den
+	r := int64(d) % den
+	return time.Duration(q*num + r*num/den)
+}
@@ -0,0 +1,61 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func spreadOffsets(in Interval, times []time.Time) []time.Duration {
+	result := make([]time.Duration, len(times))
+	for i, t := range times {
+		result[i] = t.Sub(in.StartsAt)
+	}
+	return result
+}
+
+func TestSpreadN(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T00:00:00Z/PT4H")
+	h := time.Hour
+	assert.Equal(t, []time.Duration{0, h, 2 * h, 3 * h}, spreadOffsets(*in, SpreadN(*in, 4, SpreadIncludeStart)))
+	assert.Equal(t, []time.Duration{h, 2 * h, 3 * h, 4 * h}, spreadOffsets(*in, SpreadN(*in, 4, SpreadIncludeEnd)))
+	assert.Equal(t, []time.Duration{0, 2 * h, 4 * h}, spreadOffsets(*in, SpreadN(*in, 3, SpreadIncludeBoth)))
+	assert.Equal(t, []time.Duration{30 * time.Minute, 90 * time.Minute, 150 * time.Minute, 210 * time.Minute}, spreadOffsets(*in, SpreadN(*in, 4, SpreadExclude)))
+	assert.Equal(t, []time.Duration{0}, spreadOffsets(*in, SpreadN(*in, 1, SpreadIncludeBoth)))
+}
+
+func TestSpreadN_Invalid(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T00:00:00Z/PT4H")
+	assert.Nil(t, SpreadN(*in, 0, SpreadIncludeStart))
+	open := NewOpenEndInterval(in.StartsAt)
+	assert.Nil(t, SpreadN(*open, 3, SpreadIncludeStart))
+}
+
+func TestSpreadN_Precision(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T00:00:00Z/PT1S")
+	times := SpreadN(*in, 3, SpreadIncludeStart)
+	assert.Equal(t, []time.Duration{0, 333333333, 666666666}, spreadOffsets(*in, times))
+}
+
+func TestSpreadWeighted(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T00:00:00Z/PT4H")
+	h := time.Hour
+	weights := []float64{1, 2, 1}
+	assert.Equal(t, []time.Duration{0, h, 3 * h}, spreadOffsets(*in, SpreadWeighted(*in, weights, SpreadIncludeStart)))
+	assert.Equal(t, []time.Duration{h, 3 * h, 4 * h}, spreadOffsets(*in, SpreadWeighted(*in, weights, SpreadIncludeEnd)))
+	assert.Equal(t, []time.Duration{30 * time.Minute, 2 * h, 210 * time.Minute}, spreadOffsets(*in, SpreadWeighted(*in, weights, SpreadExclude)))
+	assert.Equal(t, []time.Duration{0, 80 * time.Minute, 4 * h}, spreadOffsets(*in, SpreadWeighted(*in, weights, SpreadIncludeBoth)))
+
+	// Equal weights match SpreadN.
+	for _, endpoints := range []spreadEndpoints{SpreadIncludeStart, SpreadIncludeEnd, SpreadIncludeBoth, SpreadExclude} {
+		assert.Equal(t, SpreadN(*in, 4, endpoints), SpreadWeighted(*in, []float64{1, 1, 1, 1}, endpoints))
+	}
+}
+
+func TestSpreadWeighted_Invalid(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T00:00:00Z/PT4H")
+	assert.Nil(t, SpreadWeighted(*in, nil, SpreadIncludeStart))
+	assert.Nil(t, SpreadWeighted(*in, []float64{1, -1}, SpreadIncludeStart))
+	assert.Nil(t, SpreadWeighted(*in, []float64{0, 0}, SpreadIncludeStart))
+}
@@ -0,0 +1,108 @@
+package timeinterval
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Value returns the interval as an ISO8601 "interval" string. See: driver.Valuer.
+func (in Interval) Value() (driver.Value, error) {
+	return in.ISO8601()
+}
+
+// Scan scans the interval from an ISO8601 "interval" string or from the text of a two-column composite or range
+// of times, e.g. `(2019-01-02T21:00:00Z,2019-01-03T21:00:00Z)` or the PostgreSQL tstzrange
+// `["2019-01-02 21:00:00+00","2019-01-03 21:00:00+00")`. An empty (or infinite) bound of a range is open.
+// See: sql.Scanner.
+func (in *Interval) Scan(src interface{}) error {
+	s, err := sqlText(src)
+	if err != nil {
+		return err
+	}
+	if s == "" || !strings.ContainsAny(s[:1], "([") {
+		return in.UnmarshalText([]byte(s))
+	}
+	i, err := parseComposite(s)
+	if err != nil {
+		return err
+	}
+	in.assign(i)
+	return nil
+}
+
+// Value returns the repeating interval as an ISO8601 "repeating interval" string. See: driver.Valuer.
+func (in Repeating) Value() (driver.Value, error) {
+	return in.ISO8601()
+}
+
+// Scan scans the repeating interval from an ISO8601 "repeating interval" string. See: sql.Scanner.
+func (in *Repeating) Scan(src interface{}) error {
+	s, err := sqlText(src)
+	if err != nil {
+		return err
+	}
+	return in.UnmarshalText([]byte(s))
+}
+
+func sqlText(src interface{}) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case nil:
+		return "", errors.New("cannot scan NULL into interval")
+	}
+	return "", errors.New("cannot scan non-text value into interval")
+}
+
+// parseComposite parses the text of a two-column composite or range of times. See: Interval.Scan.
+func parseComposite(s string) (Interval, error) {
+	if len(s) < 2 || !strings.ContainsAny(s[len(s)-1:], ")]") {
+		return Interval{}, errors.New("invalid composite interval format")
+	}
+	parts := strings.Split(s[1:len(s)-1], ",")
+	if len(parts) != 2 {
+		return Interval{}, errors.New("invalid composite interval format")
+	}
+	var bounds [2]*time.Time
+	for i, part := range parts {
+		part = strings.Trim(strings.TrimSpace(part), `"`)
+		if part == "" || strings.HasSuffix(part, "infinity") {
+			continue
+		}
+		t, err := parseTimeString(compositeTime(part), nil)
+		if err != nil {
+			return Interval{}, err
+		}
+		bounds[i] = &t
+	}
+	switch {
+	case bounds[0] == nil && bounds[1] == nil:
+		return Interval{}, errors.New("composite interval must have a start or an end")
+	case bounds[0] == nil:
+		return *NewOpenStartInterval(*bounds[1]), nil
+	case bounds[1] == nil:
+		return *NewOpenEndInterval(*bounds[0]), nil
+	}
+	in, err := NewInterval(bounds[0], bounds[1], nil)
+	if err != nil {
+		return Interval{}, err
+	}
+	return *in, nil
+}
+
+// compositeTime converts a time as formatted by SQL databases (e.g. "2019-01-02 21:00:00+00") into ISO8601.
+func compositeTime(s string) string {
+	s = strings.Replace(s, " ", "T", 1)
+	n := len(s)
+	switch {
+	case strings.HasSuffix(s, "+00"):
+		s = s[:n-3] + "Z"
+	case n > 3 && (s[n-3] == '+' || s[n-3] == '-'):
+		s += ":00"
+	}
+	return s
+}
@@ -0,0 +1,53 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval_Value(t *testing.T) {
+	v, err := MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1D").Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00Z/P1D", v)
+}
+
+func TestInterval_Scan(t *testing.T) {
+	expectations := map[string]string{
+		"2019-01-02T21:00:00Z/P1D":                                  "2019-01-02T21:00:00Z/P1D",
+		"(2019-01-02T21:00:00Z,2019-01-03T21:00:00Z)":               "2019-01-02T21:00:00Z/2019-01-03T21:00:00Z",
+		`["2019-01-02 21:00:00+00","2019-01-03 22:00:00+01")`:       "2019-01-02T21:00:00Z/2019-01-03T22:00:00+01:00",
+		`["2019-01-02 21:00:00.5+05:30",)`:                          "2019-01-02T21:00:00+05:30/..",
+		`(-infinity,"2019-01-03 21:00:00-03")`:                      "../2019-01-03T21:00:00-03:00",
+		"( 2019-01-02T21:00:00+01:00 , 2019-01-02T23:00:00+01:00 )": "2019-01-02T21:00:00+01:00/2019-01-02T23:00:00+01:00",
+	}
+	for given, expected := range expectations {
+		var in Interval
+		assert.Nil(t, in.Scan([]byte(given)), given)
+		iso, err := in.ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso, given)
+	}
+
+	var in Interval
+	assert.Nil(t, in.Scan(`["2019-01-02 21:00:00.5+00",)`))
+	assert.Equal(t, time.Date(2019, 1, 2, 21, 0, 0, 500000000, time.UTC), in.StartsAt)
+
+	for _, given := range []interface{}{nil, 42, "", "(,)", "(2019-01-02T21:00:00Z)", "(2019-01-03T21:00:00Z,2019-01-02T21:00:00Z)", "(foo,bar)", "[2019-01-02T21:00:00Z,"} {
+		assert.NotNil(t, in.Scan(given), given)
+	}
+}
+
+func TestRepeating_ValueScan(t *testing.T) {
+	r := MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT15M")
+	v, err := r.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "R5/2019-01-02T21:00:00Z/PT15M", v)
+
+	var result Repeating
+	assert.Nil(t, result.Scan([]byte("R5/2019-01-02T21:00:00Z/PT15M")))
+	assert.Equal(t, *r, result)
+	assert.NotNil(t, result.Scan(nil))
+	assert.NotNil(t, result.Scan("2019-01-02T21:00:00Z/PT15M"))
+}
@@ -0,0 +1,70 @@
+package timeinterval
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// DurationStats describes the distribution of the durations of a set of intervals.
+type DurationStats struct {
+	Count int
+	Total time.Duration
+	// Mean is the arithmetic mean of the durations.
+	Mean time.Duration
+	// WeightedMean is the mean of the durations weighted by themselves, i.e. the expected duration of the interval
+	// covering a random instant of the Total time. It exceeds Mean when long intervals (e.g. outages) make up most of
+	// the time.
+	WeightedMean time.Duration
+	Median       time.Duration
+	Min          time.Duration
+	Max          time.Duration
+	// StdDev is the population standard deviation of the durations.
+	StdDev time.Duration
+}
+
+// Stats returns statistics about the durations of the given intervals.
+// The zero value is returned when no intervals are given. ErrOpenInterval is returned if an interval has an open
+// start or end and an error is returned if the total duration exceeds the range of time.Duration.
+func Stats(ins []Interval) (DurationStats, error) {
+	stats := DurationStats{Count: len(ins)}
+	if len(ins) == 0 {
+		return stats, nil
+	}
+	durations := make([]time.Duration, len(ins))
+	squares := 0.0
+	for i, in := range ins {
+		if in.OpenStart() || in.OpenEnd() {
+			return DurationStats{}, ErrOpenInterval
+		}
+		durations[i] = in.Duration()
+		if stats.Total > math.MaxInt64-durations[i] {
+			return DurationStats{}, errors.New("total duration overflows")
+		}
+		stats.Total += durations[i]
+		squares += float64(durations[i]) * float64(durations[i])
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	stats.Min = durations[0]
+	stats.Max = durations[len(durations)-1]
+	stats.Mean = stats.Total / time.Duration(len(durations))
+	middle := len(durations) / 2
+	if len(durations)%2 == 0 {
+		stats.Median = durations[middle-1] + (durations[middle]-durations[middle-1])/2
+	} else {
+		stats.Median = durations[middle]
+	}
+	mean := float64(stats.Total) / float64(len(durations))
+	variance := 0.0
+	for _, d := range durations {
+		variance += (float64(d) - mean) * (float64(d) - mean)
+	}
+	stats.StdDev = time.Duration(math.Sqrt(variance / float64(len(durations))))
+	if stats.Total > 0 {
+		stats.WeightedMean = time.Duration(math.Round(squares / float64(stats.Total)))
+	}
+	return stats, nil
+}
@@ -0,0 +1,44 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	startsAt := time.Now()
+	var ins []Interval
+	for _, d := range []time.Duration{2 * time.Hour, 4 * time.Hour, 4 * time.Hour, 4 * time.Hour, 5 * time.Hour, 5 * time.Hour, 7 * time.Hour, 9 * time.Hour} {
+		in, err := NewInterval(&startsAt, nil, &d)
+		assert.Nil(t, err)
+		ins = append(ins, *in)
+	}
+	stats, err := Stats(ins)
+	assert.Nil(t, err)
+	assert.Equal(t, DurationStats{
+		Count:        8,
+		Total:        40 * time.Hour,
+		Mean:         5 * time.Hour,
+		WeightedMean: 5*time.Hour + 48*time.Minute,
+		Median:       4*time.Hour + 30*time.Minute,
+		Min:          2 * time.Hour,
+		Max:          9 * time.Hour,
+		StdDev:       2 * time.Hour,
+	}, stats)
+
+	stats, err = Stats(ins[:3])
+	assert.Nil(t, err)
+	assert.Equal(t, 4*time.Hour, stats.Median)
+	stats, err = Stats(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, DurationStats{}, stats)
+}
+
+func TestStats_Errors(t *testing.T) {
+	_, err := Stats(mustIntervals(t, "../2019-01-01T00:00:00Z", "2019-01-01T00:00:00Z/.."))
+	assert.Equal(t, ErrOpenInterval, err)
+	_, err = Stats(mustIntervals(t, "1800-01-01T00:00:00Z/2000-01-01T00:00:00Z", "2000-01-01T00:00:00Z/2200-01-01T00:00:00Z"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,82 @@
+package timeinterval
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// ScheduleStatus describes the state of a named schedule at a point in time. See: NewStatusHandler.
+type ScheduleStatus struct {
+	Name string `json:"name"`
+	// Schedule is the schedule formatted as an ISO8601 repeating interval string.
+	Schedule string `json:"schedule"`
+	// Last is the latest occurrence at or before the time of the status or nil if there is none.
+	Last *time.Time `json:"last"`
+	// Next is the first occurrence after the time of the status or nil if there is none.
+	Next *time.Time `json:"next"`
+	// Active indicates that the schedule has started and not ended.
+	Active bool `json:"active"`
+	// Paused indicates that the schedule is paused in its PausableRepository.
+	Paused bool `json:"paused"`
+}
+
+// StatusOf returns the status of the named schedule at the given time. The schedule is not paused.
+func StatusOf(name string, r Repeating, t time.Time) (ScheduleStatus, error) {
+	iso, err := r.ISO8601()
+	if err != nil {
+		return ScheduleStatus{}, err
+	}
+	return ScheduleStatus{Name: name, Schedule: iso, Last: r.previous(t), Next: r.Next(t), Active: r.In(t)}, nil
+}
+
+// NewStatusHandler returns an http.Handler rendering the status of all schedules in the repository as a JSON array,
+// which is useful as a debug endpoint for operators. The status is computed at the time returned by now
+// (time.Now if nil). Schedules are reported as paused if the repository is a PausableRepository.
+func NewStatusHandler(repo ScheduleRepository, now func() time.Time) http.Handler {
+	if now == nil {
+		now = time.Now
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		statuses, err := repositoryStatus(req, repo, now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(statuses)
+	})
+}
+
+func repositoryStatus(req *http.Request, repo ScheduleRepository, t time.Time) ([]ScheduleStatus, error) {
+	names, err := repo.List(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]ScheduleStatus, 0, len(names))
+	for _, name := range names {
+		r, err := repo.Load(req.Context(), name)
+		if err == ErrScheduleNotFound {
+			// Deleted since it was listed.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		status, err := StatusOf(name, *r, t)
+		if err != nil {
+			return nil, err
+		}
+		if pausable, ok := repo.(PausableRepository); ok {
+			status.Paused, err = pausable.Paused(req.Context(), name)
+			if err == ErrScheduleNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
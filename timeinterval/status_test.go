@@ -0,0 +1,81 @@
+package timeinterval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusOf(t *testing.T) {
+	now := time.Date(2019, 1, 2, 22, 30, 0, 0, time.UTC)
+	status, err := StatusOf("hourly", *MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H"), now)
+	assert.Nil(t, err)
+	assert.Equal(t, "R5/2019-01-02T21:00:00Z/PT1H", status.Schedule)
+	assert.Equal(t, time.Date(2019, 1, 2, 22, 0, 0, 0, time.UTC), *status.Last)
+	assert.Equal(t, time.Date(2019, 1, 2, 23, 0, 0, 0, time.UTC), *status.Next)
+	assert.True(t, status.Active)
+
+	status, err = StatusOf("later", *MustParseRepeatingIntervalISO8601("R5/2019-01-03T21:00:00Z/PT1H"), now)
+	assert.Nil(t, err)
+	assert.Nil(t, status.Last)
+	assert.False(t, status.Active)
+
+	status, err = StatusOf("ended", *MustParseRepeatingIntervalISO8601("R2/2019-01-01T21:00:00Z/PT1H"), now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 1, 1, 23, 0, 0, 0, time.UTC), *status.Last)
+	assert.Nil(t, status.Next)
+	assert.False(t, status.Active)
+
+	// Unbounded repetitions extend before their first interval for the last and next occurrence alike.
+	status, err = StatusOf("unbounded", *MustParseRepeatingIntervalISO8601("R/2019-01-04T21:00:00Z/P1D"), now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 1, 2, 21, 0, 0, 0, time.UTC), *status.Last)
+	assert.Equal(t, time.Date(2019, 1, 3, 21, 0, 0, 0, time.UTC), *status.Next)
+	status, err = StatusOf("unbounded", *MustParseRepeatingIntervalISO8601("R/2019-01-04T21:00:00Z/PT1H"), now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 1, 2, 22, 0, 0, 0, time.UTC), *status.Last)
+	assert.Equal(t, time.Date(2019, 1, 2, 23, 0, 0, 0, time.UTC), *status.Next)
+}
+
+func TestNewStatusHandler(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+	assert.Nil(t, repo.Save(ctx, "hourly", *MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H")))
+	assert.Nil(t, repo.Save(ctx, "daily", *MustParseRepeatingIntervalISO8601("R/2019-01-01T00:00:00Z/P1D")))
+	assert.Nil(t, repo.SetPaused(ctx, "daily", true))
+	now := func() time.Time { return time.Date(2019, 1, 2, 22, 30, 0, 0, time.UTC) }
+
+	rec := httptest.NewRecorder()
+	NewStatusHandler(repo, now).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/schedules", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var statuses []ScheduleStatus
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &statuses))
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "daily", statuses[0].Name)
+	assert.Equal(t, time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC), *statuses[0].Next)
+	assert.True(t, statuses[0].Paused)
+	assert.Equal(t, "hourly", statuses[1].Name)
+	assert.False(t, statuses[1].Paused)
+	assert.Contains(t, rec.Body.String(), `"paused":true`)
+}
+
+type failingRepository struct {
+	ScheduleRepository
+}
+
+func (failingRepository) List(ctx context.Context) ([]string, error) {
+	return nil, errors.New("unavailable")
+}
+
+func TestNewStatusHandler_Error(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewStatusHandler(failingRepository{}, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/schedules", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
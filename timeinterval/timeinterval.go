@@ -3,14 +3,11 @@ package timeinterval
 import (
 	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
-var regexTimeStringISO = regexp.MustCompile("^(-?(?:[1-9][0-9]*)?[0-9]{4})-(1[0-2]|0[1-9])-(3[01]|0[1-9]|[12][0-9])T(2[0-3]|[01][0-9]):([0-5][0-9]):([0-5][0-9])(\\.[0-9]+)?(Z)?$")
-
 type formatType uint8
 
 // typeUnknown indicates that the given string has a format that is unknown and unsupported.
@@ -22,89 +19,235 @@ const typeTime formatType = 1
 // typeDuration indicates that the given string is am ISO8601 duration string
 const typeDuration formatType = 2
 
+// typeOpen indicates that the given string is the ISO8601-2 open bound ".."
+const typeOpen formatType = 3
+
 const durationWeek = 7 * 24 * time.Hour
 const durationDay = 24 * time.Hour
 
 // ParseIntervalISO8601 accepts a string with the ISO8601 "interval" format
 // and returns an Interval and an error if parsing of the string failed.
+// Input deviating from the specification is rejected. See: ParseIntervalISO8601WithOptions for lenient parsing.
 // See: ref: https://en.wikipedia.org/wiki/ISO_8601#Time_intervals
 func ParseIntervalISO8601(s string) (*Interval, error) {
+	return parseIntervalISO8601(s, ParseOptions{})
+}
+
+// MustParseIntervalISO8601 is like ParseIntervalISO8601 but panics if the string cannot be parsed.
+// It simplifies safe initialization of package-level variables holding intervals.
+func MustParseIntervalISO8601(s string) *Interval {
+	in, err := ParseIntervalISO8601(s)
+	if err != nil {
+		panic(fmt.Sprintf("timeinterval: ParseIntervalISO8601(%q): %v", s, err))
+	}
+	return in
+}
+
+func parseIntervalISO8601(s string, opts ParseOptions) (*Interval, error) {
+	in, err := parseInterval(s, opts)
+	if err != nil {
+		return in, newParseError(s, err, func(c string) error {
+			_, err := parseInterval(c, opts)
+			return err
+		})
+	}
+	return in, nil
+}
+
+func parseInterval(s string, opts ParseOptions) (*Interval, error) {
+	s = opts.normalize(s)
 	// Interval
-	parts := strings.Split(s, "/")
+	parts := splitParts(s)
 	if len(parts) != 2 {
-		return nil, errors.New("invalid interval format")
+		// The input must be consumed entirely, so extra parts are rejected at their separator.
+		offset := len(s)
+		if len(parts) > 2 {
+			offset = len(parts[0]) + 1 + len(parts[1])
+		}
+		return nil, atOffset(offset, errors.New("invalid interval format"))
+	}
+	// offsets holds the positions of the parts in s, which are kept for errors while the parts are expanded below.
+	offsets := [2]int{0, len(parts[0]) + 1}
+	// Times may name their time zone with an RFC 9557 suffix, e.g. "2019-01-02T21:00:00+01:00[Europe/Copenhagen]".
+	var zones [2]*time.Location
+	for i := range parts {
+		part, zone, err := splitIXDTF(parts[i])
+		if err != nil {
+			return nil, atOffset(offsets[i], err)
+		}
+		// Times may be given as ISO week or ordinal dates, e.g. "2019-W01-3T21:00:00Z" or "2019-002T21:00:00Z".
+		if part, err = expandDate(part); err != nil {
+			return nil, atOffset(offsets[i], err)
+		}
+		parts[i], zones[i] = part, zone
+	}
+	if isTimeStringISO(parts[0]) && zones[1] == nil && isConciseEnd(parts[1]) {
+		end, err := expandConciseEnd(parts[0], parts[1])
+		if err != nil {
+			return nil, atOffset(offsets[1], err)
+		}
+		if _, endZone := splitZone(parts[1]); zones[0] != nil && endZone == "" {
+			// The end is a wall-clock time in the named zone, which may have a different offset than the start.
+			end, _ = splitZone(end)
+		}
+		parts[1] = end
+		zones[1] = zones[0]
 	}
-	partTypes, err := identifyIntervalTypes(parts)
+	partTypes, err := identifyIntervalTypes(parts, offsets)
 	if err != nil {
 		return nil, err
 	}
 	if partTypes[0] == typeDuration && partTypes[1] == typeDuration {
-		return nil, errors.New("interval cannot consist of two durations")
+		return nil, atOffset(offsets[1], errors.New("interval cannot consist of two durations"))
 	}
+	if partTypes[0] == typeOpen || partTypes[1] == typeOpen {
+		return parseOpenInterval(parts, offsets, partTypes, zones[:], opts.DefaultLocation)
+	}
+	// The parsed values are kept in arrays outside the loop, so that pointers to them do not move them to the heap.
+	var times [2]time.Time
+	var durations [2]isoDuration
 	var startsAt, endsAt *time.Time
-	var duration *time.Duration
+	var period *isoDuration
+	var named bool
 	for i := 0; i < len(partTypes); i++ {
 		switch partTypes[i] {
 		case typeDuration:
-			d, err := parseDurationString(parts[i])
-			if err != nil {
-				return nil, err
+			if zones[i] != nil {
+				return nil, atOffset(offsets[i]+len(parts[i]), errors.New("duration cannot have a time zone suffix"))
 			}
-			duration = &d
-		case typeTime:
-			t, err := parseTimeString(parts[i])
-			if err != nil {
-				return nil, err
+			if durations[i], err = parseISODuration(parts[i]); err != nil {
+				return nil, atOffset(offsets[i], err)
 			}
-			if i == 0 {
-				startsAt = &t
-			} else {
-				endsAt = &t
+			period = &durations[i]
+		case typeTime:
+			named = named || zones[i] != nil
+			if times[i], err = parseZonedTimeString(parts[i], zones[i], opts.DefaultLocation); err != nil {
+				return nil, atOffset(offsets[i], err)
 			}
 		}
 	}
-	return NewInterval(startsAt, endsAt, duration)
+	if partTypes[0] == typeTime {
+		startsAt = &times[0]
+	}
+	if partTypes[1] == typeTime {
+		endsAt = &times[1]
+	}
+	if period != nil && (period.years != 0 || period.months != 0 || (named && (period.weeks != 0 || period.days != 0))) {
+		// Years and months do not have a fixed length and are kept as a calendar Period.
+		// In a named time zone the same holds for days and weeks since days vary in length across DST transitions.
+		in, err := NewPeriodInterval(startsAt, endsAt, period.period())
+		if err != nil {
+			return nil, atOffset(0, err)
+		}
+		return in, nil
+	}
+	var duration *time.Duration
+	if period != nil {
+		d := period.fixed()
+		duration = &d
+	}
+	in, err := NewInterval(startsAt, endsAt, duration)
+	if err != nil {
+		return nil, atOffset(0, err)
+	}
+	return in, nil
+}
+
+// parseOpenInterval parses an ISO8601-2 interval with an open start ("../Time") or end ("Time/..").
+func parseOpenInterval(parts []string, offsets [2]int, partTypes [2]formatType, zones []*time.Location, loc *time.Location) (*Interval, error) {
+	bound := 1
+	if partTypes[1] == typeOpen {
+		bound = 0
+	}
+	if partTypes[bound] != typeTime {
+		return nil, atOffset(offsets[bound], errors.New("open interval must be bounded by a time"))
+	}
+	t, err := parseZonedTimeString(parts[bound], zones[bound], loc)
+	if err != nil {
+		return nil, atOffset(offsets[bound], err)
+	}
+	if bound == 0 {
+		return NewOpenEndInterval(t), nil
+	}
+	return NewOpenStartInterval(t), nil
 }
 
 // ParseRepeatingIntervalISO8601 accepts a string with the ISO8601 "repeating interval" format
 // and returns a Repeating and an error if parsing of the string failed.
+// Input deviating from the specification is rejected. See: ParseRepeatingIntervalISO8601WithOptions for lenient parsing.
 // See: ref: https://en.wikipedia.org/wiki/ISO_8601#Repeating_intervals
 func ParseRepeatingIntervalISO8601(s string) (*Repeating, error) {
+	return parseRepeatingIntervalISO8601(s, ParseOptions{})
+}
+
+// MustParseRepeatingIntervalISO8601 is like ParseRepeatingIntervalISO8601 but panics if the string cannot be parsed.
+// It simplifies safe initialization of package-level variables holding schedules.
+func MustParseRepeatingIntervalISO8601(s string) *Repeating {
+	r, err := ParseRepeatingIntervalISO8601(s)
+	if err != nil {
+		panic(fmt.Sprintf("timeinterval: ParseRepeatingIntervalISO8601(%q): %v", s, err))
+	}
+	return r
+}
+
+func parseRepeatingIntervalISO8601(s string, opts ParseOptions) (*Repeating, error) {
+	r, err := parseRepeating(s, opts)
+	if err != nil {
+		return r, newParseError(s, err, func(c string) error {
+			_, err := parseRepeating(c, opts)
+			return err
+		})
+	}
+	return r, nil
+}
+
+func parseRepeating(s string, opts ParseOptions) (*Repeating, error) {
+	s = opts.normalize(s)
 	if !strings.HasPrefix(s, "R") {
-		return nil, errors.New("invalid repeating interval format")
+		return nil, atOffset(0, errors.New("invalid repeating interval format"))
 	}
 	ri := Repeating{}
 	// Split the "Repetition" and "Interval" parts of the string.
-	parts := strings.SplitN(s, "/", 2)
-	repetitionString := parts[0]
-	intervalString := parts[1]
+	sep := strings.IndexByte(s, '/')
+	if sep < 0 {
+		return nil, atOffset(len(s), errors.New("invalid repeating interval format"))
+	}
+	repetitionString := s[:sep]
+	intervalString := s[sep+1:]
 	// Set "Repetitions"
-	if len(repetitionString) > 1 {
-		n, err := strconv.Atoi(repetitionString[1:])
+	// ISO8601-2 denotes unbounded repetitions with "R-1", which is equivalent to "R".
+	if repetitionString == "R-1" {
+		ri.Format = RepeatFormatMinusOne
+	} else if len(repetitionString) > 1 {
+		n, err := strconv.ParseUint(repetitionString[1:], 10, 32)
 		if err != nil {
-			return nil, err
+			return nil, atOffset(1, err)
 		}
 		repetitions := uint32(n)
 		ri.Repetitions = &repetitions
 	}
 	// Set "Interval"
-	in, err := ParseIntervalISO8601(intervalString)
+	in, err := parseInterval(intervalString, opts)
 	if err != nil {
-		return nil, err
+		return nil, atOffset(sep+1, err)
+	}
+	if err := validateRepeating(*in, opts); err != nil {
+		return nil, atOffset(sep+1, err)
 	}
 	ri.Interval = *in
 	return &ri, nil
 }
 
-func identifyIntervalTypes(parts []string) ([]formatType, error) {
-	types := make([]formatType, len(parts))
+// identifyIntervalTypes returns the format types of the parts of an interval, which begin at the given offsets.
+func identifyIntervalTypes(parts []string, offsets [2]int) ([2]formatType, error) {
+	var types [2]formatType
 	for i := 0; i < len(parts); i++ {
 		ft, err := identifyType(parts[i])
 		if err != nil {
-			return nil, err
+			return types, atOffset(offsets[i], err)
 		}
 		if ft == typeUnknown {
-			return types, errors.New("invalid interval format")
+			return types, atOffset(offsets[i], errors.New("invalid interval format"))
 		}
 		types[i] = ft
 	}
@@ -112,58 +255,257 @@ func identifyIntervalTypes(parts []string) ([]formatType, error) {
 }
 
 func identifyType(s string) (formatType, error) {
-	if regexTimeStringISO.MatchString(s) {
+	if isTimeStringISO(s) {
 		return typeTime, nil
 	}
 	if strings.HasPrefix(s, "P") {
 		return typeDuration, nil
 	}
+	if s == ".." {
+		return typeOpen, nil
+	}
 	return typeUnknown, errors.New("invalid/unknown format")
 }
 
-func parseTimeString(s string) (time.Time, error) {
-	return time.Parse(time.RFC3339, s)
+// timeLayoutMinutes is the RFC3339 layout for times without seconds.
+const timeLayoutMinutes = "2006-01-02T15:04Z07:00"
+
+// timeLayoutLocal and timeLayoutLocalMinutes are the layouts for times without a time zone designator.
+const timeLayoutLocal = "2006-01-02T15:04:05"
+const timeLayoutLocalMinutes = "2006-01-02T15:04"
+
+// parseTimeString parses an ISO8601 time string. Times without a time zone designator are interpreted
+// in the given location and rejected if it is nil.
+func parseTimeString(s string, loc *time.Location) (time.Time, error) {
+	date, zone := splitZone(s)
+	// The layout is chosen by the length of the time, as times without seconds are shorter than any other.
+	minutes := len(date) == len(timeLayoutLocalMinutes)
+	if zone == "" && loc != nil {
+		if minutes {
+			return time.ParseInLocation(timeLayoutLocalMinutes, s, loc)
+		}
+		return time.ParseInLocation(timeLayoutLocal, s, loc)
+	}
+	layout := time.RFC3339
+	if minutes {
+		layout = timeLayoutMinutes
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil || t.Location() == time.UTC {
+		return t, err
+	}
+	// time.Parse uses the Local location when the offset matches it. Times derived from t (e.g. by adding
+	// a duration) would then change their offset across DST transitions, so the parsed offset is pinned instead.
+	_, offset := t.Zone()
+	return t.In(fixedZone(offset)), nil
+}
+
+// isConciseEnd returns a boolean indicating if s can be the abbreviated end of an interval (e.g. "15:30" in
+// "2007-11-13T09:00/15:30") which omits the components and/or the time zone it shares with the start.
+func isConciseEnd(s string) bool {
+	if s == "" || s == ".." || strings.HasPrefix(s, "P") {
+		return false
+	}
+	_, zone := splitZone(s)
+	return zone == "" || !isTimeStringISO(s)
+}
+
+// expandConciseEnd returns the abbreviated end with the omitted leading components (and the time zone) taken
+// from the given start.
+func expandConciseEnd(start, end string) (string, error) {
+	startTime, startZone := splitZone(start)
+	endTime, endZone := splitZone(end)
+	if endZone == "" {
+		endZone = startZone
+	}
+	// The precision of the end is given by the end itself, so fractional seconds of the start are dropped.
+	if dot := strings.IndexByte(startTime, '.'); dot >= 0 {
+		startTime = startTime[:dot]
+	}
+	endComponents := endTime
+	if dot := strings.IndexByte(endComponents, '.'); dot >= 0 {
+		endComponents = endComponents[:dot]
+	}
+	cut := len(startTime) - len(endComponents)
+	// The end must replace whole components of the start.
+	if cut < 0 || (cut > 0 && strings.IndexByte("-T:", startTime[cut-1]) < 0) {
+		return "", errors.New("invalid interval end format")
+	}
+	expanded := startTime[:cut] + endTime + endZone
+	if !isTimeStringISO(expanded) {
+		return "", errors.New("invalid interval end format")
+	}
+	return expanded, nil
+}
+
+// splitZone splits an ISO8601 time string into its date-time and time zone designator ("Z" or "+hh:mm").
+func splitZone(s string) (string, string) {
+	if strings.HasSuffix(s, "Z") {
+		return s[:len(s)-1], "Z"
+	}
+	if i := strings.LastIndexAny(s, "+-"); i >= 0 && i > strings.IndexByte(s, ':') && strings.IndexByte(s, ':') >= 0 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+// isoDuration holds the components of an ISO8601 duration string (PnYnMnWnDTnHnMnS).
+// A decimal fraction of the smallest given week, day or time component is stored in "fraction". ISO8601 allows both
+// a comma and a dot as the decimal separator (e.g. PT0,5H and PT0.5H).
+type isoDuration struct {
+	years, months, weeks, days, hours, minutes, seconds int
+	fraction                                            time.Duration
 }
 
-func parseDurationString(s string) (time.Duration, error) {
-	d := time.Duration(0)
+// parseISODuration parses an ISO8601 duration string. Errors are annotated with the offset of the invalid character.
+// See: ref: https://en.wikipedia.org/wiki/ISO_8601#Durations
+func parseISODuration(s string) (isoDuration, error) {
+	d := isoDuration{}
 	if !strings.HasPrefix(s, "P") {
-		return d, errors.New("invalid duration format")
+		return d, atOffset(0, errors.New("invalid duration format"))
 	}
-	// Exclude Duration indicator-char
+	// Designators must appear in this order in the date and time part respectively.
+	designators := "YMWD"
+	next := 0
+	inTime := false
+	components := 0
+	fractional := false
+	// countStr is the number preceding the current designator, sliced from s to avoid allocations.
 	countStr := ""
-	runes := []rune(s[1:])
-	// Iterate runes and calculate Duration
-	var currentCount = 1
-	for i := 0; i < len(runes); i++ {
-		c := string(runes[i])
-		switch c {
-		case "W":
-			{
-				countStr = ""
-				d += time.Duration(currentCount) * durationWeek
+	countStart := 1
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if (c >= '0' && c <= '9') || c == '.' || c == ',' {
+			countStr = s[countStart : i+1]
+			continue
+		}
+		countStart = i + 1
+		if c == 'T' {
+			if inTime || countStr != "" {
+				return d, atOffset(i, errors.New("invalid duration format"))
 			}
-		case "D":
-			{
-				countStr = ""
-				d += time.Duration(currentCount) * durationDay
+			inTime = true
+			designators = "HMS"
+			next = 0
+			continue
+		}
+		idx := strings.IndexByte(designators[next:], c)
+		if idx < 0 || countStr == "" {
+			return d, atOffset(i, errors.New("invalid duration format"))
+		}
+		// Only the smallest (last) component may have a decimal fraction.
+		if fractional {
+			return d, atOffset(i-len(countStr), errors.New("only the smallest duration component may have a fraction"))
+		}
+		intStr, fracStr := countStr, ""
+		if sep := strings.IndexAny(countStr, ".,"); sep >= 0 {
+			intStr, fracStr = countStr[:sep], countStr[sep+1:]
+			if intStr == "" || fracStr == "" || strings.IndexAny(fracStr, ".,") >= 0 {
+				return d, atOffset(i-len(countStr), errors.New("invalid duration format"))
 			}
-		default:
-			countStr += c
-			// Calculate "Count"
-			count, err := strconv.Atoi(countStr)
-			if err != nil {
-				return d, err
+			fractional = true
+		}
+		count, err := strconv.Atoi(intStr)
+		if err != nil {
+			return d, atOffset(i-len(countStr), err)
+		}
+		var unit time.Duration
+		switch {
+		case inTime && c == 'H':
+			d.hours, unit = count, time.Hour
+		case inTime && c == 'M':
+			d.minutes, unit = count, time.Minute
+		case inTime && c == 'S':
+			d.seconds, unit = count, time.Second
+		case c == 'Y':
+			d.years = count
+		case c == 'M':
+			d.months = count
+		case c == 'W':
+			d.weeks, unit = count, durationWeek
+		case c == 'D':
+			d.days, unit = count, durationDay
+		}
+		if fractional {
+			if unit == 0 {
+				return d, atOffset(i-len(countStr), errors.New("fractional years and months are not supported"))
 			}
-			currentCount = count
+			d.fraction = parseFraction(fracStr, unit)
 		}
+		next += idx + 1
+		components++
+		countStr = ""
+	}
+	if countStr != "" || components == 0 || strings.HasSuffix(s, "T") {
+		return d, atOffset(len(s), errors.New("invalid duration format"))
 	}
 	return d, nil
 }
 
-func durationToISO8601(d time.Duration) (string, error) {
+// parseFraction returns the decimal fraction given by its digits (e.g. "25" for .25) of the given unit.
+// Precision beyond one nanosecond is truncated.
+func parseFraction(digits string, unit time.Duration) time.Duration {
+	fraction := time.Duration(0)
+	for i := 0; i < len(digits) && unit > 0; i++ {
+		unit /= 10
+		fraction += time.Duration(digits[i]-'0') * unit
+	}
+	return fraction
+}
+
+// fixed returns the weeks, days, hours, minutes and seconds of the duration as a time.Duration.
+// Days are treated as 24 hours.
+func (d isoDuration) fixed() time.Duration {
+	return time.Duration(d.weeks)*durationWeek +
+		time.Duration(d.days)*durationDay +
+		time.Duration(d.hours)*time.Hour +
+		time.Duration(d.minutes)*time.Minute +
+		time.Duration(d.seconds)*time.Second +
+		d.fraction
+}
+
+// period returns the duration as a calendar Period. Weeks are converted to days.
+func (d isoDuration) period() Period {
+	return Period{
+		Years:  d.years,
+		Months: d.months,
+		Days:   d.weeks*7 + d.days,
+		Time: time.Duration(d.hours)*time.Hour +
+			time.Duration(d.minutes)*time.Minute +
+			time.Duration(d.seconds)*time.Second +
+			d.fraction,
+	}
+}
+
+// ParseDurationISO8601 parses an ISO8601 duration string (e.g. P3D or PT1H30M) into a fixed time.Duration.
+// An error is returned if the duration contains years or months since they do not have a fixed length.
+// See: ParsePeriodISO8601 for calendar durations.
+func ParseDurationISO8601(s string) (time.Duration, error) {
+	d, err := parseISODuration(s)
+	if err != nil {
+		return 0, newParseError(s, err, func(c string) error {
+			_, err := parseISODuration(c)
+			return err
+		})
+	}
+	if d.years != 0 || d.months != 0 {
+		return 0, errors.New("duration with years or months cannot be represented as a fixed duration")
+	}
+	return d.fixed(), nil
+}
+
+// FormatDurationISO8601 formats d as an ISO8601 duration string using weeks, days, hours, minutes and seconds.
+// The result is the shortest exact representation (e.g. PT1H30M or P1DT6H) and a zero duration is formatted as PT0S.
+// An error is returned for negative durations since they cannot be represented.
+func FormatDurationISO8601(d time.Duration) (string, error) {
 	durationLeft := d
 	iso := "P"
+	if durationLeft < 0 {
+		return iso, errors.New("negative durations cannot be represented")
+	}
+	if durationLeft == 0 {
+		return "PT0S", nil
+	}
 	if durationLeft >= durationWeek {
 		iso += fmt.Sprintf("%dW", durationLeft/durationWeek)
 		durationLeft -= (durationLeft / durationWeek) * durationWeek
@@ -173,7 +515,35 @@ func durationToISO8601(d time.Duration) (string, error) {
 		durationLeft -= (durationLeft / durationDay) * durationDay
 	}
 	if durationLeft != 0 {
-		return iso, errors.New("duration could not be represented with the supported duration types")
+		iso += "T" + timeComponentsISO8601(durationLeft)
 	}
 	return iso, nil
 }
+
+// timeComponentsISO8601 formats d as the hour, minute and second components of an ISO8601 duration (e.g. 1H30M0.5S).
+func timeComponentsISO8601(d time.Duration) string {
+	durationLeft := d
+	s := ""
+	if durationLeft >= time.Hour {
+		s += fmt.Sprintf("%dH", durationLeft/time.Hour)
+		durationLeft -= (durationLeft / time.Hour) * time.Hour
+	}
+	if durationLeft >= time.Minute {
+		s += fmt.Sprintf("%dM", durationLeft/time.Minute)
+		durationLeft -= (durationLeft / time.Minute) * time.Minute
+	}
+	if durationLeft != 0 {
+		s += formatSeconds(durationLeft) + "S"
+	}
+	return s
+}
+
+// formatSeconds formats d (less than a minute) as seconds with the fraction needed to represent it exactly.
+func formatSeconds(d time.Duration) string {
+	seconds := d / time.Second
+	nanos := d % time.Second
+	if nanos == 0 {
+		return strconv.Itoa(int(seconds))
+	}
+	return strings.TrimRight(fmt.Sprintf("%d.%09d", seconds, nanos), "0")
+}
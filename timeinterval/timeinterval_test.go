@@ -1,6 +1,8 @@
 package timeinterval
 
 import (
+	"encoding/json"
+	"strconv"
 	"testing"
 	"time"
 
@@ -52,6 +54,11 @@ func TestParseRepeatingIntervalISO8601(t *testing.T) {
 			Repetitions: &repetitions,
 			Interval:    Interval{StartsAt: endsAt.Add(-duration), EndsAt: endsAt, Format: ISOFormatDurationAndTime},
 		}, // Duration - Time
+		"R-1/P1W/2022-01-03T21:00:00Z": {
+			Repetitions: nil,
+			Interval:    Interval{StartsAt: endsAt.Add(-duration), EndsAt: endsAt, Format: ISOFormatDurationAndTime},
+			Format:      RepeatFormatMinusOne,
+		}, // Duration - Time
 	}
 	for given, expected := range expectations {
 		result, err := ParseRepeatingIntervalISO8601(given)
@@ -59,3 +66,167 @@ func TestParseRepeatingIntervalISO8601(t *testing.T) {
 		assert.Equal(t, &expected, result)
 	}
 }
+
+func TestParseISO8601_FullDuration(t *testing.T) {
+	startsAt, err := time.Parse(time.RFC3339, "2019-01-02T21:00:00Z")
+	assert.Nil(t, err)
+	endsAt, err := time.Parse(time.RFC3339, "2020-03-06T01:05:06Z")
+	assert.Nil(t, err)
+
+	result, err := ParseIntervalISO8601("2019-01-02T21:00:00Z/P1Y2M3DT4H5M6S")
+	assert.Nil(t, err)
+	assert.True(t, startsAt.Equal(result.StartsAt))
+	assert.True(t, endsAt.Equal(result.EndsAt))
+	assert.Equal(t, ISOFormatTimeAndDuration, result.Format)
+
+	result, err = ParseIntervalISO8601("P1Y2M3DT4H5M6S/2020-03-06T01:05:06Z")
+	assert.Nil(t, err)
+	assert.True(t, startsAt.Equal(result.StartsAt))
+	assert.True(t, endsAt.Equal(result.EndsAt))
+	assert.Equal(t, ISOFormatDurationAndTime, result.Format)
+
+	ri, err := ParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT15M")
+	assert.Nil(t, err)
+	assert.Equal(t, 15*time.Minute, ri.RepeatEvery())
+}
+
+func TestParseDurationISO8601(t *testing.T) {
+	expectations := map[string]time.Duration{
+		"P1W":          durationWeek,
+		"P2W3D":        2*durationWeek + 3*durationDay,
+		"P1DT12H":      36 * time.Hour,
+		"PT1H30M":      90 * time.Minute,
+		"PT36H":        36 * time.Hour,
+		"PT90S":        90 * time.Second,
+		"P0D":          0,
+		"P3DT4H5M6S":   3*durationDay + 4*time.Hour + 5*time.Minute + 6*time.Second,
+		"PT5M":         5 * time.Minute,
+		"P10DT0H0M10S": 10*durationDay + 10*time.Second,
+	}
+	for given, expected := range expectations {
+		result, err := ParseDurationISO8601(given)
+		assert.Nil(t, err, given)
+		assert.Equal(t, expected, result, given)
+	}
+	invalid := []string{"", "P", "PT", "P1", "P1DT", "PD", "P1H", "PT1D", "P1D1Y", "PT1S1M", "P1DT1H1H", "P1TD", "1D", "P1M", "P1Y"}
+	for _, given := range invalid {
+		_, err := ParseDurationISO8601(given)
+		assert.NotNil(t, err, given)
+	}
+}
+
+func TestParseDurationISO8601_Fraction(t *testing.T) {
+	expectations := map[string]time.Duration{
+		"PT0.5S":          500 * time.Millisecond,
+		"PT1.25H":         75 * time.Minute,
+		"PT1.5M":          90 * time.Second,
+		"P1.5D":           36 * time.Hour,
+		"P0.5W":           84 * time.Hour,
+		"PT1M0.000001S":   time.Minute + time.Microsecond,
+		"PT0.0000000019S": time.Nanosecond,
+		"PT0,5S":          500 * time.Millisecond,
+		"PT1,25H":         75 * time.Minute,
+		"P1,5D":           36 * time.Hour,
+		"P0,5W":           84 * time.Hour,
+		"PT1M0,000001S":   time.Minute + time.Microsecond,
+	}
+	for given, expected := range expectations {
+		result, err := ParseDurationISO8601(given)
+		assert.Nil(t, err, given)
+		assert.Equal(t, expected, result, given)
+	}
+	invalid := []string{"PT.5S", "PT1.S", "PT1.5H30M", "P1.5Y", "P0.5M", "PT1..5S", "PT1.5.5S", "PT,5S", "PT1,S", "PT1,5,5S", "PT1.5,5S", "P1,5Y"}
+	for _, given := range invalid {
+		_, err := ParseDurationISO8601(given)
+		assert.NotNil(t, err, given)
+	}
+}
+
+func TestFormatDurationISO8601(t *testing.T) {
+	expectations := map[time.Duration]string{
+		durationWeek:                   "P1W",
+		durationWeek + 36*time.Hour:    "P1W1DT12H",
+		30 * time.Hour:                 "P1DT6H",
+		90 * time.Minute:               "PT1H30M",
+		0:                              "PT0S",
+		75 * time.Minute:               "PT1H15M",
+		500 * time.Millisecond:         "PT0.5S",
+		time.Minute + time.Microsecond: "PT1M0.000001S",
+		2*time.Hour + 3*time.Second + time.Nanosecond: "PT2H3.000000001S",
+	}
+	for given, expected := range expectations {
+		result, err := FormatDurationISO8601(given)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+		d, err := ParseDurationISO8601(result)
+		assert.Nil(t, err)
+		assert.Equal(t, given, d)
+	}
+	_, err := FormatDurationISO8601(-time.Second)
+	assert.NotNil(t, err)
+}
+
+func TestParseISO8601_Offsets(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	local := time.Local
+	time.Local = loc
+	defer func() { time.Local = local }()
+
+	expectations := map[string]string{
+		"2019-03-30T12:00:00+01:00/P2D": "2019-04-01T12:00:00+01:00",
+		"P2D/2019-04-01T12:00:00+02:00": "2019-03-30T12:00:00+02:00",
+		"2019-01-02T21:00:00-05:30/P1W": "2019-01-09T21:00:00-05:30",
+	}
+	for given, expected := range expectations {
+		in, err := ParseIntervalISO8601(given)
+		assert.Nil(t, err)
+		if in.Format == ISOFormatTimeAndDuration {
+			assert.Equal(t, expected, in.EndsAt.Format(time.RFC3339))
+		} else {
+			assert.Equal(t, expected, in.StartsAt.Format(time.RFC3339))
+		}
+		b, err := json.Marshal(in)
+		assert.Nil(t, err)
+		assert.Equal(t, strconv.Quote(given), string(b))
+	}
+}
+
+func TestParseRepeatingIntervalISO8601_InvalidRepetitions(t *testing.T) {
+	for _, given := range []string{"R-2/P1W/2022-01-03T21:00:00Z", "R4294967296/P1W/2022-01-03T21:00:00Z", "Rx/P1W/2022-01-03T21:00:00Z"} {
+		_, err := ParseRepeatingIntervalISO8601(given)
+		assert.NotNil(t, err, given)
+	}
+}
+
+func TestMustParseIntervalISO8601(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1D")
+	assert.Equal(t, ISOFormatTimeAndDuration, in.Format)
+	assert.PanicsWithValue(t, `timeinterval: ParseIntervalISO8601("P1D"): invalid interval format`, func() {
+		MustParseIntervalISO8601("P1D")
+	})
+}
+
+func TestMustParseRepeatingIntervalISO8601(t *testing.T) {
+	r := MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/P1D")
+	assert.Equal(t, uint32(5), *r.Repetitions)
+	assert.Panics(t, func() {
+		MustParseRepeatingIntervalISO8601("R5/P1D/P1D")
+	})
+}
+
+func BenchmarkParseIntervalISO8601(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ParseIntervalISO8601("2019-01-02T21:00:00Z/P1DT2H30M")
+	}
+}
+
+func BenchmarkParseRepeatingIntervalISO8601(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/2019-01-03T21:00:00Z")
+	}
+}
@@ -0,0 +1,100 @@
+package timeinterval
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken is returned by VerifyScheduleToken for malformed tokens and tokens with an invalid signature.
+var ErrInvalidToken = errors.New("invalid schedule token")
+
+// ErrTokenExpired is returned by VerifyScheduleToken for tokens verified after their expiry.
+var ErrTokenExpired = errors.New("schedule token expired")
+
+// scheduleClaims is the payload of a schedule token.
+type scheduleClaims struct {
+	// Repeating is the schedule formatted as an ISO8601 repeating interval string.
+	Repeating string `json:"r,omitempty"`
+	// RRule and Start hold the schedule as an RFC 5545 recurrence rule and its start in RFC3339.
+	RRule string `json:"rrule,omitempty"`
+	Start string `json:"start,omitempty"`
+	// Expiry is the Unix time in seconds after which the token is invalid.
+	Expiry int64 `json:"exp"`
+}
+
+// SignSchedule returns a compact token holding the schedule and its expiry signed with HMAC-SHA256, so that a
+// schedule granted by one service (e.g. "you may access during this window") can be verified by another sharing
+// the key without shared storage. The token is URL-safe and has the form payload.signature.
+// Repeating and Recurrence schedules are supported.
+func SignSchedule(s Schedule, key []byte, expiresAt time.Time) (string, error) {
+	claims := scheduleClaims{Expiry: expiresAt.Unix()}
+	switch v := s.(type) {
+	case *Repeating:
+		return SignSchedule(*v, key, expiresAt)
+	case *Recurrence:
+		return SignSchedule(*v, key, expiresAt)
+	case Repeating:
+		iso, err := v.ISO8601()
+		if err != nil {
+			return "", err
+		}
+		claims.Repeating = iso
+	case Recurrence:
+		if err := v.Validate(); err != nil {
+			return "", err
+		}
+		claims.RRule, claims.Start = v.RRule(), v.Start.Format(time.RFC3339Nano)
+	default:
+		return "", errors.New("schedule cannot be signed")
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signToken(encoded, key)), nil
+}
+
+// VerifyScheduleToken returns the schedule of a token created by SignSchedule with the same key. It returns
+// ErrInvalidToken if the token is malformed or its signature is invalid, and ErrTokenExpired if it expired before now.
+// The schedule is a *Repeating or *Recurrence.
+func VerifyScheduleToken(token string, key []byte, now time.Time) (Schedule, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, signToken(parts[0], key)) {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims scheduleClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if now.Unix() > claims.Expiry {
+		return nil, ErrTokenExpired
+	}
+	if claims.Repeating != "" {
+		return ParseRepeatingIntervalISO8601(claims.Repeating)
+	}
+	start, err := time.Parse(time.RFC3339Nano, claims.Start)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	return ParseRecurrence(claims.RRule, start)
+}
+
+func signToken(payload string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
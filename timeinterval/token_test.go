@@ -0,0 +1,48 @@
+package timeinterval
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignSchedule(t *testing.T) {
+	key := []byte("secret")
+	now := time.Date(2019, 1, 2, 12, 0, 0, 0, time.UTC)
+	r := MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT1H")
+	token, err := SignSchedule(r, key, now.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(strings.Split(token, ".")))
+
+	s, err := VerifyScheduleToken(token, key, now)
+	assert.Nil(t, err)
+	assert.Equal(t, r, s)
+
+	_, err = VerifyScheduleToken(token, key, now.Add(2*time.Hour))
+	assert.Equal(t, ErrTokenExpired, err)
+	_, err = VerifyScheduleToken(token, []byte("other"), now)
+	assert.Equal(t, ErrInvalidToken, err)
+	// Tampering with the payload invalidates the signature.
+	other, err := SignSchedule(MustParseRepeatingIntervalISO8601("R/2019-01-02T21:00:00Z/PT1H"), []byte("other"), now.Add(time.Hour))
+	assert.Nil(t, err)
+	_, err = VerifyScheduleToken(strings.Split(other, ".")[0]+"."+strings.Split(token, ".")[1], key, now)
+	assert.Equal(t, ErrInvalidToken, err)
+	for _, given := range []string{"", "a.b.c", token + "x", "!." + strings.Split(token, ".")[1]} {
+		_, err = VerifyScheduleToken(given, key, now)
+		assert.Equal(t, ErrInvalidToken, err, given)
+	}
+
+	rec, err := ParseRecurrence("FREQ=WEEKLY;BYDAY=MO,WE", time.Date(2019, 1, 2, 9, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	token, err = SignSchedule(*rec, key, now.Add(time.Hour))
+	assert.Nil(t, err)
+	s, err = VerifyScheduleToken(token, key, now)
+	assert.Nil(t, err)
+	assert.Equal(t, rec.RRule(), s.(*Recurrence).RRule())
+	assert.Equal(t, rec.Next(now), s.Next(now))
+
+	_, err = SignSchedule(&AdaptiveSchedule{}, key, now)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,109 @@
+package timeinterval
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// minUnixNano and maxUnixNano are the earliest and latest times representable as int64 Unix nanoseconds.
+var minUnixNano = time.Unix(0, math.MinInt64+1)
+var maxUnixNano = time.Unix(0, math.MaxInt64-1)
+
+// FromUnix returns the interval between the given Unix times in seconds. The times are in UTC.
+func FromUnix(startSec, endSec int64) (*Interval, error) {
+	startsAt := time.Unix(startSec, 0).UTC()
+	endsAt := time.Unix(endSec, 0).UTC()
+	return NewInterval(&startsAt, &endsAt, nil)
+}
+
+// FromUnixMilli returns the interval between the given Unix times in milliseconds. The times are in UTC.
+func FromUnixMilli(startMs, endMs int64) (*Interval, error) {
+	startsAt := unixMilli(startMs)
+	endsAt := unixMilli(endMs)
+	return NewInterval(&startsAt, &endsAt, nil)
+}
+
+// Unix returns the start and end of the interval as Unix times in seconds. Fractional seconds are truncated.
+// Open bounds have no Unix time, so ErrOpenInterval is returned for open intervals. Pack encodes open bounds
+// explicitly.
+func (in Interval) Unix() (start, end int64, err error) {
+	if in.OpenStart() || in.OpenEnd() {
+		return 0, 0, ErrOpenInterval
+	}
+	return in.StartsAt.Unix(), in.EndsAt.Unix(), nil
+}
+
+// UnixMilli returns the start and end of the interval as Unix times in milliseconds. Like Unix, it returns
+// ErrOpenInterval for open intervals.
+func (in Interval) UnixMilli() (start, end int64, err error) {
+	if in.OpenStart() || in.OpenEnd() {
+		return 0, 0, ErrOpenInterval
+	}
+	return toUnixMilli(in.StartsAt), toUnixMilli(in.EndsAt), nil
+}
+
+// Pack returns the interval encoded as 16 bytes holding the start and end as big-endian Unix nanoseconds with
+// flipped sign bits, so that packed intervals sort bytewise by start and then end (e.g. as database keys).
+// Open bounds are encoded as the smallest respectively largest value. An error is returned if a time cannot be
+// represented as int64 Unix nanoseconds (years 1678 to 2262).
+func (in Interval) Pack() ([16]byte, error) {
+	var b [16]byte
+	start, end, err := in.unixNano()
+	if err != nil {
+		return b, err
+	}
+	binary.BigEndian.PutUint64(b[:8], uint64(start)^(1<<63))
+	binary.BigEndian.PutUint64(b[8:], uint64(end)^(1<<63))
+	return b, nil
+}
+
+// Unpack returns the interval encoded by Pack. The times are in UTC.
+func Unpack(b [16]byte) (*Interval, error) {
+	start := int64(binary.BigEndian.Uint64(b[:8]) ^ (1 << 63))
+	end := int64(binary.BigEndian.Uint64(b[8:]) ^ (1 << 63))
+	return fromUnixNano(start, end)
+}
+
+// unixNano returns the start and end of the interval as Unix nanoseconds with open bounds as the smallest
+// respectively largest int64.
+func (in Interval) unixNano() (int64, int64, error) {
+	start, end := int64(math.MinInt64), int64(math.MaxInt64)
+	if !in.OpenStart() {
+		if in.StartsAt.Before(minUnixNano) || in.StartsAt.After(maxUnixNano) {
+			return 0, 0, errors.New("start cannot be represented in Unix nanoseconds")
+		}
+		start = in.StartsAt.UnixNano()
+	}
+	if !in.OpenEnd() {
+		if in.EndsAt.Before(minUnixNano) || in.EndsAt.After(maxUnixNano) {
+			return 0, 0, errors.New("end cannot be represented in Unix nanoseconds")
+		}
+		end = in.EndsAt.UnixNano()
+	}
+	return start, end, nil
+}
+
+// fromUnixNano returns the interval between the given Unix nanoseconds as returned by unixNano. The times are in UTC.
+func fromUnixNano(start, end int64) (*Interval, error) {
+	switch {
+	case start == math.MinInt64 && end == math.MaxInt64:
+		return nil, errors.New("interval cannot be open at both ends")
+	case start == math.MinInt64:
+		return NewOpenStartInterval(time.Unix(0, end).UTC()), nil
+	case end == math.MaxInt64:
+		return NewOpenEndInterval(time.Unix(0, start).UTC()), nil
+	}
+	startsAt := time.Unix(0, start).UTC()
+	endsAt := time.Unix(0, end).UTC()
+	return NewInterval(&startsAt, &endsAt, nil)
+}
+
+func unixMilli(ms int64) time.Time {
+	return time.Unix(ms/1000, ms%1000*int64(time.Millisecond)).UTC()
+}
+
+func toUnixMilli(t time.Time) int64 {
+	return t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
+}
@@ -0,0 +1,77 @@
+package timeinterval
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromUnix(t *testing.T) {
+	in, err := FromUnix(1546462800, 1641243600)
+	assert.Nil(t, err)
+	assert.Equal(t, MustParseIntervalISO8601("2019-01-02T21:00:00Z/2022-01-03T21:00:00Z"), in)
+	start, end, err := in.Unix()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1546462800), start)
+	assert.Equal(t, int64(1641243600), end)
+
+	_, err = FromUnix(1641243600, 1546462800)
+	assert.NotNil(t, err)
+
+	// Open bounds have no Unix time.
+	_, _, err = NewOpenEndInterval(in.StartsAt).Unix()
+	assert.Equal(t, ErrOpenInterval, err)
+	_, _, err = NewOpenStartInterval(in.EndsAt).UnixMilli()
+	assert.Equal(t, ErrOpenInterval, err)
+}
+
+func TestFromUnixMilli(t *testing.T) {
+	in, err := FromUnixMilli(1546462800250, 1546462801500)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 1, 2, 21, 0, 0, 250000000, time.UTC), in.StartsAt)
+	assert.Equal(t, 1250*time.Millisecond, in.Duration())
+	start, end, err := in.UnixMilli()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1546462800250), start)
+	assert.Equal(t, int64(1546462801500), end)
+
+	in, err = FromUnixMilli(-1500, -500)
+	assert.Nil(t, err)
+	start, end, err = in.UnixMilli()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1500), start)
+	assert.Equal(t, int64(-500), end)
+}
+
+func TestInterval_Pack(t *testing.T) {
+	expectations := []*Interval{
+		MustParseIntervalISO8601("2019-01-02T21:00:00.5Z/2022-01-03T21:00:00Z"),
+		MustParseIntervalISO8601("1960-01-02T21:00:00Z/PT1H"),
+		MustParseIntervalISO8601("2019-01-02T21:00:00Z/.."),
+		MustParseIntervalISO8601("../2019-01-02T21:00:00Z"),
+	}
+	for _, in := range expectations {
+		b, err := in.Pack()
+		assert.Nil(t, err)
+		result, err := Unpack(b)
+		assert.Nil(t, err)
+		assert.True(t, result.StartsAt.Equal(in.StartsAt))
+		assert.True(t, result.EndsAt.Equal(in.EndsAt))
+		assert.Equal(t, in.OpenStart(), result.OpenStart())
+		assert.Equal(t, in.OpenEnd(), result.OpenEnd())
+	}
+
+	// Packed intervals sort by start.
+	a, _ := expectations[1].Pack()
+	b, _ := expectations[0].Pack()
+	c, _ := expectations[3].Pack()
+	assert.Equal(t, -1, bytes.Compare(a[:], b[:]))
+	assert.Equal(t, -1, bytes.Compare(c[:], a[:]))
+
+	_, err := MustParseIntervalISO8601("2300-01-02T21:00:00Z/PT1H").Pack()
+	assert.NotNil(t, err)
+	_, err = Unpack([16]byte{0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,138 @@
+package timeinterval
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+type dstPolicy uint8
+
+// DSTShift moves wall-clock times that do not exist on a day (because the clocks are set forward) forward
+// by the length of the gap, e.g. 02:30 becomes 03:30.
+const DSTShift dstPolicy = 0
+
+// DSTSkip skips wall-clock times on days where they do not exist (because the clocks are set forward).
+const DSTSkip dstPolicy = 1
+
+// WallClockSchedule is a Schedule with daily occurrences at fixed wall-clock times in a location.
+// Wall-clock times that occur twice on a day (because the clocks are set back) only occur at the first instant.
+type WallClockSchedule struct {
+	clocks   []time.Duration
+	location *time.Location
+	policy   dstPolicy
+}
+
+// At returns a WallClockSchedule with daily occurrences at the given wall-clock times ("09:00", "13:30:15")
+// in the given location. The policy determines how times that do not exist on a day due to DST are handled.
+func At(times []string, loc *time.Location, policy dstPolicy) (*WallClockSchedule, error) {
+	if len(times) == 0 {
+		return nil, errors.New("at least one time of day is required")
+	}
+	if loc == nil {
+		return nil, errors.New("location cannot be nil")
+	}
+	s := WallClockSchedule{location: loc, policy: policy}
+	for _, v := range times {
+		clock, err := parseClock(v)
+		if err != nil {
+			return nil, err
+		}
+		s.clocks = append(s.clocks, clock)
+	}
+	sort.Slice(s.clocks, func(i, j int) bool {
+		return s.clocks[i] < s.clocks[j]
+	})
+	return &s, nil
+}
+
+// Location returns the location the wall-clock times are evaluated in.
+func (s WallClockSchedule) Location() *time.Location {
+	return s.location
+}
+
+// Next returns the time of the first occurrence after the given time.
+func (s WallClockSchedule) Next(t time.Time) *time.Time {
+	year, month, day := t.In(s.location).Date()
+	// Shifted occurrences may move past midnight, so the previous day is considered as well.
+	for d := day - 1; d <= day+2; d++ {
+		var nxt *time.Time
+		for _, clock := range s.clocks {
+			occurrence, ok := wallClock(year, month, d, clock, s.location, s.policy)
+			if ok && occurrence.After(t) && (nxt == nil || occurrence.Before(*nxt)) {
+				nxt = &occurrence
+			}
+		}
+		if nxt != nil {
+			return nxt
+		}
+	}
+	return nil
+}
+
+// parseClock parses a time of day in the format "15:04" or "15:04:05" into the duration since midnight.
+func parseClock(s string) (time.Duration, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+		}
+	}
+	return 0, errors.New("invalid time of day format")
+}
+
+// wallClock returns the instant the wall clock in loc shows the given time of day on the given date.
+// If the time occurs twice the first instant is returned. If it does not exist, it is either shifted forward by the
+// DST gap or false is returned, depending on the policy.
+func wallClock(year int, month time.Month, day int, clock time.Duration, loc *time.Location, policy dstPolicy) (time.Time, bool) {
+	naive := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Add(clock)
+	// The offsets in effect half a day before and after cover any transition on the day.
+	_, offsetBefore := naive.Add(-12 * time.Hour).In(loc).Zone()
+	_, offsetAfter := naive.Add(12 * time.Hour).In(loc).Zone()
+	var result *time.Time
+	for _, offset := range []int{offsetBefore, offsetAfter} {
+		candidate := naive.Add(-time.Duration(offset) * time.Second).In(loc)
+		if !sameWallClock(candidate, naive) {
+			continue
+		}
+		if result == nil || candidate.Before(*result) {
+			result = &candidate
+		}
+	}
+	if result != nil {
+		return *result, true
+	}
+	if policy == DSTSkip {
+		return time.Time{}, false
+	}
+	return naive.Add(-time.Duration(offsetBefore) * time.Second).In(loc), true
+}
+
+func sameWallClock(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd && a.Hour() == b.Hour() && a.Minute() == b.Minute() && a.Second() == b.Second()
+}
+
+// Rebase returns a copy of the schedule evaluating its wall-clock times in the given location.
+// Occurrences are always computed from the wall-clock times, so no absolute times carry over.
+func (s WallClockSchedule) Rebase(loc *time.Location) *WallClockSchedule {
+	s.clocks = append([]time.Duration{}, s.clocks...)
+	s.location = loc
+	return &s
+}
+
+// Reload returns a copy of the schedule with its location reloaded from the tz database by name.
+// Use it after the tz database has been updated at runtime. See: InvalidateLocationCache.
+// Locations whose name cannot be loaded from the tz database, such as fixed zones, are kept, as are time.UTC and
+// time.Local.
+func (s WallClockSchedule) Reload() *WallClockSchedule {
+	name := s.location.String()
+	if s.location == time.UTC || s.location == time.Local || name == "" {
+		return s.Rebase(s.location)
+	}
+	loc, err := loadLocation(name)
+	if err != nil {
+		return s.Rebase(s.location)
+	}
+	return s.Rebase(loc)
+}
@@ -0,0 +1,52 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAt(t *testing.T) {
+	loc := time.FixedZone("CET", 3600)
+	s, err := At([]string{"13:30", "09:00"}, loc, DSTShift)
+	assert.Nil(t, err)
+	assert.Equal(t, loc, s.Location())
+	expectations := map[string]string{
+		"2019-01-02T07:00:00Z": "2019-01-02T09:00:00+01:00",
+		"2019-01-02T08:00:00Z": "2019-01-02T13:30:00+01:00",
+		"2019-01-02T12:30:00Z": "2019-01-03T09:00:00+01:00",
+		"2019-01-02T23:30:00Z": "2019-01-03T09:00:00+01:00",
+	}
+	for given, expected := range expectations {
+		tm, err := time.Parse(time.RFC3339, given)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, s.Next(tm).Format(time.RFC3339), given)
+	}
+
+	for _, times := range [][]string{nil, {"25:00"}, {"9am"}} {
+		_, err := At(times, loc, DSTShift)
+		assert.NotNil(t, err)
+	}
+	_, err = At([]string{"09:00"}, nil, DSTShift)
+	assert.NotNil(t, err)
+}
+
+func TestAt_DST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Copenhagen")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	// 2019-03-31 02:00 -> 03:00 (clocks set forward)
+	shift, err := At([]string{"02:30"}, loc, DSTShift)
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-03-31T03:30:00+02:00", shift.Next(mustTime(t, "2019-03-30T12:00:00Z")).Format(time.RFC3339))
+	skip, err := At([]string{"02:30"}, loc, DSTSkip)
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-04-01T02:30:00+02:00", skip.Next(mustTime(t, "2019-03-30T12:00:00Z")).Format(time.RFC3339))
+
+	// 2019-10-27 03:00 -> 02:00 (clocks set back), 02:30 only occurs at the first instant.
+	nxt := skip.Next(mustTime(t, "2019-10-26T12:00:00Z"))
+	assert.Equal(t, "2019-10-27T02:30:00+02:00", nxt.Format(time.RFC3339))
+	assert.Equal(t, "2019-10-28T02:30:00+01:00", skip.Next(*nxt).Format(time.RFC3339))
+}
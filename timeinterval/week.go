@@ -0,0 +1,77 @@
+package timeinterval
+
+import "time"
+
+// WeekNumbering determines how weeks of a year are numbered for reporting, e.g. "2019-W01".
+// The zero value numbers weeks starting on Sunday with week 1 containing January 1st, as is common in the US.
+type WeekNumbering struct {
+	// WeekStart is the first day of each week.
+	WeekStart time.Weekday
+	// MinDays is the number of days of the new year that week 1 must contain. Values below 1 are treated as 1 and
+	// values above 7 as 7.
+	MinDays int
+}
+
+// ISOWeekNumbering numbers weeks as ISO8601 does: Weeks start on Monday and week 1 contains January 4th
+// (i.e. at least 4 days of the new year). It agrees with time.Time.ISOWeek.
+var ISOWeekNumbering = WeekNumbering{WeekStart: time.Monday, MinDays: 4}
+
+// ISOWeekInterval returns the ISO8601 week of the year in UTC, starting Monday at midnight. Weeks outside the year
+// are normalized like time.Date does, e.g. week 53 of a year with 52 weeks is week 1 of the next year.
+func ISOWeekInterval(year, week int) Interval {
+	return ISOWeekNumbering.Interval(year, week, time.UTC)
+}
+
+// ISOWeekIntervalInLocation is like ISOWeekInterval but returns the week in the given location (time.Local if nil).
+func ISOWeekIntervalInLocation(year, week int, loc *time.Location) Interval {
+	return ISOWeekNumbering.Interval(year, week, loc)
+}
+
+// WeekNumberOf returns the ISO8601 year and week of t in its location. It agrees with time.Time.ISOWeek.
+func WeekNumberOf(t time.Time) (year, week int) {
+	return ISOWeekNumbering.Week(t)
+}
+
+// Week returns the year and week of the date of t in its location. Days before week 1 of a year belong to the last
+// week of the previous year and days after its last week belong to week 1 of the next year.
+func (n WeekNumbering) Week(t time.Time) (year, week int) {
+	year = t.Year()
+	date := time.Date(year, t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if next := n.firstWeek(year + 1); !date.Before(next) {
+		return year + 1, 1
+	}
+	start := n.firstWeek(year)
+	if date.Before(start) {
+		year--
+		start = n.firstWeek(year)
+	}
+	return year, int(date.Sub(start)/durationWeek) + 1
+}
+
+// Interval returns the week of the year in the given location (time.Local if nil), starting at midnight of its
+// first day. Like calendar weeks returned by ThisWeek, it is a Period of 7 days, so it spans DST transitions.
+// Weeks outside the year are normalized. See: ISOWeekInterval
+func (n WeekNumbering) Interval(year, week int, loc *time.Location) Interval {
+	start := n.firstWeek(year).AddDate(0, 0, (week-1)*7)
+	startsAt := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, localIfNil(loc))
+	unit := Period{Days: 7}
+	return Interval{Format: ISOFormatTimeAndDuration, StartsAt: startsAt, EndsAt: unit.AddTo(startsAt), Period: &unit}
+}
+
+// firstWeek returns the first day of week 1 of the year as a date in UTC.
+func (n WeekNumbering) firstWeek(year int) time.Time {
+	minDays := n.MinDays
+	if minDays < 1 {
+		minDays = 1
+	} else if minDays > 7 {
+		minDays = 7
+	}
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// daysBefore is the number of days of the week containing January 1st that belong to the previous year.
+	daysBefore := (int(jan1.Weekday()) - int(n.WeekStart) + 7) % 7
+	start := jan1.AddDate(0, 0, -daysBefore)
+	if 7-daysBefore < minDays {
+		start = start.AddDate(0, 0, 7)
+	}
+	return start
+}
@@ -0,0 +1,74 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekNumberOf(t *testing.T) {
+	// Every day of several decades is numbered like time.Time.ISOWeek and lies within its week.
+	for d := time.Date(1995, 1, 1, 12, 0, 0, 0, time.UTC); d.Year() < 2035; d = d.AddDate(0, 0, 1) {
+		year, week := WeekNumberOf(d)
+		expectedYear, expectedWeek := d.ISOWeek()
+		if !assert.Equal(t, expectedYear, year, d.String()) || !assert.Equal(t, expectedWeek, week, d.String()) {
+			return
+		}
+		if !assert.True(t, ISOWeekInterval(year, week).In(d), d.String()) {
+			return
+		}
+	}
+	// The date is taken in the location of t.
+	year, week := WeekNumberOf(time.Date(2019, 12, 29, 23, 0, 0, 0, time.FixedZone("", -3600)))
+	assert.Equal(t, 2019, year)
+	assert.Equal(t, 52, week)
+}
+
+func TestISOWeekInterval(t *testing.T) {
+	expectations := map[[2]int]string{
+		{2019, 1}:  "2018-12-31T00:00:00Z/P7D",
+		{2019, 52}: "2019-12-23T00:00:00Z/P7D",
+		{2020, 53}: "2020-12-28T00:00:00Z/P7D",
+		{2019, 53}: "2019-12-30T00:00:00Z/P7D",
+		{2020, 0}:  "2019-12-23T00:00:00Z/P7D",
+	}
+	for given, expected := range expectations {
+		iso, err := ISOWeekInterval(given[0], given[1]).ISO8601()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, iso, given)
+	}
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	in := ISOWeekIntervalInLocation(2019, 1, loc)
+	assert.Equal(t, time.Date(2018, 12, 31, 0, 0, 0, 0, loc), in.StartsAt)
+	assert.Equal(t, time.Date(2019, 1, 7, 0, 0, 0, 0, loc), in.EndsAt)
+}
+
+func TestWeekNumbering(t *testing.T) {
+	// Weeks start on Sunday and week 1 contains January 1st.
+	us := WeekNumbering{}
+	expectations := map[string][2]int{
+		"2018-12-29": {2018, 52},
+		"2018-12-30": {2019, 1},
+		"2019-01-05": {2019, 1},
+		"2019-01-06": {2019, 2},
+		"2021-01-01": {2021, 1},
+		"2020-12-26": {2020, 52},
+	}
+	for given, expected := range expectations {
+		d, err := time.Parse("2006-01-02", given)
+		assert.Nil(t, err)
+		year, week := us.Week(d)
+		assert.Equal(t, expected, [2]int{year, week}, given)
+		in := us.Interval(year, week, time.UTC)
+		assert.True(t, in.In(d), given)
+		assert.Equal(t, time.Sunday, in.StartsAt.Weekday(), given)
+	}
+
+	// Week 1 is the first full week of the year.
+	full := WeekNumbering{WeekStart: time.Monday, MinDays: 7}
+	year, week := full.Week(time.Date(2019, 1, 6, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, [2]int{2018, 53}, [2]int{year, week})
+	assert.Equal(t, time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC), full.Interval(2019, 1, time.UTC).StartsAt)
+}
@@ -0,0 +1,27 @@
+package timeinterval
+
+import "time"
+
+// Weight returns the weight of the interval at the given time, which ramps linearly from 0 to 1 during the first
+// rampIn of the interval and from 1 to 0 during its last rampOut, e.g. to gradually shift traffic within a window.
+// The weight is 0 outside the interval. Ramps overlapping in a short interval are both applied, so the weight does
+// not reach 1. Open bounds do not ramp.
+func (in Interval) Weight(t time.Time, rampIn, rampOut time.Duration) float64 {
+	if !in.In(t) {
+		return 0
+	}
+	w := 1.0
+	if rampIn > 0 && !in.OpenStart() {
+		if elapsed := t.Sub(in.StartsAt); elapsed < rampIn {
+			w = float64(elapsed) / float64(rampIn)
+		}
+	}
+	if rampOut > 0 && !in.OpenEnd() {
+		if remaining := in.EndsAt.Sub(t); remaining < rampOut {
+			if out := float64(remaining) / float64(rampOut); out < w {
+				w = out
+			}
+		}
+	}
+	return w
+}
@@ -0,0 +1,38 @@
+package timeinterval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval_Weight(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT10H")
+	expectations := map[time.Duration]float64{
+		-time.Minute:                   0,
+		0:                              0,
+		30 * time.Minute:               0.25,
+		time.Hour:                      0.5,
+		2 * time.Hour:                  1,
+		5 * time.Hour:                  1,
+		9 * time.Hour:                  1,
+		9*time.Hour + 30*time.Minute:   0.5,
+		10 * time.Hour:                 0,
+		10*time.Hour + time.Nanosecond: 0,
+	}
+	for offset, expected := range expectations {
+		assert.Equal(t, expected, in.Weight(in.StartsAt.Add(offset), 2*time.Hour, time.Hour), offset.String())
+	}
+	assert.Equal(t, 1.0, in.Weight(in.StartsAt, 0, 0))
+
+	// Overlapping ramps: the weight peaks below 1.
+	short := MustParseIntervalISO8601("2019-01-02T21:00:00Z/PT1H")
+	assert.Equal(t, 0.5, short.Weight(short.StartsAt.Add(30*time.Minute), time.Hour, time.Hour))
+
+	open := NewOpenEndInterval(in.StartsAt)
+	assert.Equal(t, 0.5, open.Weight(in.StartsAt.Add(time.Hour), 2*time.Hour, time.Hour))
+	assert.Equal(t, 1.0, open.Weight(in.StartsAt.Add(1000*time.Hour), 2*time.Hour, time.Hour))
+	openStart := NewOpenStartInterval(in.EndsAt)
+	assert.Equal(t, 1.0, openStart.Weight(in.StartsAt, 2*time.Hour, time.Hour))
+}
@@ -0,0 +1,93 @@
+package timeinterval
+
+import (
+	"sort"
+	"time"
+)
+
+// WeightedIntervalSet is a set of intervals that apply with the given probability (0 to 1),
+// e.g. the busy times of a calendar with tentative entries.
+type WeightedIntervalSet struct {
+	Intervals []Interval
+	Weight    float64
+}
+
+// WeightedSegment is a segment of time with the combined weight of the sets covering it. See: MergeWeighted.
+type WeightedSegment struct {
+	Interval Interval
+	Weight   float64
+}
+
+// MergeWeighted splits the time covered by the sets into segments with the probability that at least one of the sets
+// covering the segment applies, assuming the sets are independent. For sets of uncertain busy times the weight of
+// a segment is thereby the probability that a resource is busy, and 1 - weight the probability that it is free.
+// Weights are clamped to [0, 1]. Adjacent segments with the same weight are merged and segments are returned in
+// chronological order. Time not covered by any set is not returned.
+func MergeWeighted(sets []WeightedIntervalSet) []WeightedSegment {
+	normalized := make([][]Interval, len(sets))
+	var boundaries []time.Time
+	for i, set := range sets {
+		normalized[i] = normalize(set.Intervals)
+		for _, in := range normalized[i] {
+			boundaries = append(boundaries, in.StartsAt, in.EndsAt)
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+
+	next := make([]int, len(sets))
+	var result []WeightedSegment
+	for k := 0; k+1 < len(boundaries); k++ {
+		startsAt, endsAt := boundaries[k], boundaries[k+1]
+		if !startsAt.Before(endsAt) {
+			continue
+		}
+		covered := false
+		free := 1.0
+		for i, ins := range normalized {
+			for next[i] < len(ins) && !ins[next[i]].EndsAt.After(startsAt) {
+				next[i]++
+			}
+			if next[i] < len(ins) && !ins[next[i]].StartsAt.After(startsAt) {
+				covered = true
+				free *= 1 - clampWeight(sets[i].Weight)
+			}
+		}
+		if !covered {
+			continue
+		}
+		weight := 1 - free
+		last := len(result) - 1
+		if last >= 0 && result[last].Weight == weight && result[last].Interval.EndsAt.Equal(startsAt) {
+			result[last].Interval.EndsAt = endsAt
+			continue
+		}
+		result = append(result, WeightedSegment{
+			Interval: Interval{StartsAt: startsAt, EndsAt: endsAt, Format: ISOFormatTimeAndTime},
+			Weight:   weight,
+		})
+	}
+	return result
+}
+
+// Threshold returns the coverage of the segments with a weight of at least min as plain intervals.
+func Threshold(segments []WeightedSegment, min float64) []Interval {
+	var ins []Interval
+	for _, s := range segments {
+		if s.Weight >= min {
+			ins = append(ins, s.Interval)
+		}
+	}
+	return normalize(ins)
+}
+
+func clampWeight(w float64) float64 {
+	switch {
+	case w < 0:
+		return 0
+	case w > 1:
+		return 1
+	}
+	return w
+}
@@ -0,0 +1,44 @@
+package timeinterval
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeWeighted(t *testing.T) {
+	startsAt := time.Date(2019, 1, 2, 9, 0, 0, 0, time.UTC)
+	in := func(from, to int) Interval {
+		return Interval{Format: ISOFormatTimeAndTime, StartsAt: startsAt.Add(time.Duration(from) * time.Hour), EndsAt: startsAt.Add(time.Duration(to) * time.Hour)}
+	}
+	sets := []WeightedIntervalSet{
+		{Intervals: []Interval{in(0, 2), in(5, 6)}, Weight: 0.5},
+		{Intervals: []Interval{in(1, 3)}, Weight: 0.8},
+		{Intervals: []Interval{in(2, 3), in(3, 4)}, Weight: 0.8},
+		{Intervals: []Interval{in(5, 6)}, Weight: 2},
+	}
+	segments := MergeWeighted(sets)
+	expected := []WeightedSegment{
+		{Interval: in(0, 1), Weight: 0.5},
+		{Interval: in(1, 2), Weight: 0.9},
+		{Interval: in(2, 3), Weight: 0.96},
+		{Interval: in(3, 4), Weight: 0.8},
+		{Interval: in(5, 6), Weight: 1},
+	}
+	assert.Len(t, segments, len(expected))
+	for i, s := range segments {
+		assert.Equal(t, expected[i].Interval, s.Interval)
+		assert.True(t, math.Abs(expected[i].Weight-s.Weight) < 1e-9, s.Interval.String())
+	}
+
+	assert.Equal(t, []Interval{in(1, 4), in(5, 6)}, Threshold(segments, 0.8))
+	assert.Equal(t, []Interval{in(0, 4), in(5, 6)}, Threshold(segments, 0))
+	assert.Empty(t, Threshold(segments, 1.1))
+
+	// Adjacent segments with the same weight are merged.
+	merged := MergeWeighted([]WeightedIntervalSet{{Intervals: []Interval{in(0, 1)}, Weight: 0.5}, {Intervals: []Interval{in(1, 2)}, Weight: 0.5}})
+	assert.Equal(t, []WeightedSegment{{Interval: in(0, 2), Weight: 0.5}}, merged)
+	assert.Empty(t, MergeWeighted(nil))
+}
@@ -0,0 +1,33 @@
+package timeinterval
+
+// The YAML (un)marshalers below follow the gopkg.in/yaml.v2 interfaces, which gopkg.in/yaml.v3 also respects.
+// They make intervals decode from YAML scalars such as "window: 2019-01-02T21:00:00Z/P1D" without depending on
+// a YAML package.
+
+// MarshalYAML marshals Interval into an ISO8601 "interval" string.
+func (in Interval) MarshalYAML() (interface{}, error) {
+	return in.ISO8601()
+}
+
+// UnmarshalYAML unmarshal Interval from an ISO8601 "interval" string.
+func (in *Interval) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return in.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML marshals Repeating into an ISO8601 "repeating interval" string.
+func (in Repeating) MarshalYAML() (interface{}, error) {
+	return in.ISO8601()
+}
+
+// UnmarshalYAML unmarshal Repeating from an ISO8601 "repeating interval" string.
+func (in *Repeating) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return in.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,46 @@
+package timeinterval
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// yamlScalar returns an unmarshal func like the one passed by a YAML decoder for the given scalar.
+func yamlScalar(s string) func(interface{}) error {
+	return func(v interface{}) error {
+		p, ok := v.(*string)
+		if !ok {
+			return errors.New("cannot unmarshal string")
+		}
+		*p = s
+		return nil
+	}
+}
+
+func TestInterval_YAML(t *testing.T) {
+	in := MustParseIntervalISO8601("2019-01-02T21:00:00Z/P1D")
+	v, err := in.MarshalYAML()
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-01-02T21:00:00Z/P1D", v)
+
+	var result Interval
+	assert.Nil(t, result.UnmarshalYAML(yamlScalar("2019-01-02T21:00:00Z/P1D")))
+	assert.Equal(t, *in, result)
+	assert.NotNil(t, result.UnmarshalYAML(yamlScalar("P1D")))
+	assert.NotNil(t, result.UnmarshalYAML(func(interface{}) error { return errors.New("not a scalar") }))
+}
+
+func TestRepeating_YAML(t *testing.T) {
+	r := MustParseRepeatingIntervalISO8601("R5/2019-01-02T21:00:00Z/PT15M")
+	v, err := r.MarshalYAML()
+	assert.Nil(t, err)
+	assert.Equal(t, "R5/2019-01-02T21:00:00Z/PT15M", v)
+
+	result := Repeating{Reference: OccurrenceEnd}
+	assert.Nil(t, result.UnmarshalYAML(yamlScalar("R5/2019-01-02T21:00:00Z/PT15M")))
+	assert.Equal(t, r.Interval, result.Interval)
+	assert.Equal(t, OccurrenceEnd, result.Reference)
+	assert.NotNil(t, result.UnmarshalYAML(yamlScalar("2019-01-02T21:00:00Z/PT15M")))
+}